@@ -10,7 +10,9 @@ import (
 	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/evm"
 	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/fuel"
 	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/initia_minimove"
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/pusher"
 	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/solana"
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/starknet"
 	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/sui"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/pkgerrors"
@@ -27,7 +29,12 @@ func main() {
 		CompletionOptions: cobra.CompletionOptions{
 			HiddenDefaultCmd: true,
 		},
-		PersistentPreRun: func(_ *cobra.Command, _ []string) {
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			err := pusher.ApplyConfig(cmd)
+			if err != nil {
+				return err
+			}
+
 			//nolint:reassign
 			zerolog.TimeFieldFormat = time.RFC3339Nano
 			//nolint:reassign
@@ -44,17 +51,44 @@ func main() {
 
 			// set global log level
 			zerolog.SetGlobalLevel(logLevel)
+
+			return nil
 		},
 	}
 	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Enable verbose logging")
+	rootCmd.PersistentFlags().String(pusher.ConfigFlag, "", pusher.ConfigDesc)
+
+	newPushCmds := []func() *cobra.Command{
+		evm.NewPushCmd,
+		solana.NewPushCmd,
+		sui.NewPushCmd,
+		cosmwasm.NewPushCmd,
+		aptos.NewPushCmd,
+		fuel.NewPushCmd,
+		initia_minimove.NewPushCmd,
+		starknet.NewPushCmd,
+	}
+
+	printConfigCmd := &cobra.Command{
+		Use:   "print-config",
+		Short: "Print the settings a chain subcommand resolves from flags, STORK_ environment variables and the config file",
+	}
+
+	for _, newPushCmd := range newPushCmds {
+		rootCmd.AddCommand(newPushCmd())
+
+		// the same command with the same flags, printing its settings instead of pushing
+		printCmd := newPushCmd()
+		printCmd.Run = func(cmd *cobra.Command, _ []string) {
+			err := pusher.PrintConfig(cmd, cmd.OutOrStdout())
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+		printConfigCmd.AddCommand(printCmd)
+	}
 
-	rootCmd.AddCommand(evm.NewPushCmd())
-	rootCmd.AddCommand(solana.NewPushCmd())
-	rootCmd.AddCommand(sui.NewPushCmd())
-	rootCmd.AddCommand(cosmwasm.NewPushCmd())
-	rootCmd.AddCommand(aptos.NewPushCmd())
-	rootCmd.AddCommand(fuel.NewPushCmd())
-	rootCmd.AddCommand(initia_minimove.NewPushCmd())
+	rootCmd.AddCommand(printConfigCmd)
 
 	err := rootCmd.Execute()
 	if err != nil {
@@ -0,0 +1,232 @@
+package bindings
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrIndexerNotConfigured = errors.New("indexer url not configured")
+	ErrIndexerQueryFailed   = errors.New("indexer query failed")
+	ErrInvalidEventData     = errors.New("invalid event data")
+)
+
+// feedUpdateEventQuery selects feed update events after a (transaction version, event index) cursor in order.
+// Stork emits module events, which have no event handle to page through on the node REST API, so they are read
+// by type from the indexer instead.
+const feedUpdateEventQuery = `query FeedUpdateEvents($type: String!, $version: bigint!, $index: bigint!, $limit: Int!) {
+  events(
+    where: {
+      indexed_type: {_eq: $type}
+      _or: [
+        {transaction_version: {_gt: $version}}
+        {transaction_version: {_eq: $version}, event_index: {_gt: $index}}
+      ]
+    }
+    order_by: [{transaction_version: asc}, {event_index: asc}]
+    limit: $limit
+  ) {
+    transaction_version
+    event_index
+    data
+  }
+}`
+
+// EventCursor identifies the last processed event by its transaction version and index within the transaction.
+type EventCursor struct {
+	TransactionVersion uint64 `json:"transactionVersion"`
+	EventIndex         uint64 `json:"eventIndex"`
+}
+
+type TemporalNumericValueUpdateEvent struct {
+	AssetID EncodedAssetID
+	Value   TemporalNumericValue
+}
+
+type indexerEvent struct {
+	TransactionVersion json.Number     `json:"transaction_version"`
+	EventIndex         json.Number     `json:"event_index"`
+	Data               json.RawMessage `json:"data"`
+}
+
+type indexerResponse struct {
+	Data struct {
+		Events []indexerEvent `json:"events"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+type feedUpdateEventData struct {
+	AssetID struct {
+		Bytes string `json:"bytes"`
+	} `json:"asset_id"`
+	TemporalNumericValue struct {
+		TimestampNs    string `json:"timestamp_ns"`
+		QuantizedValue struct {
+			Magnitude string `json:"magnitude"`
+			Negative  bool   `json:"negative"`
+		} `json:"quantized_value"`
+	} `json:"temporal_numeric_value"`
+}
+
+// LatestEventCursor returns a cursor at the current ledger version, so that only events emitted from now on are read.
+func (sc *StorkContract) LatestEventCursor() (EventCursor, error) {
+	info, err := sc.Client.Info()
+	if err != nil {
+		return EventCursor{}, fmt.Errorf("failed to get ledger info: %w", err)
+	}
+
+	return EventCursor{TransactionVersion: info.LedgerVersion(), EventIndex: math.MaxUint32}, nil
+}
+
+// QueryTemporalNumericValueUpdateEvents returns up to limit feed update events after the cursor in order,
+// along with the cursor of the last returned event.
+func (sc *StorkContract) QueryTemporalNumericValueUpdateEvents(
+	after EventCursor,
+	limit int,
+) ([]TemporalNumericValueUpdateEvent, EventCursor, error) {
+	if sc.IndexerURL == "" {
+		return nil, after, ErrIndexerNotConfigured
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"query": feedUpdateEventQuery,
+		"variables": map[string]any{
+			"type":    sc.feedUpdateEventType(),
+			"version": after.TransactionVersion,
+			"index":   after.EventIndex,
+			"limit":   limit,
+		},
+	})
+	if err != nil {
+		return nil, after, fmt.Errorf("failed to encode indexer query: %w", err)
+	}
+
+	response, err := sc.HTTPClient.Post(sc.IndexerURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, after, fmt.Errorf("failed to query indexer: %w", err)
+	}
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, after, fmt.Errorf("failed to read indexer response: %w", err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, after, fmt.Errorf("%w: status %d: %s", ErrIndexerQueryFailed, response.StatusCode, responseBody)
+	}
+
+	return parseFeedUpdateEvents(responseBody, after)
+}
+
+func (sc *StorkContract) feedUpdateEventType() string {
+	return sc.ContractAddress.String() + "::event::TemporalNumericValueUpdateEvent"
+}
+
+func parseFeedUpdateEvents(
+	responseBody []byte,
+	after EventCursor,
+) ([]TemporalNumericValueUpdateEvent, EventCursor, error) {
+	var parsed indexerResponse
+
+	err := json.Unmarshal(responseBody, &parsed)
+	if err != nil {
+		return nil, after, fmt.Errorf("failed to parse indexer response: %w", err)
+	}
+
+	if len(parsed.Errors) > 0 {
+		return nil, after, fmt.Errorf("%w: %s", ErrIndexerQueryFailed, parsed.Errors[0].Message)
+	}
+
+	cursor := after
+	events := make([]TemporalNumericValueUpdateEvent, 0, len(parsed.Data.Events))
+
+	for _, rawEvent := range parsed.Data.Events {
+		event, err := parseFeedUpdateEvent(rawEvent.Data)
+		if err != nil {
+			return nil, after, err
+		}
+
+		events = append(events, event)
+
+		cursor, err = parseEventCursor(rawEvent)
+		if err != nil {
+			return nil, after, err
+		}
+	}
+
+	return events, cursor, nil
+}
+
+func parseEventCursor(rawEvent indexerEvent) (EventCursor, error) {
+	version, err := strconv.ParseUint(rawEvent.TransactionVersion.String(), 10, 64)
+	if err != nil {
+		return EventCursor{}, fmt.Errorf("%w: transaction version: %w", ErrInvalidEventData, err)
+	}
+
+	index, err := strconv.ParseUint(rawEvent.EventIndex.String(), 10, 64)
+	if err != nil {
+		return EventCursor{}, fmt.Errorf("%w: event index: %w", ErrInvalidEventData, err)
+	}
+
+	return EventCursor{TransactionVersion: version, EventIndex: index}, nil
+}
+
+func parseFeedUpdateEvent(data json.RawMessage) (TemporalNumericValueUpdateEvent, error) {
+	var eventData feedUpdateEventData
+
+	err := json.Unmarshal(data, &eventData)
+	if err != nil {
+		return TemporalNumericValueUpdateEvent{}, fmt.Errorf("%w: %w", ErrInvalidEventData, err)
+	}
+
+	assetIDBytes, err := hex.DecodeString(strings.TrimPrefix(eventData.AssetID.Bytes, "0x"))
+	if err != nil {
+		return TemporalNumericValueUpdateEvent{}, fmt.Errorf("%w: asset id: %w", ErrInvalidEventData, err)
+	}
+
+	var assetID EncodedAssetID
+	if len(assetIDBytes) != len(assetID) {
+		return TemporalNumericValueUpdateEvent{}, fmt.Errorf(
+			"%w: asset id is %d bytes", ErrInvalidEventData, len(assetIDBytes),
+		)
+	}
+
+	copy(assetID[:], assetIDBytes)
+
+	value := eventData.TemporalNumericValue
+
+	timestampNs, err := strconv.ParseUint(value.TimestampNs, 10, 64)
+	if err != nil {
+		return TemporalNumericValueUpdateEvent{}, fmt.Errorf("%w: timestamp: %w", ErrInvalidEventData, err)
+	}
+
+	//nolint:mnd // base number.
+	magnitude, ok := new(big.Int).SetString(value.QuantizedValue.Magnitude, 10)
+	if !ok {
+		return TemporalNumericValueUpdateEvent{}, fmt.Errorf("%w: magnitude", ErrInvalidEventData)
+	}
+
+	return TemporalNumericValueUpdateEvent{
+		AssetID: assetID,
+		Value: TemporalNumericValue{
+			TimestampNs: timestampNs,
+			QuantizedValue: I128{
+				Magnitude: magnitude,
+				Negative:  value.QuantizedValue.Negative,
+			},
+		},
+	}, nil
+}
@@ -26,12 +26,29 @@ var (
 	ErrEmptyResponse  = errors.New("empty response")
 	ErrWrongType      = errors.New("wrong type")
 	ErrTxFailed       = errors.New("transaction failed")
+	ErrNotFeePayerTx  = errors.New("built transaction is not a fee payer transaction")
 )
 
 type StorkContract struct {
-	Client          *aptos.Client
-	Account         *aptos.Account
+	Client  *aptos.Client
+	Account *aptos.Account
+	// FeePayer pays the gas for update transactions sent by Account when set.
+	FeePayer        *aptos.Account
 	ContractAddress aptos.AccountAddress
+	IndexerURL      string
+	HTTPClient      *http.Client
+}
+
+// UpdateResult describes an executed update transaction.
+type UpdateResult struct {
+	Hash         string
+	GasUsed      uint64
+	GasUnitPrice uint64
+}
+
+// Fee returns the fee paid for the transaction in octas.
+func (r UpdateResult) Fee() uint64 {
+	return r.GasUsed * r.GasUnitPrice
 }
 
 type EncodedAssetID [32]byte
@@ -58,13 +75,21 @@ type UpdateData struct {
 	V                               byte
 }
 
-func NewStorkContract(rpcUrl string, contractAddress string, key *crypto.Ed25519PrivateKey) (*StorkContract, error) {
+// NewStorkContract creates a contract client. The indexer url is only needed to read update events, and the fee
+// payer key is optional.
+func NewStorkContract(
+	rpcUrl string,
+	indexerUrl string,
+	contractAddress string,
+	key *crypto.Ed25519PrivateKey,
+	feePayerKey *crypto.Ed25519PrivateKey,
+) (*StorkContract, error) {
 	config := aptos.NetworkConfig{
 		Name:       "",
 		ChainId:    0,
 		NodeUrl:    rpcUrl,
 		FaucetUrl:  "",
-		IndexerUrl: "",
+		IndexerUrl: indexerUrl,
 	}
 
 	// pass custom http client as aptos client calls are not context aware, they hardcode a timeout of 60 seconds
@@ -89,6 +114,14 @@ func NewStorkContract(rpcUrl string, contractAddress string, key *crypto.Ed25519
 		return nil, fmt.Errorf("failed to create account from signer: %w", err)
 	}
 
+	var feePayer *aptos.Account
+	if feePayerKey != nil {
+		feePayer, err = aptos.NewAccountFromSigner(feePayerKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create fee payer account from signer: %w", err)
+		}
+	}
+
 	address := aptos.AccountAddress{}
 
 	err = address.ParseStringRelaxed(contractAddress)
@@ -96,7 +129,14 @@ func NewStorkContract(rpcUrl string, contractAddress string, key *crypto.Ed25519
 		return nil, fmt.Errorf("failed to parse contract address: %w", err)
 	}
 
-	return &StorkContract{Client: client, Account: account, ContractAddress: address}, nil
+	return &StorkContract{
+		Client:          client,
+		Account:         account,
+		FeePayer:        feePayer,
+		ContractAddress: address,
+		IndexerURL:      indexerUrl,
+		HTTPClient:      httpClient,
+	}, nil
 }
 
 // GetMultipleTemporalNumericValuesUnchecked returns the temporal numeric values for the given feed IDs.
@@ -136,7 +176,7 @@ func (sc *StorkContract) GetMultipleTemporalNumericValuesUnchecked(
 }
 
 //nolint:funlen // This is a long function but does related work.
-func (sc *StorkContract) UpdateMultipleTemporalNumericValuesEvm(updateData []UpdateData) (string, error) {
+func (sc *StorkContract) UpdateMultipleTemporalNumericValuesEvm(updateData []UpdateData) (UpdateResult, error) {
 	// Create separate serializers for each vector
 	idsSerializer := bcs.Serializer{}
 	timestampsSerializer := bcs.Serializer{}
@@ -150,7 +190,7 @@ func (sc *StorkContract) UpdateMultipleTemporalNumericValuesEvm(updateData []Upd
 
 	// Serialize each vector with its own serializer
 	if len(updateData) > math.MaxUint32 {
-		return "", ErrInvalidLengths
+		return UpdateResult{}, ErrInvalidLengths
 	}
 
 	//nolint:all // safe to cast to uint32.
@@ -231,26 +271,81 @@ func (sc *StorkContract) UpdateMultipleTemporalNumericValuesEvm(updateData []Upd
 		},
 	}
 
-	submitResponse, err := sc.Client.BuildSignAndSubmitTransaction(
-		sc.Account,
-		aptos.TransactionPayload{Payload: payload},
-	)
+	hash, err := sc.submit(aptos.TransactionPayload{Payload: payload})
 	if err != nil {
-		return "", fmt.Errorf("failed to build sign and submit transaction: %w", err)
+		return UpdateResult{}, err
 	}
 
-	hash := submitResponse.Hash
-
 	tx, err := sc.Client.WaitForTransaction(hash)
 	if err != nil {
-		return "", fmt.Errorf("failed to wait for transaction: %w", err)
+		return UpdateResult{}, fmt.Errorf("failed to wait for transaction: %w", err)
 	}
 
 	if !tx.Success {
-		return "", fmt.Errorf("%s: %w", tx.VmStatus, ErrTxFailed)
+		return UpdateResult{}, fmt.Errorf("%s: %w", tx.VmStatus, ErrTxFailed)
+	}
+
+	return UpdateResult{Hash: tx.Hash, GasUsed: tx.GasUsed, GasUnitPrice: tx.GasUnitPrice}, nil
+}
+
+// submit signs and submits the payload from Account, with FeePayer paying the gas if set, and returns the hash.
+func (sc *StorkContract) submit(payload aptos.TransactionPayload) (string, error) {
+	if sc.FeePayer == nil {
+		submitResponse, err := sc.Client.BuildSignAndSubmitTransaction(sc.Account, payload)
+		if err != nil {
+			return "", fmt.Errorf("failed to build sign and submit transaction: %w", err)
+		}
+
+		return submitResponse.Hash, nil
+	}
+
+	feePayerAddress := sc.FeePayer.AccountAddress()
+
+	rawTxn, err := sc.Client.BuildTransactionMultiAgent(
+		sc.Account.AccountAddress(),
+		payload,
+		aptos.FeePayer(&feePayerAddress),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to build fee payer transaction: %w", err)
+	}
+
+	senderAuth, err := rawTxn.Sign(sc.Account)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign transaction as sender: %w", err)
+	}
+
+	feePayerAuth, err := rawTxn.Sign(sc.FeePayer)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign transaction as fee payer: %w", err)
+	}
+
+	signedTxn, ok := rawTxn.ToFeePayerSignedTransaction(senderAuth, feePayerAuth, []crypto.AccountAuthenticator{})
+	if !ok {
+		return "", ErrNotFeePayerTx
+	}
+
+	submitResponse, err := sc.Client.SubmitTransaction(signedTxn)
+	if err != nil {
+		return "", fmt.Errorf("failed to submit transaction: %w", err)
+	}
+
+	return submitResponse.Hash, nil
+}
+
+// GetBalance returns the APT balance in octas of the account paying for updates.
+func (sc *StorkContract) GetBalance() (uint64, error) {
+	payer := sc.Account
+	if sc.FeePayer != nil {
+		payer = sc.FeePayer
+	}
+
+	balance, err := sc.Client.AccountAPTBalance(payer.AccountAddress())
+	if err != nil {
+		return 0, fmt.Errorf("failed to get account balance: %w", err)
 	}
 
-	return tx.Hash, nil
+	return balance, nil
 }
 
 func (sc *StorkContract) getTemporalNumericValueUnchecked(id EncodedAssetID) (TemporalNumericValue, error) {
@@ -0,0 +1,149 @@
+package aptos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/aptos/bindings"
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/pusher"
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/types"
+)
+
+// DefaultEventPollInterval is how often the indexer is queried for new feed update events.
+const DefaultEventPollInterval = 2 * time.Second
+
+// eventQueryLimit is the number of events requested from the indexer per page.
+const eventQueryLimit = 100
+
+// ListenContractEvents polls the indexer for feed update events, resuming from the persisted cursor.
+// Aptos does not support websocket subscriptions, so events are followed with cursor polling instead.
+func (aci *ContractInteractor) ListenContractEvents(
+	ctx context.Context,
+	ch chan map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue,
+) {
+	if aci.indexerUrl == "" || aci.eventPollInterval <= 0 {
+		aci.logger.Warn().Msg("No indexer configured or event polling disabled, relying on contract polling only")
+
+		return
+	}
+
+	cursor, err := aci.initialEventCursor(ctx)
+	if err != nil {
+		aci.logger.Error().Err(err).Msg("Failed to determine starting event cursor, relying on contract polling only")
+
+		return
+	}
+
+	aci.logger.Info().Dur("interval", aci.eventPollInterval).Msg("Polling contract feed update events")
+
+	ticker := time.NewTicker(aci.eventPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			cursor = aci.pollEvents(ctx, cursor, ch)
+		}
+	}
+}
+
+// initialEventCursor returns the persisted cursor if there is one, and otherwise the current ledger version so
+// that history is not replayed on first start.
+func (aci *ContractInteractor) initialEventCursor(ctx context.Context) (bindings.EventCursor, error) {
+	cursor, found, err := loadEventCursor(aci.eventCursorFile)
+	if err != nil {
+		return bindings.EventCursor{}, err
+	}
+
+	if found {
+		aci.logger.Info().Str("cursorFile", aci.eventCursorFile).Msg("Resuming events from persisted cursor")
+
+		return cursor, nil
+	}
+
+	for {
+		cursor, err = aci.contract.LatestEventCursor()
+		if err == nil {
+			return cursor, nil
+		}
+
+		aci.logger.Warn().Err(err).Msg("Failed to get latest event cursor, retrying")
+
+		select {
+		case <-ctx.Done():
+			return bindings.EventCursor{}, fmt.Errorf("failed to get latest event cursor: %w", ctx.Err())
+		case <-time.After(aci.eventPollInterval):
+		}
+	}
+}
+
+// pollEvents forwards all events after the cursor to the channel and returns the cursor to continue from.
+func (aci *ContractInteractor) pollEvents(
+	ctx context.Context,
+	cursor bindings.EventCursor,
+	ch chan map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue,
+) bindings.EventCursor {
+	for {
+		events, nextCursor, err := aci.contract.QueryTemporalNumericValueUpdateEvents(cursor, eventQueryLimit)
+		if err != nil {
+			aci.logger.Warn().Err(err).Msg("Failed to query feed update events")
+
+			return cursor
+		}
+
+		updates := latestEventValues(events)
+		if len(updates) > 0 {
+			select {
+			case ch <- updates:
+			case <-ctx.Done():
+				return cursor
+			}
+		}
+
+		if nextCursor == cursor {
+			return cursor
+		}
+
+		cursor = nextCursor
+
+		err = pusher.WriteStateFile(aci.eventCursorFile, cursor)
+		if err != nil {
+			aci.logger.Warn().Err(err).Msg("Failed to persist event cursor")
+		}
+
+		if len(events) < eventQueryLimit {
+			return cursor
+		}
+	}
+}
+
+// latestEventValues keeps the newest value per asset from a page of events.
+func latestEventValues(
+	events []bindings.TemporalNumericValueUpdateEvent,
+) map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue {
+	updates := make(map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue)
+
+	for _, event := range events {
+		encodedAssetID := types.InternalEncodedAssetID(event.AssetID)
+
+		existing, ok := updates[encodedAssetID]
+		if ok && existing.TimestampNs >= event.Value.TimestampNs {
+			continue
+		}
+
+		updates[encodedAssetID] = temporalNumericValueToInternal(event.Value)
+	}
+
+	return updates
+}
+
+func loadEventCursor(path string) (bindings.EventCursor, bool, error) {
+	var cursor bindings.EventCursor
+
+	found, err := pusher.ReadStateFile(path, &cursor)
+
+	return cursor, found, err
+}
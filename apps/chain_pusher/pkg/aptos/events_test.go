@@ -0,0 +1,141 @@
+package aptos
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/aptos/bindings"
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/pusher"
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/types"
+	aptos_sdk "github.com/aptos-labs/aptos-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const indexerEventsResponse = `{"data":{"events":[
+{"transaction_version":"100","event_index":2,"data":{
+	"asset_id":{"bytes":"0x0700000000000000000000000000000000000000000000000000000000000000"},
+	"temporal_numeric_value":{"timestamp_ns":"1722632569208762117",
+		"quantized_value":{"magnitude":"1000000000000000000000000000000","negative":true}}}},
+{"transaction_version":101,"event_index":"0","data":{
+	"asset_id":{"bytes":"0x0700000000000000000000000000000000000000000000000000000000000000"},
+	"temporal_numeric_value":{"timestamp_ns":"1722632569208762118",
+		"quantized_value":{"magnitude":"5","negative":false}}}}
+]}}`
+
+func TestQueryTemporalNumericValueUpdateEvents(t *testing.T) {
+	t.Parallel()
+
+	var request struct {
+		Variables map[string]any `json:"variables"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		_, _ = w.Write([]byte(indexerEventsResponse))
+	}))
+	defer server.Close()
+
+	contractAddress := aptos_sdk.AccountAddress{}
+	require.NoError(t, contractAddress.ParseStringRelaxed("0x1"))
+
+	contract := &bindings.StorkContract{
+		ContractAddress: contractAddress,
+		IndexerURL:      server.URL,
+		HTTPClient:      server.Client(),
+	}
+
+	events, cursor, err := contract.QueryTemporalNumericValueUpdateEvents(
+		bindings.EventCursor{TransactionVersion: 99, EventIndex: 4}, 10,
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "0x1::event::TemporalNumericValueUpdateEvent", request.Variables["type"])
+	assert.InDelta(t, 99, request.Variables["version"], 0)
+	assert.InDelta(t, 4, request.Variables["index"], 0)
+
+	magnitude, ok := new(big.Int).SetString("1000000000000000000000000000000", 10)
+	require.True(t, ok)
+
+	var assetID bindings.EncodedAssetID
+	assetID[0] = 7
+
+	require.Len(t, events, 2)
+	assert.Equal(t, bindings.TemporalNumericValueUpdateEvent{
+		AssetID: assetID,
+		Value: bindings.TemporalNumericValue{
+			TimestampNs:    1722632569208762117,
+			QuantizedValue: bindings.I128{Magnitude: magnitude, Negative: true},
+		},
+	}, events[0])
+	assert.Equal(t, bindings.EventCursor{TransactionVersion: 101, EventIndex: 0}, cursor)
+
+	updates := latestEventValues(events)
+	assert.Equal(t, map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue{
+		types.InternalEncodedAssetID(assetID): {TimestampNs: 1722632569208762118, QuantizedValue: big.NewInt(5)},
+	}, updates)
+}
+
+func TestQueryTemporalNumericValueUpdateEventsErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		status   int
+		response string
+		wantErr  error
+	}{
+		{"graphql error", http.StatusOK, `{"errors":[{"message":"field not found"}]}`, bindings.ErrIndexerQueryFailed},
+		{"http error", http.StatusBadGateway, `bad gateway`, bindings.ErrIndexerQueryFailed},
+		{
+			"short asset id",
+			http.StatusOK,
+			`{"data":{"events":[{"transaction_version":"1","event_index":"0","data":{"asset_id":{"bytes":"0x07"}}}]}}`,
+			bindings.ErrInvalidEventData,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			contract := &bindings.StorkContract{IndexerURL: server.URL, HTTPClient: server.Client()}
+			after := bindings.EventCursor{TransactionVersion: 5, EventIndex: 1}
+
+			_, cursor, err := contract.QueryTemporalNumericValueUpdateEvents(after, 10)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, after, cursor)
+		})
+	}
+
+	_, _, err := (&bindings.StorkContract{}).QueryTemporalNumericValueUpdateEvents(bindings.EventCursor{}, 10)
+	require.ErrorIs(t, err, bindings.ErrIndexerNotConfigured)
+}
+
+func TestEventCursorPersistence(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cursor.json")
+
+	_, found, err := loadEventCursor(path)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	expected := bindings.EventCursor{TransactionVersion: 123456789, EventIndex: 3}
+	require.NoError(t, pusher.WriteStateFile(path, expected))
+
+	cursor, found, err := loadEventCursor(path)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, expected, cursor)
+}
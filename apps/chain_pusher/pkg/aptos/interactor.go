@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"time"
 
 	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/aptos/bindings"
 	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/pusher"
@@ -17,9 +18,13 @@ import (
 type ContractInteractor struct {
 	logger zerolog.Logger
 
-	pollingPeriodSec int
-	privateKey       *crypto.Ed25519PrivateKey
-	contractAddress  string
+	pollingPeriodSec  int
+	privateKey        *crypto.Ed25519PrivateKey
+	feePayerKey       *crypto.Ed25519PrivateKey
+	contractAddress   string
+	indexerUrl        string
+	eventPollInterval time.Duration
+	eventCursorFile   string
 
 	contract *bindings.StorkContract
 }
@@ -29,6 +34,10 @@ func NewContractInteractor(
 	keyFileContent []byte,
 	pollingPeriodSec int,
 	logger zerolog.Logger,
+	feePayerKeyFileContent []byte,
+	indexerUrl string,
+	eventPollInterval time.Duration,
+	eventCursorFile string,
 ) (*ContractInteractor, error) {
 	logger = logger.With().Str("component", "aptos-contract-interactor").Logger()
 
@@ -37,17 +46,31 @@ func NewContractInteractor(
 		return nil, err
 	}
 
+	var feePayerKey *crypto.Ed25519PrivateKey
+	if len(feePayerKeyFileContent) > 0 {
+		feePayerKey, err = loadPrivateKey(feePayerKeyFileContent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load fee payer key: %w", err)
+		}
+	}
+
 	return &ContractInteractor{
-		logger:           logger,
-		contract:         nil,
-		pollingPeriodSec: pollingPeriodSec,
-		privateKey:       privateKey,
-		contractAddress:  contractAddr,
+		logger:            logger,
+		contract:          nil,
+		pollingPeriodSec:  pollingPeriodSec,
+		privateKey:        privateKey,
+		feePayerKey:       feePayerKey,
+		contractAddress:   contractAddr,
+		indexerUrl:        indexerUrl,
+		eventPollInterval: eventPollInterval,
+		eventCursorFile:   eventCursorFile,
 	}, nil
 }
 
 func (aci *ContractInteractor) ConnectHTTP(_ context.Context, url string) error {
-	contract, err := bindings.NewStorkContract(url, aci.contractAddress, aci.privateKey)
+	contract, err := bindings.NewStorkContract(
+		url, aci.indexerUrl, aci.contractAddress, aci.privateKey, aci.feePayerKey,
+	)
 	if err != nil {
 		return fmt.Errorf("failed to create stork contract: %w", err)
 	}
@@ -62,15 +85,6 @@ func (aci *ContractInteractor) ConnectWs(ctx context.Context, url string) error
 	return nil
 }
 
-// ListenContractEvents is a placeholder function for the contract interactor.
-// unfortunately, Aptos doesn't currently support websocket RPCs, so we can't listen to events from the contract
-// this contract does emit events, so this can be implemented in the future if Aptos re-adds websocket support.
-func (aci *ContractInteractor) ListenContractEvents(
-	ctx context.Context, ch chan map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue,
-) {
-	aci.logger.Warn().Msg("Aptos does not currently support listening to events via websocket, falling back to polling")
-}
-
 func (aci *ContractInteractor) PullValues(
 	_ context.Context, // this satisfies the interface but is not used as aptos client calls are not context aware
 	encodedAssetIDs []types.InternalEncodedAssetID,
@@ -92,20 +106,7 @@ func (aci *ContractInteractor) PullValues(
 
 	for _, encodedAssetID := range encodedAssetIDs {
 		if value, ok := values[bindings.EncodedAssetID(encodedAssetID)]; ok {
-			magnitude := value.QuantizedValue.Magnitude
-			negative := value.QuantizedValue.Negative
-
-			signMultiplier := 1
-			if negative {
-				signMultiplier = -1
-			}
-
-			quantizedValue := new(big.Int).Mul(magnitude, big.NewInt(int64(signMultiplier)))
-
-			result[encodedAssetID] = types.InternalTemporalNumericValue{
-				TimestampNs:    value.TimestampNs,
-				QuantizedValue: quantizedValue,
-			}
+			result[encodedAssetID] = temporalNumericValueToInternal(value)
 		}
 	}
 
@@ -127,7 +128,7 @@ func (aci *ContractInteractor) BatchPushToContract(
 		updateData = append(updateData, update)
 	}
 
-	hash, err := aci.contract.UpdateMultipleTemporalNumericValuesEvm(updateData)
+	result, err := aci.contract.UpdateMultipleTemporalNumericValuesEvm(updateData)
 	if err != nil {
 		aci.logger.Error().Err(err).Msg("failed to update multiple temporal numeric values")
 
@@ -136,18 +137,41 @@ func (aci *ContractInteractor) BatchPushToContract(
 
 	aci.logger.Debug().
 		Int("numUpdates", len(priceUpdates)).
-		Str("txnHash", hash).
+		Str("txnHash", result.Hash).
+		Uint64("gasUsed", result.GasUsed).
+		Uint64("gasUnitPrice", result.GasUnitPrice).
+		Uint64("feeOctas", result.Fee()).
 		Msg("Successfully pushed batch update to contract")
 
 	return nil
 }
 
-// GetWalletBalance is a placeholder function to get the balance of the wallet being used to push to the contract.
-// todo: implement
-//
-//nolint:godox // This function has unmet criteria to be implemented.
-func (aci *ContractInteractor) GetWalletBalance(ctx context.Context) (float64, error) {
-	return -1, nil
+// GetWalletBalance returns the APT balance in octas of the account paying for updates, which is the fee payer
+// if one is configured.
+func (aci *ContractInteractor) GetWalletBalance(_ context.Context) (float64, error) {
+	balance, err := aci.contract.GetBalance()
+	if err != nil {
+		return -1, fmt.Errorf("failed to get wallet balance: %w", err)
+	}
+
+	return float64(balance), nil
+}
+
+func temporalNumericValueToInternal(value bindings.TemporalNumericValue) types.InternalTemporalNumericValue {
+	magnitude := value.QuantizedValue.Magnitude
+	negative := value.QuantizedValue.Negative
+
+	signMultiplier := 1
+	if negative {
+		signMultiplier = -1
+	}
+
+	quantizedValue := new(big.Int).Mul(magnitude, big.NewInt(int64(signMultiplier)))
+
+	return types.InternalTemporalNumericValue{
+		TimestampNs:    value.TimestampNs,
+		QuantizedValue: quantizedValue,
+	}
 }
 
 func aggregatedSignedPriceToUpdateData(
@@ -24,6 +24,16 @@ func NewPushCmd() *cobra.Command {
 	pushCmd.Flags().IntP(pusher.BatchingWindowFlag, "b", pusher.DefaultBatchingWindow, pusher.BatchingWindowDesc)
 	pushCmd.Flags().String(pusher.BatchingWindowStrFlag, "", pusher.BatchingWindowStrDesc)
 	pushCmd.Flags().IntP(pusher.PollingPeriodFlag, "p", pusher.DefaultPollingPeriod, pusher.PollingPeriodDesc)
+	pushCmd.Flags().Float64(pusher.MaxSpendPerHourFlag, 0, pusher.MaxSpendPerHourDesc)
+	pushCmd.Flags().Float64(pusher.MaxSpendPerDayFlag, 0, pusher.MaxSpendPerDayDesc)
+	pushCmd.Flags().String(pusher.SpendStateFileFlag, "", pusher.SpendStateFileDesc)
+	pushCmd.Flags().String(pusher.MetricsAddressFlag, "", pusher.MetricsAddressDesc)
+	pushCmd.Flags().Int(pusher.MaxAssetsPerWindowFlag, 0, pusher.MaxAssetsPerWindowDesc)
+	pushCmd.Flags().BoolP(pusher.VerifyPublishersFlag, "v", false, pusher.VerifyPublishersDesc)
+	pushCmd.Flags().String(pusher.IndexerUrlFlag, "", pusher.IndexerUrlDesc)
+	pushCmd.Flags().Duration(pusher.EventPollIntervalFlag, DefaultEventPollInterval, pusher.EventPollIntervalDesc)
+	pushCmd.Flags().String(pusher.EventCursorFileFlag, "", pusher.EventCursorFileDesc)
+	pushCmd.Flags().String(pusher.FeePayerKeyFileFlag, "", pusher.FeePayerKeyFileDesc)
 
 	pushCmd.MarkFlagsMutuallyExclusive(pusher.BatchingWindowFlag, pusher.BatchingWindowStrFlag)
 
@@ -47,6 +57,16 @@ func runPush(cmd *cobra.Command, args []string) {
 	batchingWindow, _ := cmd.Flags().GetInt(pusher.BatchingWindowFlag)
 	batchingWindowStr, _ := cmd.Flags().GetString(pusher.BatchingWindowStrFlag)
 	pollingPeriod, _ := cmd.Flags().GetInt(pusher.PollingPeriodFlag)
+	maxSpendPerHour, _ := cmd.Flags().GetFloat64(pusher.MaxSpendPerHourFlag)
+	maxSpendPerDay, _ := cmd.Flags().GetFloat64(pusher.MaxSpendPerDayFlag)
+	spendStateFile, _ := cmd.Flags().GetString(pusher.SpendStateFileFlag)
+	metricsAddress, _ := cmd.Flags().GetString(pusher.MetricsAddressFlag)
+	maxAssetsPerWindow, _ := cmd.Flags().GetInt(pusher.MaxAssetsPerWindowFlag)
+	verifyPublishers, _ := cmd.Flags().GetBool(pusher.VerifyPublishersFlag)
+	indexerUrl, _ := cmd.Flags().GetString(pusher.IndexerUrlFlag)
+	eventPollInterval, _ := cmd.Flags().GetDuration(pusher.EventPollIntervalFlag)
+	eventCursorFile, _ := cmd.Flags().GetString(pusher.EventCursorFileFlag)
+	feePayerKeyFile, _ := cmd.Flags().GetString(pusher.FeePayerKeyFileFlag)
 
 	logger := PusherLogger(chainRpcUrl, contractAddress)
 
@@ -55,11 +75,35 @@ func runPush(cmd *cobra.Command, args []string) {
 		logger.Fatal().Err(err).Msg("Failed to read private key file")
 	}
 
-	interactor, err := NewContractInteractor(contractAddress, keyFileContent, pollingPeriod, logger)
+	var feePayerKeyFileContent []byte
+	if feePayerKeyFile != "" {
+		feePayerKeyFileContent, err = os.ReadFile(feePayerKeyFile)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to read fee payer key file")
+		}
+	}
+
+	interactor, err := NewContractInteractor(
+		contractAddress,
+		keyFileContent,
+		pollingPeriod,
+		logger,
+		feePayerKeyFileContent,
+		indexerUrl,
+		eventPollInterval,
+		eventCursorFile,
+	)
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Failed to initialize contract interactor")
 	}
 
+	pusher.StartMetricsServer(metricsAddress, logger)
+
+	budget, err := pusher.NewSpendBudget(maxSpendPerHour, maxSpendPerDay, spendStateFile, logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to initialize spend budget")
+	}
+
 	pusher := pusher.NewPusher(
 		storkWsEndpoint,
 		storkAuth,
@@ -70,7 +114,10 @@ func runPush(cmd *cobra.Command, args []string) {
 		batchingWindowStr,
 		batchingWindow,
 		pollingPeriod,
+		verifyPublishers,
+		maxAssetsPerWindow,
 		interactor,
+		budget,
 		&logger,
 	)
 	pusher.Run(context.Background())
@@ -0,0 +1,113 @@
+// Package cometbft contains helpers shared by the pushers of CometBFT based chains.
+package cometbft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/rpc/client/http"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/rs/zerolog"
+)
+
+var ErrConnectionLost = errors.New("websocket connection lost")
+
+// DefaultReconnectDelay is how long to wait before resubscribing after the websocket connection is lost.
+const DefaultReconnectDelay = 5 * time.Second
+
+const (
+	websocketEndpoint = "/websocket"
+	subscriberName    = "stork-chain-pusher"
+	eventBufferSize   = 64
+)
+
+// TxEventSubscriber follows the transactions matching a CometBFT event query over the /websocket endpoint.
+type TxEventSubscriber struct {
+	url            string
+	query          string
+	reconnectDelay time.Duration
+	logger         zerolog.Logger
+}
+
+// NewTxEventSubscriber creates a subscriber for the query, e.g. tm.event='Tx' AND wasm._contract_address='...'.
+// The url is the CometBFT RPC url, http(s) urls are switched to ws(s) by the client.
+func NewTxEventSubscriber(
+	url string,
+	query string,
+	reconnectDelay time.Duration,
+	logger zerolog.Logger,
+) *TxEventSubscriber {
+	return &TxEventSubscriber{
+		url:            url,
+		query:          query,
+		reconnectDelay: reconnectDelay,
+		logger:         logger,
+	}
+}
+
+// Run passes the ABCI events of every matching transaction to handle until the context is done. The client
+// resubscribes by itself after short drops, and once it gives up, Run reconnects with a new client.
+func (s *TxEventSubscriber) Run(ctx context.Context, handle func(events []abci.Event)) {
+	for {
+		err := s.subscribe(ctx, handle)
+		if ctx.Err() != nil {
+			return
+		}
+
+		s.logger.Warn().Err(err).Dur("delay", s.reconnectDelay).Msg("Event subscription dropped, reconnecting")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.reconnectDelay):
+		}
+	}
+}
+
+func (s *TxEventSubscriber) subscribe(ctx context.Context, handle func(events []abci.Event)) error {
+	client, err := http.New(s.url, websocketEndpoint)
+	if err != nil {
+		return fmt.Errorf("failed to create websocket client: %w", err)
+	}
+
+	err = client.Start()
+	if err != nil {
+		return fmt.Errorf("failed to start websocket client: %w", err)
+	}
+
+	defer func() {
+		_ = client.Stop()
+	}()
+
+	results, err := client.Subscribe(ctx, subscriberName, s.query, eventBufferSize)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe: %w", err)
+	}
+
+	s.logger.Info().Str("query", s.query).Msg("Subscribed to contract events")
+
+	// the results channel is never closed, so the client is checked periodically to notice when it gives up
+	ticker := time.NewTicker(s.reconnectDelay)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("subscription cancelled: %w", ctx.Err())
+		case <-ticker.C:
+			if !client.IsRunning() {
+				return ErrConnectionLost
+			}
+		case result := <-results:
+			txEvent, ok := result.Data.(cmttypes.EventDataTx)
+			if !ok {
+				continue
+			}
+
+			handle(txEvent.Result.Events)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package cometbft
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testQuery = "tm.event='Tx' AND wasm._contract_address='contract'"
+
+// txEventMessage is a subscription result as sent by CometBFT for a transaction with a single event.
+const txEventMessage = `{"jsonrpc":"2.0","id":%s,"result":{"query":"` + testQuery + `","data":{
+"type":"tendermint/event/Tx","value":{"TxResult":{"height":"10","index":0,"tx":null,"result":{
+"events":[{"type":"wasm","attributes":[{"key":"_contract_address","value":"contract","index":true}]}]}}}},
+"events":{}}}`
+
+func TestTxEventSubscriberRun(t *testing.T) {
+	t.Parallel()
+
+	upgrader := websocket.Upgrader{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+
+			var request struct {
+				ID     json.RawMessage `json:"id"`
+				Method string          `json:"method"`
+			}
+
+			if json.Unmarshal(message, &request) != nil || request.Method != "subscribe" {
+				continue
+			}
+
+			_ = conn.WriteMessage(websocket.TextMessage, []byte(
+				`{"jsonrpc":"2.0","id":`+string(request.ID)+`,"result":{}}`,
+			))
+			_ = conn.WriteMessage(websocket.TextMessage, fmt.Appendf(nil, txEventMessage, request.ID))
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	received := make(chan []abci.Event, 1)
+	subscriber := NewTxEventSubscriber(server.URL, testQuery, time.Second, zerolog.Nop())
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		subscriber.Run(ctx, func(events []abci.Event) {
+			select {
+			case received <- events:
+			default:
+			}
+		})
+	}()
+
+	select {
+	case events := <-received:
+		require.Len(t, events, 1)
+		assert.Equal(t, "wasm", events[0].Type)
+		assert.Equal(t, "contract", events[0].Attributes[0].Value)
+	case <-ctx.Done():
+		t.Fatal("no event received")
+	}
+
+	cancel()
+	<-done
+}
@@ -10,6 +10,9 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"regexp"
+	"strconv"
+	"sync"
 
 	cosmossdk_io_math "cosmossdk.io/math"
 	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
@@ -23,9 +26,11 @@ import (
 	keyring "github.com/cosmos/cosmos-sdk/crypto/keyring"
 	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
 	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/types/tx/signing"
 	"github.com/cosmos/cosmos-sdk/x/auth/tx"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 )
 
@@ -35,8 +40,16 @@ var (
 	ErrNoAccountFound        = errors.New("no account found")
 	ErrQueryFailed           = errors.New("query failed")
 	ErrTxFailed              = errors.New("transaction failed")
+	ErrSequenceMismatch      = errors.New("account sequence mismatch")
+	ErrInvalidGranter        = errors.New("invalid granter address")
 )
 
+// maxSequenceMismatchRetries is how many times a transaction is rebuilt after an account sequence mismatch.
+const maxSequenceMismatchRetries = 3
+
+// expectedSequencePattern matches the sequence the ante handler expected in a sequence mismatch error.
+var expectedSequencePattern = regexp.MustCompile(`account sequence mismatch, expected (\d+)`)
+
 // Addr is a type representing an address.
 type Addr string
 
@@ -132,10 +145,21 @@ type ExecMsg_UpdateTemporalNumericValuesEvm struct {
 type StorkContract struct {
 	ContractAddress string
 	ChainPrefix     string
+	// AuthzGranter, if set, is the account updates are executed on behalf of through an authz MsgExec, so that it
+	// pays the update fees.
+	AuthzGranter sdktypes.AccAddress
+	// FeeGranter, if set, is the account paying the gas through an x/feegrant allowance.
+	FeeGranter      sdktypes.AccAddress
 	singleUpdateFee Coin
 	clientCtx       sdkclient.Context
 	txf             sdkclient_tx.Factory
 	marshaler       codec.Codec
+
+	// txMu serializes transactions, so that the locally tracked sequence stays consistent.
+	txMu          sync.Mutex
+	accountNumber uint64
+	sequence      uint64
+	sequenceKnown bool
 }
 
 func NewStorkContract(
@@ -148,7 +172,19 @@ func NewStorkContract(
 	denom string,
 	chainID string,
 	chainPrefix string,
+	authzGranter string,
+	feeGranter string,
 ) (*StorkContract, error) {
+	authzGranterAddr, err := parseOptionalAddress(authzGranter, chainPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("%w: authz granter: %w", ErrInvalidGranter, err)
+	}
+
+	feeGranterAddr, err := parseOptionalAddress(feeGranter, chainPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("%w: fee granter: %w", ErrInvalidGranter, err)
+	}
+
 	rpcClient, err := http.New(rpcUrl, "/websocket")
 	if err != nil {
 		return nil, fmt.Errorf("failed to create rpc http client: %w", err)
@@ -164,7 +200,12 @@ func NewStorkContract(
 	privKey := secp256k1.PrivKey{Key: derivedPrivKey[:32]}
 
 	//nolint:exhaustruct // all fields are set in the constructor.
-	storkContract := &StorkContract{ContractAddress: contractAddress, ChainPrefix: chainPrefix}
+	storkContract := &StorkContract{
+		ContractAddress: contractAddress,
+		ChainPrefix:     chainPrefix,
+		AuthzGranter:    authzGranterAddr,
+		FeeGranter:      feeGranterAddr,
+	}
 
 	// set up execution context and factory
 	interfaceRegistry := codectypes.NewInterfaceRegistry()
@@ -172,6 +213,7 @@ func NewStorkContract(
 	authtypes.RegisterInterfaces(interfaceRegistry)
 	cryptocodec.RegisterInterfaces(interfaceRegistry)
 	wasmtypes.RegisterInterfaces(interfaceRegistry)
+	authz.RegisterInterfaces(interfaceRegistry)
 
 	marshaler := codec.NewProtoCodec(interfaceRegistry)
 	storkContract.marshaler = marshaler
@@ -215,6 +257,7 @@ func NewStorkContract(
 		WithAccountRetriever(storkContract.clientCtx.AccountRetriever).
 		WithKeybase(kr).
 		WithFromName(keyName).
+		WithFeeGranter(feeGranterAddr).
 		WithSimulateAndExecute(true)
 
 	singleUpdateFee, err := storkContract.GetSingleUpdateFee(ctx)
@@ -335,8 +378,19 @@ func (s *StorkContract) queryContract(ctx context.Context, rawQueryData []byte)
 	return resp.Data, nil
 }
 
+// GetWalletBalance returns the balance of the account paying for updates, which is the fee granter if set, then
+// the authz granter if set, and otherwise the pusher's own account.
 func (s *StorkContract) GetWalletBalance(ctx context.Context, denom string) (float64, error) {
-	addr, err := sdktypes.Bech32ifyAddressBytes(s.ChainPrefix, s.clientCtx.FromAddress)
+	payer := s.clientCtx.FromAddress
+
+	switch {
+	case !s.FeeGranter.Empty():
+		payer = s.FeeGranter
+	case !s.AuthzGranter.Empty():
+		payer = s.AuthzGranter
+	}
+
+	addr, err := sdktypes.Bech32ifyAddressBytes(s.ChainPrefix, payer)
 	if err != nil {
 		return 0, fmt.Errorf("failed to bech32ify address: %w", err)
 	}
@@ -380,27 +434,146 @@ func (s *StorkContract) GetWalletBalance(ctx context.Context, denom string) (flo
 	return balanceFloat, nil
 }
 
-//nolint:cyclop,funlen // permissible complexity and funlen for this function due to lack of nesting.
+// executeContract executes the contract, through authz if a granter is configured, and rebuilds the transaction
+// with a fresh sequence when it is rejected for an account sequence mismatch.
 func (s *StorkContract) executeContract(
 	ctx context.Context,
 	rawExecData []byte,
 	funds []sdktypes.Coin,
 ) (string, error) {
+	msg, err := s.buildExecuteMsg(rawExecData, funds)
+	if err != nil {
+		return "", err
+	}
+
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	for attempt := 0; ; attempt++ {
+		txHash, err := s.signAndBroadcast(ctx, msg)
+		if err == nil {
+			s.sequence++
+
+			return txHash, nil
+		}
+
+		if !errors.Is(err, ErrSequenceMismatch) || attempt >= maxSequenceMismatchRetries {
+			// a rejected transaction does not consume the sequence, but re-query in case it was not the cause
+			s.sequenceKnown = false
+
+			return "", err
+		}
+
+		expected, ok := expectedSequence(err.Error())
+		if ok {
+			s.sequence = expected
+		} else {
+			s.sequenceKnown = false
+		}
+	}
+}
+
+// buildExecuteMsg wraps the contract execution in an authz MsgExec when executing on behalf of a granter.
+func (s *StorkContract) buildExecuteMsg(rawExecData []byte, funds []sdktypes.Coin) (sdktypes.Msg, error) {
 	senderBech32, err := sdktypes.Bech32ifyAddressBytes(s.ChainPrefix, s.clientCtx.FromAddress)
 	if err != nil {
-		return "", fmt.Errorf("failed to bech32ify address: %w", err)
+		return nil, fmt.Errorf("failed to bech32ify address: %w", err)
+	}
+
+	if s.AuthzGranter.Empty() {
+		return &wasmtypes.MsgExecuteContract{
+			Sender:   senderBech32,
+			Contract: s.ContractAddress,
+			Msg:      rawExecData,
+			Funds:    funds,
+		}, nil
+	}
+
+	granterBech32, err := sdktypes.Bech32ifyAddressBytes(s.ChainPrefix, s.AuthzGranter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bech32ify granter address: %w", err)
 	}
 
-	msg := &wasmtypes.MsgExecuteContract{
-		Sender:   senderBech32,
+	executeMsg, err := codectypes.NewAnyWithValue(&wasmtypes.MsgExecuteContract{
+		Sender:   granterBech32,
 		Contract: s.ContractAddress,
 		Msg:      rawExecData,
 		Funds:    funds,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack execute message: %w", err)
+	}
+
+	// built directly rather than with authz.NewMsgExec, which formats the grantee with the global bech32 prefix
+	return &authz.MsgExec{
+		Grantee: senderBech32,
+		Msgs:    []*codectypes.Any{executeMsg},
+	}, nil
+}
+
+func (s *StorkContract) signAndBroadcast(ctx context.Context, msg sdktypes.Msg) (string, error) {
+	if !s.sequenceKnown {
+		acc, err := s.queryAccount(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		s.accountNumber = acc.GetAccountNumber()
+		s.sequence = acc.GetSequence()
+		s.sequenceKnown = true
+	}
+
+	txf := s.txf.
+		WithAccountNumber(s.accountNumber).
+		WithSequence(s.sequence)
+
+	_, adjusted, err := sdkclient_tx.CalculateGas(s.clientCtx, txf, msg)
+	if err != nil {
+		return "", fmt.Errorf("failed to calculate gas: %w", wrapSequenceMismatch(err))
 	}
 
+	txf = txf.WithGas(adjusted)
+
+	tx, err := txf.BuildUnsignedTx(msg)
+	if err != nil {
+		return "", fmt.Errorf("failed to build unsigned transaction: %w", err)
+	}
+
+	err = sdkclient_tx.Sign(ctx, txf, s.clientCtx.FromName, tx, true)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	encoder := s.clientCtx.TxConfig.TxEncoder()
+	if encoder == nil {
+		return "", ErrNilTxEncoder
+	}
+
+	txBytes, err := encoder(tx.GetTx())
+	if err != nil {
+		return "", fmt.Errorf("failed to encode transaction: %w", err)
+	}
+
+	res, err := s.clientCtx.BroadcastTx(txBytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to broadcast transaction: %w", err)
+	}
+
+	if res.Code == sdkerrors.ErrWrongSequence.ABCICode() && res.Codespace == sdkerrors.ErrWrongSequence.Codespace() {
+		return "", fmt.Errorf("%w: %s", ErrSequenceMismatch, res.RawLog)
+	}
+
+	if res.Code != 0 {
+		return "", fmt.Errorf("%w: code=%d codespace=%s log=%s", ErrTxFailed, res.Code, res.Codespace, res.RawLog)
+	}
+
+	return res.TxHash, nil
+}
+
+func (s *StorkContract) queryAccount(ctx context.Context) (sdktypes.AccountI, error) {
 	senderBech32Acc, err := sdktypes.Bech32ifyAddressBytes(s.ChainPrefix, s.clientCtx.FromAddress)
 	if err != nil {
-		return "", fmt.Errorf("%w: failed to bech32ify account address", err)
+		return nil, fmt.Errorf("%w: failed to bech32ify account address", err)
 	}
 
 	accMsg := &authtypes.QueryAccountRequest{
@@ -409,7 +582,7 @@ func (s *StorkContract) executeContract(
 
 	rawAccMsg, err := s.marshaler.Marshal(accMsg)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal account message: %w", err)
+		return nil, fmt.Errorf("failed to marshal account message: %w", err)
 	}
 
 	result, err := s.clientCtx.Client.ABCIQuery(
@@ -418,75 +591,73 @@ func (s *StorkContract) executeContract(
 		rawAccMsg,
 	)
 	if err != nil {
-		return "", fmt.Errorf("failed to query account: %w", err)
+		return nil, fmt.Errorf("failed to query account: %w", err)
 	}
 
 	if result.Response.Value == nil {
-		return "", ErrNoAccountFound
+		return nil, ErrNoAccountFound
 	}
 
 	var resp authtypes.QueryAccountResponse
 
 	err = s.marshaler.Unmarshal(result.Response.Value, &resp)
 	if err != nil {
-		return "", fmt.Errorf("failed to unmarshal account: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal account: %w", err)
 	}
 
 	if resp.Account == nil {
-		return "", ErrNoAccountFound
+		return nil, ErrNoAccountFound
 	}
 
 	var acc sdktypes.AccountI
 
 	err = s.clientCtx.InterfaceRegistry.UnpackAny(resp.Account, &acc)
 	if err != nil {
-		return "", fmt.Errorf("failed to unpack account: %w", err)
+		return nil, fmt.Errorf("failed to unpack account: %w", err)
 	}
 
 	if acc == nil {
-		return "", ErrFailedToDecodeAccount
+		return nil, ErrFailedToDecodeAccount
 	}
 
-	txf := s.txf.
-		WithAccountNumber(acc.GetAccountNumber()).
-		WithSequence(acc.GetSequence())
+	return acc, nil
+}
 
-	_, adjusted, err := sdkclient_tx.CalculateGas(s.clientCtx, txf, msg)
-	if err != nil {
-		return "", fmt.Errorf("failed to calculate gas: %w", err)
+// wrapSequenceMismatch marks simulation errors caused by a stale sequence, which only carry the ante handler's
+// message.
+func wrapSequenceMismatch(err error) error {
+	if _, ok := expectedSequence(err.Error()); ok {
+		return fmt.Errorf("%w: %w", ErrSequenceMismatch, err)
 	}
 
-	txf = txf.WithGas(adjusted)
+	return err
+}
 
-	tx, err := txf.BuildUnsignedTx(msg)
-	if err != nil {
-		return "", fmt.Errorf("failed to build unsigned transaction: %w", err)
+// expectedSequence extracts the expected sequence from an account sequence mismatch error message.
+func expectedSequence(message string) (uint64, bool) {
+	match := expectedSequencePattern.FindStringSubmatch(message)
+	if match == nil {
+		return 0, false
 	}
 
-	err = sdkclient_tx.Sign(ctx, txf, s.clientCtx.FromName, tx, true)
+	sequence, err := strconv.ParseUint(match[1], 10, 64)
 	if err != nil {
-		return "", fmt.Errorf("failed to sign transaction: %w", err)
+		return 0, false
 	}
 
-	encoder := s.clientCtx.TxConfig.TxEncoder()
-	if encoder == nil {
-		return "", ErrNilTxEncoder
-	}
+	return sequence, true
+}
 
-	txBytes, err := encoder(tx.GetTx())
-	if err != nil {
-		return "", fmt.Errorf("failed to encode transaction: %w", err)
+// parseOptionalAddress parses a bech32 address with the chain prefix, returning an empty address if none is set.
+func parseOptionalAddress(address string, chainPrefix string) (sdktypes.AccAddress, error) {
+	if address == "" {
+		return nil, nil
 	}
 
-	// broadcast to a CometBFT?
-	res, err := s.clientCtx.BroadcastTx(txBytes)
+	addr, err := sdktypes.GetFromBech32(address, chainPrefix)
 	if err != nil {
-		return "", fmt.Errorf("failed to broadcast transaction: %w", err)
-	}
-
-	if res.Code != 0 {
-		return "", fmt.Errorf("%w: code=%d codespace=%s log=%s", ErrTxFailed, res.Code, res.Codespace, res.RawLog)
+		return nil, fmt.Errorf("failed to parse bech32 address: %w", err)
 	}
 
-	return res.TxHash, nil
+	return addr, nil
 }
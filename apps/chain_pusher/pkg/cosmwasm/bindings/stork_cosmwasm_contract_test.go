@@ -0,0 +1,73 @@
+package bindings
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpectedSequence(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		message  string
+		expected uint64
+		ok       bool
+	}{
+		{
+			name:     "broadcast log",
+			message:  "account sequence mismatch, expected 42, got 41: incorrect account sequence",
+			expected: 42,
+			ok:       true,
+		},
+		{
+			name: "simulation error",
+			message: "rpc error: code = Unknown desc = account sequence mismatch, expected 7, got 9: " +
+				"incorrect account sequence [cosmos/cosmos-sdk@v0.50.0/x/auth/ante/sigverify.go:290] with gas used: '35614'",
+			expected: 7,
+			ok:       true,
+		},
+		{
+			name:    "other error",
+			message: "insufficient fees; got: 10uatom required: 20uatom",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sequence, ok := expectedSequence(tt.message)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, sequence)
+		})
+	}
+}
+
+func TestWrapSequenceMismatch(t *testing.T) {
+	t.Parallel()
+
+	err := wrapSequenceMismatch(errors.New("account sequence mismatch, expected 3, got 2"))
+	require.ErrorIs(t, err, ErrSequenceMismatch)
+
+	err = wrapSequenceMismatch(errors.New("out of gas"))
+	require.NotErrorIs(t, err, ErrSequenceMismatch)
+}
+
+func TestParseOptionalAddress(t *testing.T) {
+	t.Parallel()
+
+	addr, err := parseOptionalAddress("", "wasm")
+	require.NoError(t, err)
+	assert.True(t, addr.Empty())
+
+	addr, err = parseOptionalAddress("wasm1qypqxpqpqgpsgqgzqvzqzqsrqsqsyqcywkl7u0", "wasm")
+	require.NoError(t, err)
+	assert.Len(t, addr, 20)
+
+	_, err = parseOptionalAddress("osmo1qypqxpqpqgpsgqgzqvzqzqsrqsqsyqcyv3amrk", "wasm")
+	require.Error(t, err)
+}
@@ -0,0 +1,152 @@
+package cosmwasm
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/cometbft"
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/types"
+	abci "github.com/cometbft/cometbft/abci/types"
+)
+
+var ErrInvalidUpdateEvent = errors.New("invalid temporal numeric value update event")
+
+const (
+	// updateEventType is the type of the contract's temporal_numeric_value_update event, prefixed by wasmd.
+	updateEventType         = "wasm-temporal_numeric_value_update"
+	contractAddressAttrKey  = "_contract_address"
+	updateEventIDAttrKey    = "id"
+	updateEventValueAttrKey = "value"
+	// updateEventValueAttrs is the number of value attributes per event, the timestamp followed by the quantized value.
+	updateEventValueAttrs = 2
+)
+
+// ListenContractEvents subscribes to the contract's update events over the CometBFT websocket, reconnecting
+// whenever the connection drops.
+func (sci *ContractInteractor) ListenContractEvents(
+	ctx context.Context, ch chan map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue,
+) {
+	if sci.wsUrl == "" {
+		sci.logger.Warn().Msg("No websocket url configured, relying on contract polling only")
+
+		return
+	}
+
+	query := fmt.Sprintf("tm.event='Tx' AND %s.%s='%s'", updateEventType, contractAddressAttrKey, sci.contractAddress)
+	subscriber := cometbft.NewTxEventSubscriber(sci.wsUrl, query, cometbft.DefaultReconnectDelay, sci.logger)
+
+	subscriber.Run(ctx, func(events []abci.Event) {
+		updates, err := parseUpdateEvents(events, sci.contractAddress)
+		if err != nil {
+			sci.logger.Warn().Err(err).Msg("Failed to parse contract update events")
+		}
+
+		if len(updates) == 0 {
+			return
+		}
+
+		select {
+		case ch <- updates:
+		case <-ctx.Done():
+		}
+	})
+}
+
+// parseUpdateEvents extracts the newest value per asset from the contract's update events in a transaction,
+// skipping malformed events.
+func parseUpdateEvents(
+	events []abci.Event,
+	contractAddress string,
+) (map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue, error) {
+	updates := make(map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue)
+
+	var parseErr error
+
+	for _, event := range events {
+		if event.Type != updateEventType {
+			continue
+		}
+
+		encodedAssetID, value, emitter, err := parseUpdateEvent(event)
+		if err != nil {
+			parseErr = err
+
+			continue
+		}
+
+		if emitter != contractAddress {
+			continue
+		}
+
+		existing, ok := updates[encodedAssetID]
+		if ok && existing.TimestampNs >= value.TimestampNs {
+			continue
+		}
+
+		updates[encodedAssetID] = value
+	}
+
+	return updates, parseErr
+}
+
+func parseUpdateEvent(
+	event abci.Event,
+) (types.InternalEncodedAssetID, types.InternalTemporalNumericValue, string, error) {
+	var (
+		emitter string
+		id      string
+		values  []string
+	)
+
+	for _, attr := range event.Attributes {
+		switch attr.Key {
+		case contractAddressAttrKey:
+			emitter = attr.Value
+		case updateEventIDAttrKey:
+			id = attr.Value
+		case updateEventValueAttrKey:
+			values = append(values, attr.Value)
+		}
+	}
+
+	var encodedAssetID types.InternalEncodedAssetID
+
+	if len(values) != updateEventValueAttrs {
+		return encodedAssetID, types.InternalTemporalNumericValue{}, "", fmt.Errorf(
+			"%w: expected %d value attributes, got %d", ErrInvalidUpdateEvent, updateEventValueAttrs, len(values),
+		)
+	}
+
+	idBytes, err := hex.DecodeString(id)
+	if err != nil || len(idBytes) != len(encodedAssetID) {
+		return encodedAssetID, types.InternalTemporalNumericValue{}, "", fmt.Errorf(
+			"%w: invalid id %q", ErrInvalidUpdateEvent, id,
+		)
+	}
+
+	copy(encodedAssetID[:], idBytes)
+
+	timestampNs, err := strconv.ParseUint(values[0], 10, 64)
+	if err != nil {
+		return encodedAssetID, types.InternalTemporalNumericValue{}, "", fmt.Errorf(
+			"%w: invalid timestamp: %w", ErrInvalidUpdateEvent, err,
+		)
+	}
+
+	//nolint:mnd // base number.
+	quantizedValue, ok := new(big.Int).SetString(values[1], 10)
+	if !ok {
+		return encodedAssetID, types.InternalTemporalNumericValue{}, "", fmt.Errorf(
+			"%w: invalid quantized value %q", ErrInvalidUpdateEvent, values[1],
+		)
+	}
+
+	return encodedAssetID, types.InternalTemporalNumericValue{
+		TimestampNs:    timestampNs,
+		QuantizedValue: quantizedValue,
+	}, emitter, nil
+}
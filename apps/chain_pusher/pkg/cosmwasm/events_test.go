@@ -0,0 +1,104 @@
+package cosmwasm
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/types"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testContractAddress = "wasm14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"
+
+func updateEvent(contractAddress string, idByte string, timestampNs string, quantizedValue string) abci.Event {
+	return abci.Event{
+		Type: updateEventType,
+		Attributes: []abci.EventAttribute{
+			{Key: contractAddressAttrKey, Value: contractAddress},
+			{Key: updateEventIDAttrKey, Value: idByte + strings.Repeat("00", 31)},
+			{Key: updateEventValueAttrKey, Value: timestampNs},
+			{Key: updateEventValueAttrKey, Value: quantizedValue},
+		},
+	}
+}
+
+func TestParseUpdateEvents(t *testing.T) {
+	t.Parallel()
+
+	var first, second types.InternalEncodedAssetID
+	first[0] = 1
+	second[0] = 2
+
+	tests := []struct {
+		name      string
+		events    []abci.Event
+		expected  map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue
+		wantError bool
+	}{
+		{
+			name: "newest value per asset",
+			events: []abci.Event{
+				{Type: "message", Attributes: []abci.EventAttribute{{Key: "action", Value: "execute"}}},
+				updateEvent(testContractAddress, "01", "10", "100"),
+				updateEvent(testContractAddress, "02", "5", "-50"),
+				updateEvent(testContractAddress, "01", "20", "200"),
+				updateEvent(testContractAddress, "01", "15", "150"),
+			},
+			expected: map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue{
+				first:  {TimestampNs: 20, QuantizedValue: big.NewInt(200)},
+				second: {TimestampNs: 5, QuantizedValue: big.NewInt(-50)},
+			},
+		},
+		{
+			name: "other contract",
+			events: []abci.Event{
+				updateEvent("wasm1other", "01", "10", "100"),
+			},
+			expected: map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue{},
+		},
+		{
+			name: "malformed event is skipped",
+			events: []abci.Event{
+				updateEvent(testContractAddress, "01", "not a timestamp", "100"),
+				updateEvent(testContractAddress, "02", "5", "50"),
+			},
+			expected: map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue{
+				second: {TimestampNs: 5, QuantizedValue: big.NewInt(50)},
+			},
+			wantError: true,
+		},
+		{
+			name: "missing value attribute",
+			events: []abci.Event{
+				{
+					Type: updateEventType,
+					Attributes: []abci.EventAttribute{
+						{Key: contractAddressAttrKey, Value: testContractAddress},
+						{Key: updateEventIDAttrKey, Value: strings.Repeat("00", 32)},
+						{Key: updateEventValueAttrKey, Value: "10"},
+					},
+				},
+			},
+			expected:  map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue{},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			updates, err := parseUpdateEvents(tt.events, testContractAddress)
+			if tt.wantError {
+				require.ErrorIs(t, err, ErrInvalidUpdateEvent)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.expected, updates)
+		})
+	}
+}
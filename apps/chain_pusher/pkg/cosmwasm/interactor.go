@@ -21,13 +21,17 @@ type ContractInteractor struct {
 
 	mnemonic        string
 	contractAddress string
+	wsUrl           string
 	gasPrice        float64
 	gasAdjustment   float64
 	denom           string
 	chainID         string
 	chainPrefix     string
+	authzGranter    string
+	feeGranter      string
 
 	contract *bindings.StorkContract
+	puller   *pusher.ConcurrentPuller
 }
 
 func NewContractInteractor(
@@ -39,6 +43,11 @@ func NewContractInteractor(
 	denom string,
 	chainID string,
 	chainPrefix string,
+	authzGranter string,
+	feeGranter string,
+	pullConcurrency int,
+	limitPerSecond int,
+	burstLimit int,
 ) (*ContractInteractor, error) {
 	logger = logger.With().Str("component", "cosmwasm-contract-interactor").Logger()
 
@@ -54,6 +63,9 @@ func NewContractInteractor(
 		denom:           denom,
 		chainID:         chainID,
 		chainPrefix:     chainPrefix,
+		authzGranter:    authzGranter,
+		feeGranter:      feeGranter,
+		puller:          pusher.NewConcurrentPuller(pullConcurrency, limitPerSecond, burstLimit),
 	}, nil
 }
 
@@ -68,6 +80,8 @@ func (sci *ContractInteractor) ConnectHTTP(ctx context.Context, url string) erro
 		sci.denom,
 		sci.chainID,
 		sci.chainPrefix,
+		sci.authzGranter,
+		sci.feeGranter,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create stork contract: %w", err)
@@ -78,76 +92,59 @@ func (sci *ContractInteractor) ConnectHTTP(ctx context.Context, url string) erro
 	return nil
 }
 
-func (sci *ContractInteractor) ConnectWs(ctx context.Context, url string) error {
-	// not implemented
-	return nil
-}
+// ConnectWs records the CometBFT RPC url to subscribe to events on. The connection itself is made, and remade
+// after drops, by ListenContractEvents.
+func (sci *ContractInteractor) ConnectWs(_ context.Context, url string) error {
+	sci.wsUrl = url
 
-// ListenContractEvents is a placeholder function for the contract interactor.
-// unfortunately, Cosmwasm doesn't currently support websocket RPCs, so we can't listen to events from the contract
-// this contract does emit events, so this can be implemented in the future if Cosmwasm re-adds websocket support.
-func (sci *ContractInteractor) ListenContractEvents(
-	ctx context.Context, ch chan map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue,
-) {
-	sci.logger.Warn().
-		Msg("Cosmwasm pusher does not currently support listening to events via websocket, falling back to polling")
+	return nil
 }
 
 func (sci *ContractInteractor) PullValues(
 	ctx context.Context,
 	encodedAssetIDs []types.InternalEncodedAssetID,
 ) (map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue, error) {
-	polledVals := make(map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue)
-
-	var failedToGetLatestValueErr error
-
-	for _, encodedAssetID := range encodedAssetIDs {
-		var encodeAssetIDInt [32]int
-		for i, b := range encodedAssetID {
-			encodeAssetIDInt[i] = int(b)
-		}
-
-		response, err := sci.contract.GetLatestCanonicalTemporalNumericValueUnchecked(ctx, encodeAssetIDInt)
-		if err != nil {
-			failedToGetLatestValueErr = err
-
-			continue
-		}
+	polledVals, err := sci.puller.Pull(ctx, encodedAssetIDs, sci.pullValue)
 
-		quantizedValueBigInt := new(big.Int)
+	sci.logger.Debug().Msgf("Pulled %d values from contract", len(polledVals))
 
-		//nolint:mnd // base number.
-		quantizedValueBigInt, ok := quantizedValueBigInt.SetString(
-			string(response.TemporalNumericValue.QuantizedValue),
-			10,
-		)
-		if !ok {
-			return nil, ErrFailedToConvertBigInt
-		}
+	if err != nil {
+		return polledVals, fmt.Errorf("failed to pull values: %w", err)
+	}
 
-		timestampNs, err := strconv.ParseUint(string(response.TemporalNumericValue.TimestampNs), 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse timestamp ns: %w", err)
-		}
+	return polledVals, nil
+}
 
-		polledVals[encodedAssetID] = types.InternalTemporalNumericValue{
-			TimestampNs:    timestampNs,
-			QuantizedValue: quantizedValueBigInt,
-		}
+// pullValue reads a single feed, as the contract has no query for multiple feeds.
+func (sci *ContractInteractor) pullValue(
+	ctx context.Context,
+	encodedAssetID types.InternalEncodedAssetID,
+) (types.InternalTemporalNumericValue, error) {
+	var encodeAssetIDInt [32]int
+	for i, b := range encodedAssetID {
+		encodeAssetIDInt[i] = int(b)
 	}
 
-	sci.logger.Debug().Msgf("Pulled %d values from contract", len(polledVals))
+	response, err := sci.contract.GetLatestCanonicalTemporalNumericValueUnchecked(ctx, encodeAssetIDInt)
+	if err != nil {
+		return types.InternalTemporalNumericValue{}, fmt.Errorf("failed to get latest value: %w", err)
+	}
 
-	if failedToGetLatestValueErr != nil {
-		err := fmt.Errorf(
-			"failed to pull at least one value from the contract. Last error: %w",
-			failedToGetLatestValueErr,
-		)
+	//nolint:mnd // base number.
+	quantizedValueBigInt, ok := new(big.Int).SetString(string(response.TemporalNumericValue.QuantizedValue), 10)
+	if !ok {
+		return types.InternalTemporalNumericValue{}, ErrFailedToConvertBigInt
+	}
 
-		return polledVals, err
+	timestampNs, err := strconv.ParseUint(string(response.TemporalNumericValue.TimestampNs), 10, 64)
+	if err != nil {
+		return types.InternalTemporalNumericValue{}, fmt.Errorf("failed to parse timestamp ns: %w", err)
 	}
 
-	return polledVals, nil
+	return types.InternalTemporalNumericValue{
+		TimestampNs:    timestampNs,
+		QuantizedValue: quantizedValueBigInt,
+	}, nil
 }
 
 func (sci *ContractInteractor) BatchPushToContract(
@@ -8,6 +8,10 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// DefaultPollingPeriod is longer than the pusher default, as contract state is followed through events and polling
+// only catches up on missed ones.
+const DefaultPollingPeriod = 30
+
 func NewPushCmd() *cobra.Command {
 	pushCmd := &cobra.Command{
 		Use:   "cosmwasm",
@@ -18,17 +22,29 @@ func NewPushCmd() *cobra.Command {
 	pushCmd.Flags().StringP(pusher.StorkWebsocketEndpointFlag, "w", "", pusher.StorkWebsocketEndpointDesc)
 	pushCmd.Flags().StringP(pusher.StorkAuthCredentialsFlag, "a", "", pusher.StorkAuthCredentialsDesc)
 	pushCmd.Flags().StringP(pusher.ChainRpcUrlFlag, "r", "", pusher.ChainRpcUrlDesc)
+	pushCmd.Flags().StringP(pusher.ChainWsUrlFlag, "u", "", pusher.CometBFTWsUrlDesc)
 	pushCmd.Flags().StringP(pusher.ContractAddressFlag, "x", "", pusher.ContractAddressDesc)
 	pushCmd.Flags().StringP(pusher.AssetConfigFileFlag, "f", "", pusher.AssetConfigFileDesc)
 	pushCmd.Flags().StringP(pusher.MnemonicFileFlag, "m", "", pusher.MnemonicFileDesc)
 	pushCmd.Flags().IntP(pusher.BatchingWindowFlag, "b", pusher.DefaultBatchingWindow, pusher.BatchingWindowDesc)
 	pushCmd.Flags().String(pusher.BatchingWindowStrFlag, "", pusher.BatchingWindowStrDesc)
-	pushCmd.Flags().IntP(pusher.PollingPeriodFlag, "p", pusher.DefaultPollingPeriod, pusher.PollingPeriodDesc)
+	pushCmd.Flags().IntP(pusher.PollingPeriodFlag, "p", DefaultPollingPeriod, pusher.PollingPeriodDesc)
+	pushCmd.Flags().Float64(pusher.MaxSpendPerHourFlag, 0, pusher.MaxSpendPerHourDesc)
+	pushCmd.Flags().Float64(pusher.MaxSpendPerDayFlag, 0, pusher.MaxSpendPerDayDesc)
+	pushCmd.Flags().String(pusher.SpendStateFileFlag, "", pusher.SpendStateFileDesc)
+	pushCmd.Flags().String(pusher.MetricsAddressFlag, "", pusher.MetricsAddressDesc)
+	pushCmd.Flags().Int(pusher.MaxAssetsPerWindowFlag, 0, pusher.MaxAssetsPerWindowDesc)
+	pushCmd.Flags().BoolP(pusher.VerifyPublishersFlag, "v", false, pusher.VerifyPublishersDesc)
 	pushCmd.Flags().Float64P(pusher.GasPriceFlag, "g", 0.0, pusher.GasPriceDesc)
 	pushCmd.Flags().Float64P(pusher.GasAdjustmentFlag, "j", 1.0, pusher.GasAdjustmentDesc)
 	pushCmd.Flags().StringP(pusher.DenomFlag, "d", "", pusher.DenomDesc)
 	pushCmd.Flags().StringP(pusher.ChainIDFlag, "i", "", pusher.ChainIDDesc)
 	pushCmd.Flags().StringP(pusher.ChainPrefixFlag, "c", "", pusher.ChainPrefixDesc)
+	pushCmd.Flags().String(pusher.AuthzGranterFlag, "", pusher.AuthzGranterDesc)
+	pushCmd.Flags().String(pusher.FeeGranterFlag, "", pusher.FeeGranterDesc)
+	pushCmd.Flags().Int(pusher.PullConcurrencyFlag, pusher.DefaultPullConcurrency, pusher.PullConcurrencyDesc)
+	pushCmd.Flags().IntP(pusher.LimitPerSecondFlag, "l", pusher.DefaultPullLimitPerSecond, pusher.LimitPerSecondDesc)
+	pushCmd.Flags().Int(pusher.BurstLimitFlag, pusher.DefaultPullBurstLimit, pusher.BurstLimitDesc)
 
 	pushCmd.MarkFlagsMutuallyExclusive(pusher.BatchingWindowFlag, pusher.BatchingWindowStrFlag)
 
@@ -46,18 +62,30 @@ func runPush(cmd *cobra.Command, args []string) {
 	storkWsEndpoint, _ := cmd.Flags().GetString(pusher.StorkWebsocketEndpointFlag)
 	storkAuth, _ := cmd.Flags().GetString(pusher.StorkAuthCredentialsFlag)
 	chainRpcUrl, _ := cmd.Flags().GetString(pusher.ChainRpcUrlFlag)
+	chainWsUrl, _ := cmd.Flags().GetString(pusher.ChainWsUrlFlag)
 	contractAddress, _ := cmd.Flags().GetString(pusher.ContractAddressFlag)
 	assetConfigFile, _ := cmd.Flags().GetString(pusher.AssetConfigFileFlag)
 	mnemonicFile, _ := cmd.Flags().GetString(pusher.MnemonicFileFlag)
 	batchingWindow, _ := cmd.Flags().GetInt(pusher.BatchingWindowFlag)
 	batchingWindowStr, _ := cmd.Flags().GetString(pusher.BatchingWindowStrFlag)
 	pollingPeriod, _ := cmd.Flags().GetInt(pusher.PollingPeriodFlag)
+	maxSpendPerHour, _ := cmd.Flags().GetFloat64(pusher.MaxSpendPerHourFlag)
+	maxSpendPerDay, _ := cmd.Flags().GetFloat64(pusher.MaxSpendPerDayFlag)
+	spendStateFile, _ := cmd.Flags().GetString(pusher.SpendStateFileFlag)
+	metricsAddress, _ := cmd.Flags().GetString(pusher.MetricsAddressFlag)
+	maxAssetsPerWindow, _ := cmd.Flags().GetInt(pusher.MaxAssetsPerWindowFlag)
+	verifyPublishers, _ := cmd.Flags().GetBool(pusher.VerifyPublishersFlag)
 
 	gasPrice, _ := cmd.Flags().GetFloat64(pusher.GasPriceFlag)
 	gasAdjustment, _ := cmd.Flags().GetFloat64(pusher.GasAdjustmentFlag)
 	denom, _ := cmd.Flags().GetString(pusher.DenomFlag)
 	chainID, _ := cmd.Flags().GetString(pusher.ChainIDFlag)
 	chainPrefix, _ := cmd.Flags().GetString(pusher.ChainPrefixFlag)
+	authzGranter, _ := cmd.Flags().GetString(pusher.AuthzGranterFlag)
+	feeGranter, _ := cmd.Flags().GetString(pusher.FeeGranterFlag)
+	pullConcurrency, _ := cmd.Flags().GetInt(pusher.PullConcurrencyFlag)
+	limitPerSecond, _ := cmd.Flags().GetInt(pusher.LimitPerSecondFlag)
+	burstLimit, _ := cmd.Flags().GetInt(pusher.BurstLimitFlag)
 	logger := PusherLogger(chainRpcUrl, contractAddress)
 
 	mnemonic, err := os.ReadFile(mnemonicFile)
@@ -74,22 +102,42 @@ func runPush(cmd *cobra.Command, args []string) {
 		denom,
 		chainID,
 		chainPrefix,
+		authzGranter,
+		feeGranter,
+		pullConcurrency,
+		limitPerSecond,
+		burstLimit,
 	)
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Failed to initialize contract interactor")
 	}
 
+	// CometBFT serves websocket subscriptions on the RPC endpoint, so the RPC url is used unless overridden
+	if chainWsUrl == "" {
+		chainWsUrl = chainRpcUrl
+	}
+
+	pusher.StartMetricsServer(metricsAddress, logger)
+
+	budget, err := pusher.NewSpendBudget(maxSpendPerHour, maxSpendPerDay, spendStateFile, logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to initialize spend budget")
+	}
+
 	pusher := pusher.NewPusher(
 		storkWsEndpoint,
 		storkAuth,
 		chainRpcUrl,
-		"",
+		chainWsUrl,
 		contractAddress,
 		assetConfigFile,
 		batchingWindowStr,
 		batchingWindow,
 		pollingPeriod,
+		verifyPublishers,
+		maxAssetsPerWindow,
 		interactor,
+		budget,
 		&logger,
 	)
 	pusher.Run(context.Background())
@@ -0,0 +1,156 @@
+package evm
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/evm/bindings"
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/types"
+)
+
+const (
+	abiWordSize = 32
+
+	// selector + offset word + length word of the single dynamic array argument.
+	calldataHeaderBytes = 4 + 2*abiWordSize
+	// TemporalNumericValueInput is a static tuple of 8 words: timestampNs, quantizedValue, id,
+	// publisherMerkleRoot, valueComputeAlgHash, r, s, v.
+	unpackedEntryBytes = 8 * abiWordSize
+	packedEntryBytes   = packedWordsPerEntry * abiWordSize
+
+	baseTransactionGas  = 21_000
+	defaultGasPerUpdate = 60_000
+)
+
+// batchLimits bounds the size of a single update transaction. Zero values mean unlimited.
+type batchLimits struct {
+	maxGas       uint64
+	maxCalldata  int
+	packed       bool
+	gasPerUpdate uint64
+}
+
+// calldataSize returns the size in bytes of the calldata for an update of n entries.
+func calldataSize(n int, packed bool) int {
+	if packed {
+		return calldataHeaderBytes + n*packedEntryBytes
+	}
+
+	return calldataHeaderBytes + n*unpackedEntryBytes
+}
+
+// maxUpdatesPerTransaction returns how many updates fit in a single transaction under the limits,
+// or 0 if the limits do not bound the batch size. At least one update is always allowed so that a
+// single oversized update is still attempted rather than dropped.
+func (l batchLimits) maxUpdatesPerTransaction() int {
+	maxUpdates := 0
+
+	if l.maxCalldata > 0 {
+		entryBytes := unpackedEntryBytes
+		if l.packed {
+			entryBytes = packedEntryBytes
+		}
+
+		maxUpdates = max((l.maxCalldata-calldataHeaderBytes)/entryBytes, 1)
+	}
+
+	if l.maxGas > 0 {
+		gasPerUpdate := l.gasPerUpdate
+		if gasPerUpdate == 0 {
+			gasPerUpdate = defaultGasPerUpdate
+		}
+
+		byGas := 1
+		if l.maxGas > baseTransactionGas {
+			byGas = max(int((l.maxGas-baseTransactionGas)/gasPerUpdate), 1)
+		}
+
+		if maxUpdates == 0 || byGas < maxUpdates {
+			maxUpdates = byGas
+		}
+	}
+
+	return maxUpdates
+}
+
+// splitUpdatePayload splits updates into consecutive chunks that each fit the limits,
+// preserving the order of updates.
+func splitUpdatePayload(
+	updates []bindings.StorkStructsTemporalNumericValueInput,
+	limits batchLimits,
+) [][]bindings.StorkStructsTemporalNumericValueInput {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	chunkSize := limits.maxUpdatesPerTransaction()
+	if chunkSize == 0 || chunkSize >= len(updates) {
+		return [][]bindings.StorkStructsTemporalNumericValueInput{updates}
+	}
+
+	chunks := make([][]bindings.StorkStructsTemporalNumericValueInput, 0, (len(updates)+chunkSize-1)/chunkSize)
+	for start := 0; start < len(updates); start += chunkSize {
+		end := min(start+chunkSize, len(updates))
+		chunks = append(chunks, updates[start:end])
+	}
+
+	return chunks
+}
+
+// orderByStaleness orders updates so that assets this interactor pushed longest ago (or never) come first,
+// so that when a batch has to be split the stalest feeds land in the earliest transaction.
+func orderByStaleness(
+	priceUpdates map[types.InternalEncodedAssetID]types.AggregatedSignedPrice,
+	lastPushedNs map[types.InternalEncodedAssetID]uint64,
+) []types.AggregatedSignedPrice {
+	ids := make([]types.InternalEncodedAssetID, 0, len(priceUpdates))
+	for encodedAssetID := range priceUpdates {
+		ids = append(ids, encodedAssetID)
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		lastI, lastJ := lastPushedNs[ids[i]], lastPushedNs[ids[j]]
+		if lastI != lastJ {
+			return lastI < lastJ
+		}
+
+		return bytes.Compare(ids[i][:], ids[j][:]) < 0
+	})
+
+	ordered := make([]types.AggregatedSignedPrice, len(ids))
+	for i, encodedAssetID := range ids {
+		ordered[i] = priceUpdates[encodedAssetID]
+	}
+
+	return ordered
+}
+
+// estimateGasPerUpdate derives a per-update gas figure from the cached per-batch-size gas limits,
+// falling back to a conservative default when nothing has been observed yet.
+func (eci *ContractInteractor) estimateGasPerUpdate() uint64 {
+	var gasPerUpdate uint64
+
+	for n, gasLimit := range eci.gasLimits {
+		if n <= 0 || gasLimit <= baseTransactionGas {
+			continue
+		}
+
+		perUpdate := (gasLimit - baseTransactionGas + uint64(n) - 1) / uint64(n)
+		gasPerUpdate = max(gasPerUpdate, perUpdate)
+	}
+
+	if gasPerUpdate == 0 {
+		return defaultGasPerUpdate
+	}
+
+	return gasPerUpdate
+}
+
+func (eci *ContractInteractor) batchLimits() batchLimits {
+	return batchLimits{
+		maxGas:       eci.maxGasPerTx,
+		maxCalldata:  eci.maxCalldataBytes,
+		packed:       eci.packedUpdateSupported(),
+		gasPerUpdate: eci.estimateGasPerUpdate(),
+	}
+}
@@ -0,0 +1,161 @@
+package evm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/evm/bindings"
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeBatchingInputs(n int) []bindings.StorkStructsTemporalNumericValueInput {
+	inputs := make([]bindings.StorkStructsTemporalNumericValueInput, n)
+	for i := range inputs {
+		inputs[i].Id[31] = byte(i)
+		inputs[i].TemporalNumericValue.TimestampNs = uint64(i + 1)
+		inputs[i].TemporalNumericValue.QuantizedValue = big.NewInt(int64(i))
+		inputs[i].V = sigV27
+	}
+
+	return inputs
+}
+
+func TestCalldataSizeMatchesAbiEncoding(t *testing.T) {
+	t.Parallel()
+
+	contractAbi, err := bindings.StorkContractMetaData.GetAbi()
+	require.NoError(t, err)
+
+	for _, n := range []int{1, 2, 7} {
+		inputs := makeBatchingInputs(n)
+
+		unpacked, err := contractAbi.Pack("updateTemporalNumericValuesV1", inputs)
+		require.NoError(t, err)
+		assert.Equal(t, len(unpacked), calldataSize(n, false))
+
+		packedWords, err := packUpdatePayload(inputs)
+		require.NoError(t, err)
+
+		packed, err := contractAbi.Pack("updateTemporalNumericValuesV1Packed", packedWords)
+		require.NoError(t, err)
+		assert.Equal(t, len(packed), calldataSize(n, true))
+	}
+}
+
+func TestSplitUpdatePayload(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		n          int
+		limits     batchLimits
+		wantChunks []int
+	}{
+		{name: "no limits", n: 10, limits: batchLimits{}, wantChunks: []int{10}},
+		{name: "empty", n: 0, limits: batchLimits{maxGas: 1}, wantChunks: nil},
+		{
+			name:       "calldata limit unpacked",
+			n:          10,
+			limits:     batchLimits{maxCalldata: calldataSize(4, false)},
+			wantChunks: []int{4, 4, 2},
+		},
+		{
+			name:       "calldata limit packed fits more",
+			n:          10,
+			limits:     batchLimits{maxCalldata: calldataSize(4, false), packed: true},
+			wantChunks: []int{5, 5},
+		},
+		{
+			name:       "gas limit",
+			n:          7,
+			limits:     batchLimits{maxGas: baseTransactionGas + 3*100_000, gasPerUpdate: 100_000},
+			wantChunks: []int{3, 3, 1},
+		},
+		{
+			name:       "gas limit uses default per update gas",
+			n:          5,
+			limits:     batchLimits{maxGas: baseTransactionGas + 2*defaultGasPerUpdate},
+			wantChunks: []int{2, 2, 1},
+		},
+		{
+			name: "tightest limit wins",
+			n:    6,
+			limits: batchLimits{
+				maxGas:       baseTransactionGas + 4*100_000,
+				gasPerUpdate: 100_000,
+				maxCalldata:  calldataSize(2, false),
+			},
+			wantChunks: []int{2, 2, 2},
+		},
+		{
+			name:       "limit below a single update still sends one per transaction",
+			n:          2,
+			limits:     batchLimits{maxGas: 1, maxCalldata: 1},
+			wantChunks: []int{1, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			inputs := makeBatchingInputs(tt.n)
+			chunks := splitUpdatePayload(inputs, tt.limits)
+
+			sizes := make([]int, 0, len(chunks))
+			var flattened []bindings.StorkStructsTemporalNumericValueInput
+
+			for _, chunk := range chunks {
+				sizes = append(sizes, len(chunk))
+				flattened = append(flattened, chunk...)
+			}
+
+			if tt.wantChunks == nil {
+				assert.Empty(t, chunks)
+
+				return
+			}
+
+			assert.Equal(t, tt.wantChunks, sizes)
+			assert.Equal(t, inputs, flattened)
+		})
+	}
+}
+
+func TestOrderByStaleness(t *testing.T) {
+	t.Parallel()
+
+	var neverPushed, pushedLongAgo, pushedRecently types.InternalEncodedAssetID
+	neverPushed[31] = 3
+	pushedLongAgo[31] = 2
+	pushedRecently[31] = 1
+
+	priceUpdates := map[types.InternalEncodedAssetID]types.AggregatedSignedPrice{
+		pushedRecently: {AssetID: "RECENT"},
+		pushedLongAgo:  {AssetID: "OLD"},
+		neverPushed:    {AssetID: "NEVER"},
+	}
+	lastPushedNs := map[types.InternalEncodedAssetID]uint64{
+		pushedRecently: 2000,
+		pushedLongAgo:  1000,
+	}
+
+	ordered := orderByStaleness(priceUpdates, lastPushedNs)
+	require.Len(t, ordered, 3)
+	assert.Equal(t, "NEVER", string(ordered[0].AssetID))
+	assert.Equal(t, "OLD", string(ordered[1].AssetID))
+	assert.Equal(t, "RECENT", string(ordered[2].AssetID))
+}
+
+func TestEstimateGasPerUpdate(t *testing.T) {
+	t.Parallel()
+
+	eci := &ContractInteractor{gasLimits: map[int]uint64{}}
+	assert.Equal(t, uint64(defaultGasPerUpdate), eci.estimateGasPerUpdate())
+
+	eci.gasLimits[2] = baseTransactionGas + 2*40_000
+	eci.gasLimits[4] = baseTransactionGas + 4*30_000
+	assert.Equal(t, uint64(40_000), eci.estimateGasPerUpdate())
+}
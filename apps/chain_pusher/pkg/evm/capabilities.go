@@ -0,0 +1,267 @@
+package evm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Masterminds/semver"
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/evm/bindings"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+)
+
+var ErrInvalidUpdateEncoding = errors.New("invalid update encoding, expected auto, packed or unpacked")
+
+// UpdateEncoding selects the calldata encoding used for update transactions.
+type UpdateEncoding string
+
+const (
+	// UpdateEncodingAuto uses the packed encoding whenever the deployed contract supports it.
+	UpdateEncodingAuto     UpdateEncoding = "auto"
+	UpdateEncodingPacked   UpdateEncoding = "packed"
+	UpdateEncodingUnpacked UpdateEncoding = "unpacked"
+)
+
+// ParseUpdateEncoding parses an update encoding flag value.
+func ParseUpdateEncoding(encoding string) (UpdateEncoding, error) {
+	switch UpdateEncoding(encoding) {
+	case UpdateEncodingAuto, UpdateEncodingPacked, UpdateEncodingUnpacked:
+		return UpdateEncoding(encoding), nil
+	default:
+		return "", fmt.Errorf("%w: got %q", ErrInvalidUpdateEncoding, encoding)
+	}
+}
+
+// batchReadMinVersion is the first contract version that supports getTemporalNumericValuesUnsafeV1.
+const batchReadMinVersion = "1.0.5"
+
+// erc1967ImplementationSlot is bytes32(uint256(keccak256("eip1967.proxy.implementation")) - 1).
+var erc1967ImplementationSlot = common.HexToHash(
+	"0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc",
+)
+
+const (
+	opPush1             = 0x60
+	methodSelectorBytes = 4
+)
+
+// ContractCapabilities describes what the deployed Stork contract supports, as probed at connect time.
+type ContractCapabilities struct {
+	Version          *semver.Version
+	Implementation   common.Address
+	SingleUpdateFee  *big.Int
+	BatchReads       bool
+	PackedUpdates    bool
+	VerifyPublishers bool
+}
+
+// Equal reports whether both probes found the same contract capabilities.
+func (c ContractCapabilities) Equal(other ContractCapabilities) bool {
+	sameVersion := c.Version == nil && other.Version == nil ||
+		c.Version != nil && other.Version != nil && c.Version.Equal(other.Version)
+	sameFee := c.SingleUpdateFee == nil && other.SingleUpdateFee == nil ||
+		c.SingleUpdateFee != nil && other.SingleUpdateFee != nil && c.SingleUpdateFee.Cmp(other.SingleUpdateFee) == 0
+
+	return sameVersion && sameFee &&
+		c.Implementation == other.Implementation &&
+		c.BatchReads == other.BatchReads &&
+		c.PackedUpdates == other.PackedUpdates &&
+		c.VerifyPublishers == other.VerifyPublishers
+}
+
+func (c ContractCapabilities) MarshalZerologObject(e *zerolog.Event) {
+	if c.Version != nil {
+		e.Str("version", c.Version.String())
+	}
+
+	if c.Implementation != (common.Address{}) {
+		e.Str("implementation", c.Implementation.Hex())
+	}
+
+	if c.SingleUpdateFee != nil {
+		e.Str("singleUpdateFee", c.SingleUpdateFee.String())
+	}
+
+	e.Bool("batchReads", c.BatchReads).
+		Bool("packedUpdates", c.PackedUpdates).
+		Bool("verifyPublishers", c.VerifyPublishers)
+}
+
+// hasMethodSelector reports whether the bytecode pushes the given 4-byte selector, which is how the
+// solidity function dispatcher matches calldata. Leading zero bytes are dropped by the compiler,
+// so shorter PUSH opcodes are matched for those selectors.
+func hasMethodSelector(code []byte, selector []byte) bool {
+	trimmed := bytes.TrimLeft(selector, "\x00")
+	if len(trimmed) == 0 {
+		return false
+	}
+
+	pattern := append([]byte{byte(opPush1 + len(trimmed) - 1)}, trimmed...)
+
+	return bytes.Contains(code, pattern)
+}
+
+// detectCapabilities determines supported methods from the implementation bytecode, falling back
+// to the contract version when the bytecode is unavailable.
+func detectCapabilities(contractAbi *abi.ABI, version *semver.Version, code []byte) ContractCapabilities {
+	capabilities := ContractCapabilities{Version: version}
+
+	if len(code) > 0 {
+		hasMethod := func(name string) bool {
+			method, ok := contractAbi.Methods[name]
+
+			return ok && hasMethodSelector(code, method.ID[:methodSelectorBytes])
+		}
+
+		capabilities.BatchReads = hasMethod("getTemporalNumericValuesUnsafeV1")
+		capabilities.PackedUpdates = hasMethod("updateTemporalNumericValuesV1Packed")
+		capabilities.VerifyPublishers = hasMethod("verifyPublisherSignaturesV1")
+
+		return capabilities
+	}
+
+	if version != nil {
+		capabilities.BatchReads = version.Compare(semver.MustParse(batchReadMinVersion)) >= 0
+		capabilities.PackedUpdates = version.Compare(semver.MustParse(packedUpdateMinVersion)) >= 0
+	}
+
+	capabilities.VerifyPublishers = true
+
+	return capabilities
+}
+
+// getImplementationAddress returns the ERC1967 implementation behind the contract address,
+// or the zero address if the contract is not a proxy.
+func (eci *ContractInteractor) getImplementationAddress(ctx context.Context) (common.Address, error) {
+	slot, err := eci.client.StorageAt(ctx, eci.contractAddress, erc1967ImplementationSlot, nil)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to read implementation slot: %w", err)
+	}
+
+	return common.BytesToAddress(slot), nil
+}
+
+// probeCapabilities reads the contract version, fee and implementation bytecode to decide which
+// methods the deployed contract supports.
+func (eci *ContractInteractor) probeCapabilities(ctx context.Context) (ContractCapabilities, error) {
+	contractAbi, err := bindings.StorkContractMetaData.GetAbi()
+	if err != nil {
+		return ContractCapabilities{}, fmt.Errorf("failed to load contract abi: %w", err)
+	}
+
+	var version *semver.Version
+
+	versionStr, err := eci.contract.Version(makeCallOpts(ctx))
+	if err != nil {
+		eci.logger.Error().Err(err).Msg("Failed to get contract version")
+	} else {
+		version, err = semver.NewVersion(versionStr)
+		if err != nil {
+			eci.logger.Error().Err(err).Msg("Failed to parse contract version")
+		}
+	}
+
+	implementation, err := eci.getImplementationAddress(ctx)
+	if err != nil {
+		eci.logger.Warn().Err(err).Msg("Failed to read proxy implementation address")
+	}
+
+	codeAddress := eci.contractAddress
+	if implementation != (common.Address{}) {
+		codeAddress = implementation
+	}
+
+	code, err := eci.client.CodeAt(ctx, codeAddress, nil)
+	if err != nil {
+		eci.logger.Warn().Err(err).Msg("Failed to read contract bytecode, detecting capabilities from version")
+	}
+
+	capabilities := detectCapabilities(contractAbi, version, code)
+	capabilities.Implementation = implementation
+
+	capabilities.SingleUpdateFee, err = eci.getSingleUpdateFee(ctx)
+	if err != nil {
+		return capabilities, err
+	}
+
+	return capabilities, nil
+}
+
+// applyCapabilities stores newly probed capabilities and logs them, along with the resulting encoding choice,
+// whenever they change.
+func (eci *ContractInteractor) applyCapabilities(capabilities ContractCapabilities) {
+	changed := !capabilities.Equal(eci.capabilities)
+
+	eci.capabilities = capabilities
+	eci.singleUpdateFee = capabilities.SingleUpdateFee
+
+	if !changed {
+		eci.logger.Debug().Object("capabilities", capabilities).Msg("Contract capabilities unchanged")
+
+		return
+	}
+
+	if eci.updateEncoding == UpdateEncodingPacked && !capabilities.PackedUpdates {
+		eci.logger.Warn().Msg("Packed updates requested but not supported by the contract, using unpacked updates")
+	}
+
+	encoding := UpdateEncodingUnpacked
+	if eci.packedUpdateSupported() {
+		encoding = UpdateEncodingPacked
+	}
+
+	eci.logger.Info().
+		Object("capabilities", capabilities).
+		Str("updateEncoding", string(encoding)).
+		Msg("Detected contract capabilities")
+}
+
+// refreshCapabilities re-probes the contract if its proxy implementation has changed since the last probe or an
+// update reverted since, and otherwise only refreshes the update fee.
+func (eci *ContractInteractor) refreshCapabilities(ctx context.Context) {
+	implementation, err := eci.getImplementationAddress(ctx)
+	implementationChanged := err == nil && implementation != eci.capabilities.Implementation
+
+	if implementationChanged || eci.updateReverted {
+		if implementationChanged {
+			eci.logger.Info().
+				Str("previousImplementation", eci.capabilities.Implementation.Hex()).
+				Str("implementation", implementation.Hex()).
+				Msg("Contract implementation changed, re-probing capabilities")
+		} else {
+			eci.logger.Info().Msg("Update reverted, re-probing capabilities")
+		}
+
+		capabilities, err := eci.probeCapabilities(ctx)
+		if err != nil {
+			eci.logger.Error().Err(err).Msg("Failed to re-probe contract capabilities")
+
+			return
+		}
+
+		eci.updateReverted = false
+		eci.applyCapabilities(capabilities)
+
+		return
+	}
+
+	singleUpdateFee, err := eci.getSingleUpdateFee(ctx)
+	if err != nil {
+		eci.logger.Error().Err(err).Msg("failed to get single update fee")
+
+		return
+	}
+
+	capabilities := eci.capabilities
+	capabilities.SingleUpdateFee = singleUpdateFee
+	eci.applyCapabilities(capabilities)
+}
+
+// Capabilities returns the contract capabilities detected at the last probe.
+func (eci *ContractInteractor) Capabilities() ContractCapabilities {
+	return eci.capabilities
+}
@@ -0,0 +1,136 @@
+package evm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Masterminds/semver"
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/evm/bindings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUpdateEncoding(t *testing.T) {
+	t.Parallel()
+
+	for _, encoding := range []string{"auto", "packed", "unpacked"} {
+		parsed, err := ParseUpdateEncoding(encoding)
+		require.NoError(t, err)
+		assert.Equal(t, UpdateEncoding(encoding), parsed)
+	}
+
+	_, err := ParseUpdateEncoding("compressed")
+	require.ErrorIs(t, err, ErrInvalidUpdateEncoding)
+}
+
+func TestHasMethodSelector(t *testing.T) {
+	t.Parallel()
+
+	// PUSH4 0xdeadbeef EQ
+	code := []byte{0x80, 0x63, 0xde, 0xad, 0xbe, 0xef, 0x14}
+	assert.True(t, hasMethodSelector(code, []byte{0xde, 0xad, 0xbe, 0xef}))
+	assert.False(t, hasMethodSelector(code, []byte{0xca, 0xfe, 0xba, 0xbe}))
+
+	// selectors with a leading zero byte are pushed with PUSH3
+	shortCode := []byte{0x80, 0x62, 0x12, 0x34, 0x56, 0x14}
+	assert.True(t, hasMethodSelector(shortCode, []byte{0x00, 0x12, 0x34, 0x56}))
+	assert.False(t, hasMethodSelector(code, []byte{0x00, 0x00, 0x00, 0x00}))
+}
+
+func TestDetectCapabilities(t *testing.T) {
+	t.Parallel()
+
+	contractAbi, err := bindings.StorkContractMetaData.GetAbi()
+	require.NoError(t, err)
+
+	dispatch := func(methods ...string) []byte {
+		var code []byte
+
+		for _, name := range methods {
+			id := contractAbi.Methods[name].ID
+			code = append(code, 0x80, 0x63)
+			code = append(code, id[:4]...)
+			code = append(code, 0x14)
+		}
+
+		return code
+	}
+
+	t.Run("from bytecode", func(t *testing.T) {
+		t.Parallel()
+
+		code := dispatch("getTemporalNumericValuesUnsafeV1", "verifyPublisherSignaturesV1")
+		capabilities := detectCapabilities(contractAbi, semver.MustParse("1.0.6"), code)
+
+		assert.True(t, capabilities.BatchReads)
+		assert.False(t, capabilities.PackedUpdates)
+		assert.True(t, capabilities.VerifyPublishers)
+	})
+
+	t.Run("from version", func(t *testing.T) {
+		t.Parallel()
+
+		capabilities := detectCapabilities(contractAbi, semver.MustParse("1.0.5"), nil)
+		assert.True(t, capabilities.BatchReads)
+		assert.False(t, capabilities.PackedUpdates)
+
+		capabilities = detectCapabilities(contractAbi, semver.MustParse("1.0.6"), nil)
+		assert.True(t, capabilities.BatchReads)
+		assert.True(t, capabilities.PackedUpdates)
+
+		capabilities = detectCapabilities(contractAbi, nil, nil)
+		assert.False(t, capabilities.BatchReads)
+		assert.False(t, capabilities.PackedUpdates)
+	})
+}
+
+func TestPackedUpdateSupported(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		encoding  UpdateEncoding
+		supported bool
+		want      bool
+	}{
+		{encoding: UpdateEncodingAuto, supported: true, want: true},
+		{encoding: UpdateEncodingAuto, supported: false, want: false},
+		{encoding: UpdateEncodingPacked, supported: true, want: true},
+		{encoding: UpdateEncodingPacked, supported: false, want: false},
+		{encoding: UpdateEncodingUnpacked, supported: true, want: false},
+	}
+
+	for _, tt := range tests {
+		eci := &ContractInteractor{
+			updateEncoding: tt.encoding,
+			capabilities:   ContractCapabilities{PackedUpdates: tt.supported},
+		}
+		assert.Equal(t, tt.want, eci.packedUpdateSupported(), "encoding %s supported %v", tt.encoding, tt.supported)
+	}
+}
+
+func TestContractCapabilitiesEqual(t *testing.T) {
+	t.Parallel()
+
+	probed := ContractCapabilities{
+		Version:         semver.MustParse("1.0.6"),
+		SingleUpdateFee: big.NewInt(1),
+		BatchReads:      true,
+		PackedUpdates:   true,
+	}
+
+	same := probed
+	same.Version = semver.MustParse("1.0.6")
+	same.SingleUpdateFee = big.NewInt(1)
+	assert.True(t, probed.Equal(same))
+
+	feeChanged := probed
+	feeChanged.SingleUpdateFee = big.NewInt(2)
+	assert.False(t, probed.Equal(feeChanged))
+
+	packedRemoved := probed
+	packedRemoved.PackedUpdates = false
+	assert.False(t, probed.Equal(packedRemoved))
+
+	assert.False(t, probed.Equal(ContractCapabilities{}))
+	assert.True(t, ContractCapabilities{}.Equal(ContractCapabilities{}))
+}
@@ -11,7 +11,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/Masterminds/semver"
 	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/evm/bindings"
 	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/pusher"
 	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/types"
@@ -71,28 +70,45 @@ type ContractInteractor struct {
 	wsContract      *bindings.StorkContract
 	client          *ethclient.Client
 	useSyncSend     bool
-	usePackedUpdate bool
-	version         *semver.Version
+	updateEncoding  UpdateEncoding
+	capabilities    ContractCapabilities
 	gasFeeCap       *big.Int
 	gasTipCap       *big.Int
 	singleUpdateFee *big.Int
 	lastSetGasCaps  time.Time
 	gasLimits       map[int]uint64
+	// updateReverted is set when an update transaction reverts, so the next push re-probes the capabilities
+	// in case the selected update method is no longer supported.
+	updateReverted bool
 
 	chainID *big.Int
 
-	verifyPublishers bool
+	simulateUpdates  bool
+	quarantinePeriod time.Duration
+	quarantined      map[types.InternalEncodedAssetID]time.Time
+
+	maxGasPerTx      uint64
+	maxCalldataBytes int
+	lastPushedNs     map[types.InternalEncodedAssetID]uint64
+
+	logPollInterval  time.Duration
+	logConfirmations uint64
 }
 
 func NewContractInteractor(
 	contractAddr string,
 	keyFileContent []byte,
 	nonceManager NonceManagerI,
-	verifyPublishers bool,
 	logger zerolog.Logger,
 	gasLimit uint64,
 	useSyncSend bool,
-	usePackedUpdate bool,
+	updateEncoding UpdateEncoding,
+	simulateUpdates bool,
+	quarantinePeriod time.Duration,
+	maxGasPerTx uint64,
+	maxCalldataBytes int,
+	logPollInterval time.Duration,
+	logConfirmations uint64,
 ) (*ContractInteractor, error) {
 	privateKey, err := loadPrivateKey(keyFileContent)
 	if err != nil {
@@ -107,20 +123,29 @@ func NewContractInteractor(
 		nonceManager:    nonceManager,
 		gasLimit:        gasLimit,
 
-		verifyPublishers: verifyPublishers,
-
 		contract:        nil,
 		wsContract:      nil,
 		client:          nil,
 		chainID:         nil,
-		version:         nil,
+		capabilities:    ContractCapabilities{},
 		gasFeeCap:       nil,
 		gasTipCap:       nil,
 		useSyncSend:     useSyncSend,
-		usePackedUpdate: usePackedUpdate,
+		updateEncoding:  updateEncoding,
 		gasLimits:       make(map[int]uint64),
 		singleUpdateFee: nil,
 		lastSetGasCaps:  time.Time{},
+
+		simulateUpdates:  simulateUpdates,
+		quarantinePeriod: quarantinePeriod,
+		quarantined:      make(map[types.InternalEncodedAssetID]time.Time),
+
+		maxGasPerTx:      maxGasPerTx,
+		maxCalldataBytes: maxCalldataBytes,
+		lastPushedNs:     make(map[types.InternalEncodedAssetID]uint64),
+
+		logPollInterval:  logPollInterval,
+		logConfirmations: logConfirmations,
 	}, nil
 }
 
@@ -146,25 +171,12 @@ func (eci *ContractInteractor) ConnectHTTP(ctx context.Context, url string) erro
 	eci.client = client
 	eci.chainID = chainID
 
-	// load version
-	versionStr, err := eci.contract.Version(makeCallOpts(ctx))
+	capabilities, err := eci.probeCapabilities(ctx)
 	if err != nil {
-		eci.logger.Error().Err(err).Msg("Failed to get contract version")
-	} else {
-		version, err := semver.NewVersion(versionStr)
-		if err != nil {
-			eci.logger.Error().Err(err).Msg("Failed to parse contract version")
-		}
-		eci.version = version
-		eci.logger.Info().Interface("version", eci.version).Msg("contract version")
+		return fmt.Errorf("failed to probe contract capabilities: %w", err)
 	}
 
-	// set single update fee
-	singleUpdateFee, err := eci.getSingleUpdateFee(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to get single update fee: %w", err)
-	}
-	eci.singleUpdateFee = singleUpdateFee
+	eci.applyCapabilities(capabilities)
 
 	if err = eci.nonceManager.ResetNonce(ctx, eci.client, crypto.PubkeyToAddress(eci.privateKey.PublicKey)); err != nil {
 		eci.logger.Error().Err(err).Msg("Failed to reset nonce")
@@ -204,14 +216,16 @@ func (eci *ContractInteractor) ListenContractEvents(
 	ctx context.Context, ch chan map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue,
 ) {
 	if eci.wsContract == nil {
-		eci.logger.Warn().Msg("WebSocket contract not available, cannot listen for events")
+		eci.logger.Warn().Msg("WebSocket contract not available, falling back to log polling")
+		eci.fallbackToLogPolling(ctx, ch)
 
 		return
 	}
 
 	sub, eventCh, err := setupSubscription(eci, makeWatchOpts(ctx))
 	if err != nil {
-		eci.logger.Warn().Err(err).Msg("Failed to establish initial subscription")
+		eci.logger.Warn().Err(err).Msg("Failed to establish initial subscription, falling back to log polling")
+		eci.fallbackToLogPolling(ctx, ch)
 
 		return
 	}
@@ -242,16 +256,33 @@ func (eci *ContractInteractor) ListenContractEvents(
 
 		sub, eventCh, err = eci.reconnect(ctx, makeWatchOpts(ctx))
 		if err != nil {
+			if ctx.Err() == nil {
+				eci.fallbackToLogPolling(ctx, ch)
+			}
+
 			return
 		}
 	}
 }
 
+// fallbackToLogPolling polls eth_getLogs for contract events unless log polling is disabled.
+func (eci *ContractInteractor) fallbackToLogPolling(
+	ctx context.Context, ch chan map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue,
+) {
+	if eci.logPollInterval <= 0 {
+		eci.logger.Warn().Msg("Log polling disabled, relying on contract polling only")
+
+		return
+	}
+
+	eci.pollContractEvents(ctx, ch)
+}
+
 func (eci *ContractInteractor) PullValues(
 	ctx context.Context,
 	encodedAssetIDs []types.InternalEncodedAssetID,
 ) (map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue, error) {
-	if eci.version != nil && eci.version.Compare(semver.MustParse("1.0.5")) >= 0 {
+	if eci.capabilities.BatchReads {
 		return eci.batchPullValues(ctx, encodedAssetIDs)
 	} else {
 		return eci.individuallyPullValues(ctx, encodedAssetIDs)
@@ -371,119 +402,64 @@ func packUpdatePayload(
 	return packed, nil
 }
 
-type verifyPayload struct {
-	pubSigs    []bindings.StorkStructsPublisherSignature
-	merkleRoot [32]byte
-}
-
-func getVerifyPublishersPayloads(
+func (eci *ContractInteractor) BatchPushToContract(
+	ctx context.Context,
 	priceUpdates map[types.InternalEncodedAssetID]types.AggregatedSignedPrice,
-) ([]verifyPayload, error) {
-	payloads := make([]verifyPayload, len(priceUpdates))
-	i := 0
-
-	for _, priceUpdate := range priceUpdates {
-		merkleRootBytes, err := pusher.HexStringToByte32(priceUpdate.StorkSignedPrice.PublisherMerkleRoot)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse PublisherMerkleRoot: %w", err)
-		}
-
-		payloads[i] = verifyPayload{
-			pubSigs:    make([]bindings.StorkStructsPublisherSignature, len(priceUpdate.SignedPrices)),
-			merkleRoot: merkleRootBytes,
-		}
-		j := 0
-
-		var (
-			pubKeyBytes [20]byte
-			rBytes      [32]byte
-			sBytes      [32]byte
-			vInt        int64
-		)
+) error {
+	priceUpdates = eci.filterQuarantined(priceUpdates, time.Now())
+	if len(priceUpdates) == 0 {
+		eci.logger.Debug().Msg("All updates in batch are quarantined, skipping push")
 
-		for _, signedPrice := range priceUpdate.SignedPrices {
-			pubKeyBytes, err = pusher.HexStringToByte20(string(signedPrice.PublisherKey))
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse PublisherMerkleRoot: %w", err)
-			}
+		return nil
+	}
 
-			quantizedPriceBigInt := new(big.Int)
-			//nolint:mnd // base number.
-			quantizedPriceBigInt.SetString(string(signedPrice.QuantizedPrice), 10)
+	// order by staleness so that if the batch has to be split the stalest feeds land first
+	priceUpdatesSlice := orderByStaleness(priceUpdates, eci.lastPushedNs)
 
-			rBytes, err = pusher.HexStringToByte32(signedPrice.TimestampedSignature.Signature.R)
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse signature R: %w", err)
-			}
+	updatePayload, err := getUpdatePayload(priceUpdatesSlice)
+	if err != nil {
+		return err
+	}
 
-			sBytes, err = pusher.HexStringToByte32(signedPrice.TimestampedSignature.Signature.S)
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse signature S: %w", err)
-			}
+	chunks := splitUpdatePayload(updatePayload, eci.batchLimits())
+	if len(chunks) > 1 {
+		eci.logger.Debug().
+			Int("numUpdates", len(updatePayload)).
+			Int("numTransactions", len(chunks)).
+			Msg("Splitting batch into multiple transactions")
+	}
 
-			vInt, err = strconv.ParseInt(signedPrice.TimestampedSignature.Signature.V[2:], 16, 8)
-			if err != nil || vInt < 0 || vInt > 255 {
-				return nil, fmt.Errorf("failed to parse signature V: %w", err)
-			}
+	var pushErrs []error
 
-			payloads[i].pubSigs[j] = bindings.StorkStructsPublisherSignature{
-				PubKey:         pubKeyBytes,
-				AssetPairId:    signedPrice.ExternalAssetID,
-				Timestamp:      signedPrice.TimestampedSignature.TimestampNano / uint64(time.Second),
-				QuantizedValue: quantizedPriceBigInt,
-				R:              rBytes,
-				S:              sBytes,
-				V:              uint8(vInt),
-			}
-			j++
+	for _, chunk := range chunks {
+		err = eci.pushUpdateChunk(ctx, chunk)
+		if err != nil {
+			pushErrs = append(pushErrs, err)
 		}
-
-		i++
 	}
 
-	return payloads, nil
+	return errors.Join(pushErrs...)
 }
 
-func (eci *ContractInteractor) BatchPushToContract(
+// pushUpdateChunk submits a single update transaction, simulating it first if enabled.
+func (eci *ContractInteractor) pushUpdateChunk(
 	ctx context.Context,
-	priceUpdates map[types.InternalEncodedAssetID]types.AggregatedSignedPrice,
+	updatePayload []bindings.StorkStructsTemporalNumericValueInput,
 ) error {
-	if eci.verifyPublishers {
-		publisherVerifyPayloads, err := getVerifyPublishersPayloads(priceUpdates)
+	var err error
+
+	if eci.simulateUpdates {
+		updatePayload, err = eci.preflightUpdates(ctx, updatePayload)
 		if err != nil {
 			return err
 		}
 
-		var verified bool
-		for i := range publisherVerifyPayloads {
-			verified, err = eci.contract.VerifyPublisherSignaturesV1(
-				makeCallOpts(ctx),
-				publisherVerifyPayloads[i].pubSigs,
-				publisherVerifyPayloads[i].merkleRoot,
-			)
-			if err != nil {
-				eci.logger.Error().Err(err).Msg("Failed to verify publisher signatures")
-
-				return fmt.Errorf("failed to verify publisher signatures: %w", err)
-			}
+		if len(updatePayload) == 0 {
+			eci.logger.Warn().Msg("Every update in batch reverted in simulation, skipping push")
 
-			if !verified {
-				eci.logger.Error().Msg("Publisher signatures not verified, skipping update")
-
-				return nil
-			}
+			return nil
 		}
 	}
-	// convert to []types.AggregatedSignedPrice
-	priceUpdatesSlice := make([]types.AggregatedSignedPrice, 0, len(priceUpdates))
-	for _, priceUpdate := range priceUpdates {
-		priceUpdatesSlice = append(priceUpdatesSlice, priceUpdate)
-	}
-
-	updatePayload, err := getUpdatePayload(priceUpdatesSlice)
-	if err != nil {
-		return err
-	}
 
 	// this is the same logic as whats on the contract, but do it locally to avoid an rpc call
 	fee := eci.getUpdateFee(updatePayload)
@@ -502,9 +478,14 @@ func (eci *ContractInteractor) BatchPushToContract(
 		}
 	}
 
+	for _, update := range updatePayload {
+		eci.lastPushedNs[update.Id] = update.TemporalNumericValue.TimestampNs
+	}
+
 	eci.logger.Debug().
 		Str("txHash", tx.Hash().Hex()).
 		Int("numUpdates", len(updatePayload)).
+		Int("calldataBytes", len(tx.Data())).
 		Uint64("gasPrice", tx.GasPrice().Uint64()).
 		Msg("Pushed new values to contract")
 
@@ -705,13 +686,10 @@ func (eci *ContractInteractor) submitTransaction(
 		eci.gasTipCap = nil
 		clear(eci.gasLimits)
 
-		singleUpdateFee, err := eci.getSingleUpdateFee(ctx)
-		if err != nil {
-			eci.logger.Error().Err(err).Msg("failed to get single update fee")
-		} else {
-			eci.singleUpdateFee = singleUpdateFee
-		}
+		eci.refreshCapabilities(ctx)
 		eci.lastSetGasCaps = time.Now()
+	} else if eci.updateReverted {
+		eci.refreshCapabilities(ctx)
 	}
 	nonce, err := eci.nonceManager.GetLatestNonce(ctx, eci.client, crypto.PubkeyToAddress(eci.privateKey.PublicKey))
 	if err != nil {
@@ -741,7 +719,7 @@ func (eci *ContractInteractor) submitTransaction(
 
 	var tx *ethtypes.Transaction
 
-	if eci.usePackedUpdate && eci.version != nil && eci.version.Compare(semver.MustParse(packedUpdateMinVersion)) >= 0 {
+	if eci.packedUpdateSupported() {
 		packed, packErr := packUpdatePayload(updatePayload)
 		if packErr != nil {
 			return nil, fmt.Errorf("failed to pack update payload: %w", packErr)
@@ -754,6 +732,7 @@ func (eci *ContractInteractor) submitTransaction(
 
 	if err != nil {
 		if revertData, ok := ethclient.RevertErrorData(err); ok {
+			eci.updateReverted = true
 			eci.logger.Error().Str("revertData", hex.EncodeToString(revertData)).Msg("transaction reverted with data")
 		}
 
@@ -783,6 +762,7 @@ func (eci *ContractInteractor) submitTransaction(
 			// batch; clear the cache so the next attempt re-estimates instead of
 			// failing until gasCalcResetInterval clears it.
 			clear(eci.gasLimits)
+			eci.updateReverted = true
 
 			eci.logger.Warn().
 				Str("txHash", tx.Hash().Hex()).
@@ -801,6 +781,7 @@ func (eci *ContractInteractor) submitTransaction(
 
 		if txErr != nil {
 			if revertData, ok := ethclient.RevertErrorData(txErr); ok {
+				eci.updateReverted = true
 				eci.logger.Error().Str("revertData", hex.EncodeToString(revertData)).Msg("transaction reverted with data")
 			} else if strings.Contains(txErr.Error(), "nonce") {
 				eci.logger.Warn().Err(txErr).Msg("Nonce mismatch, resetting nonce")
@@ -822,6 +803,11 @@ func (eci *ContractInteractor) submitTransaction(
 	return tx, nil
 }
 
+// packedUpdateSupported reports whether updates should be sent with the packed calldata encoding.
+func (eci *ContractInteractor) packedUpdateSupported() bool {
+	return eci.updateEncoding != UpdateEncodingUnpacked && eci.capabilities.PackedUpdates
+}
+
 func (eci *ContractInteractor) getSingleUpdateFee(ctx context.Context) (*big.Int, error) {
 	singleUpdateFee, err := eci.contract.SingleUpdateFeeInWei(makeCallOpts(ctx))
 	if err != nil {
@@ -45,11 +45,16 @@ func (s *InteractorTestSuite) SetupSuite() {
 		s.config.ContractAddress,
 		[]byte(s.config.PrivateKey),
 		nonceManager,
-		false,
 		s.logger,
 		0,
 		false,
+		UpdateEncodingUnpacked,
 		false,
+		DefaultQuarantinePeriod,
+		0,
+		0,
+		DefaultLogPollInterval,
+		DefaultLogConfirmations,
 	)
 	s.Require().NoError(err)
 
@@ -0,0 +1,188 @@
+package evm
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// DefaultLogPollInterval is how often eth_getLogs is polled when no websocket subscription is available.
+	DefaultLogPollInterval = 5 * time.Second
+	// DefaultLogConfirmations is how many blocks behind head logs are read, so that shallow reorgs
+	// are settled before events are processed.
+	DefaultLogConfirmations = 2
+
+	maxLogBlockRange = 1000
+)
+
+// logCursor tracks the last block whose ValueUpdate logs have been processed.
+type logCursor struct {
+	blockNumber uint64
+	blockHash   common.Hash
+}
+
+// nextLogRange returns the inclusive block range to query after the last processed block, given the chain head.
+// Blocks within confirmations of head are not yet considered settled. ok is false if there is nothing new to read.
+func nextLogRange(lastProcessed, head, confirmations, maxRange uint64) (uint64, uint64, bool) {
+	if head < confirmations {
+		return 0, 0, false
+	}
+
+	safeHead := head - confirmations
+	if safeHead <= lastProcessed {
+		return 0, 0, false
+	}
+
+	from := lastProcessed + 1
+
+	to := safeHead
+	if maxRange > 0 && to-from+1 > maxRange {
+		to = from + maxRange - 1
+	}
+
+	return from, to, true
+}
+
+// rewindCursor moves the cursor back by confirmations blocks after a reorg past the confirmation depth.
+func rewindCursor(cursor logCursor, confirmations uint64) uint64 {
+	rewind := max(confirmations, 1)
+	if cursor.blockNumber < rewind {
+		return 0
+	}
+
+	return cursor.blockNumber - rewind
+}
+
+// pollContractEvents reads ValueUpdate events with eth_getLogs and feeds them into ch. It is used when
+// the RPC provider has no websocket endpoint or does not support eth_subscribe.
+func (eci *ContractInteractor) pollContractEvents(
+	ctx context.Context,
+	ch chan map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue,
+) {
+	eci.logger.Info().
+		Dur("interval", eci.logPollInterval).
+		Uint64("confirmations", eci.logConfirmations).
+		Msg("Listening for contract events via eth_getLogs polling")
+
+	var cursor *logCursor
+
+	ticker := time.NewTicker(eci.logPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			eci.logger.Debug().Msg("Exiting contract event polling")
+
+			return
+		case <-ticker.C:
+			next, err := eci.pollLogsOnce(ctx, cursor, ch)
+			if err != nil {
+				eci.logger.Warn().Err(err).Msg("Failed to poll contract events")
+
+				continue
+			}
+
+			cursor = next
+		}
+	}
+}
+
+// pollLogsOnce processes all settled blocks after cursor and returns the advanced cursor. A nil cursor
+// starts from the current settled head, since initial values are read from the contract directly.
+func (eci *ContractInteractor) pollLogsOnce(
+	ctx context.Context,
+	cursor *logCursor,
+	ch chan map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue,
+) (*logCursor, error) {
+	head, err := eci.client.BlockNumber(ctx)
+	if err != nil {
+		return cursor, fmt.Errorf("failed to get block number: %w", err)
+	}
+
+	if cursor == nil {
+		start := uint64(0)
+		if head > eci.logConfirmations {
+			start = head - eci.logConfirmations
+		}
+
+		return eci.cursorAt(ctx, start)
+	}
+
+	lastProcessed := cursor.blockNumber
+
+	header, err := eci.client.HeaderByNumber(ctx, new(big.Int).SetUint64(cursor.blockNumber))
+	if err != nil {
+		return cursor, fmt.Errorf("failed to get header for block %d: %w", cursor.blockNumber, err)
+	}
+
+	if header.Hash() != cursor.blockHash {
+		lastProcessed = rewindCursor(*cursor, eci.logConfirmations)
+
+		eci.logger.Warn().
+			Uint64("blockNumber", cursor.blockNumber).
+			Uint64("rewindTo", lastProcessed).
+			Msg("Reorg detected past confirmation depth, re-reading contract events")
+	}
+
+	from, to, ok := nextLogRange(lastProcessed, head, eci.logConfirmations, maxLogBlockRange)
+	if !ok {
+		return cursor, nil
+	}
+
+	iter, err := eci.contract.FilterValueUpdate(&bind.FilterOpts{Start: from, End: &to, Context: ctx}, nil)
+	if err != nil {
+		return cursor, fmt.Errorf("failed to filter value update logs: %w", err)
+	}
+
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	updates := make(map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue)
+
+	for iter.Next() {
+		if iter.Event.Raw.Removed {
+			continue
+		}
+
+		existing, exists := updates[iter.Event.Id]
+		if exists && existing.TimestampNs > iter.Event.TimestampNs {
+			continue
+		}
+
+		updates[iter.Event.Id] = types.InternalTemporalNumericValue{
+			QuantizedValue: iter.Event.QuantizedValue,
+			TimestampNs:    iter.Event.TimestampNs,
+		}
+	}
+
+	if err = iter.Error(); err != nil {
+		return cursor, fmt.Errorf("failed to iterate value update logs: %w", err)
+	}
+
+	if len(updates) > 0 {
+		select {
+		case ch <- updates:
+		case <-ctx.Done():
+			return cursor, fmt.Errorf("context done: %w", ctx.Err())
+		}
+	}
+
+	return eci.cursorAt(ctx, to)
+}
+
+func (eci *ContractInteractor) cursorAt(ctx context.Context, blockNumber uint64) (*logCursor, error) {
+	header, err := eci.client.HeaderByNumber(ctx, new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get header for block %d: %w", blockNumber, err)
+	}
+
+	return &logCursor{blockNumber: blockNumber, blockHash: header.Hash()}, nil
+}
@@ -0,0 +1,54 @@
+package evm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextLogRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		lastProcessed uint64
+		head          uint64
+		confirmations uint64
+		maxRange      uint64
+		wantFrom      uint64
+		wantTo        uint64
+		wantOk        bool
+	}{
+		{name: "new settled blocks", lastProcessed: 100, head: 110, confirmations: 2, wantFrom: 101, wantTo: 108, wantOk: true},
+		{name: "no confirmations", lastProcessed: 100, head: 101, confirmations: 0, wantFrom: 101, wantTo: 101, wantOk: true},
+		{name: "nothing settled yet", lastProcessed: 100, head: 102, confirmations: 2, wantOk: false},
+		{name: "head behind cursor", lastProcessed: 100, head: 90, confirmations: 0, wantOk: false},
+		{name: "head below confirmations", lastProcessed: 0, head: 1, confirmations: 5, wantOk: false},
+		{
+			name: "range capped", lastProcessed: 100, head: 5000, confirmations: 2, maxRange: 1000,
+			wantFrom: 101, wantTo: 1100, wantOk: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			from, to, ok := nextLogRange(tt.lastProcessed, tt.head, tt.confirmations, tt.maxRange)
+			assert.Equal(t, tt.wantOk, ok)
+
+			if tt.wantOk {
+				assert.Equal(t, tt.wantFrom, from)
+				assert.Equal(t, tt.wantTo, to)
+			}
+		})
+	}
+}
+
+func TestRewindCursor(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, uint64(97), rewindCursor(logCursor{blockNumber: 100}, 3))
+	assert.Equal(t, uint64(99), rewindCursor(logCursor{blockNumber: 100}, 0))
+	assert.Equal(t, uint64(0), rewindCursor(logCursor{blockNumber: 2}, 5))
+}
@@ -26,12 +26,26 @@ func NewPushCmd() *cobra.Command {
 	pushCmd.Flags().IntP(pusher.BatchingWindowFlag, "b", pusher.DefaultBatchingWindow, pusher.BatchingWindowDesc)
 	pushCmd.Flags().String(pusher.BatchingWindowStrFlag, "", pusher.BatchingWindowStrDesc)
 	pushCmd.Flags().IntP(pusher.PollingPeriodFlag, "p", pusher.DefaultPollingPeriod, pusher.PollingPeriodDesc)
+	pushCmd.Flags().Float64(pusher.MaxSpendPerHourFlag, 0, pusher.MaxSpendPerHourDesc)
+	pushCmd.Flags().Float64(pusher.MaxSpendPerDayFlag, 0, pusher.MaxSpendPerDayDesc)
+	pushCmd.Flags().String(pusher.SpendStateFileFlag, "", pusher.SpendStateFileDesc)
+	pushCmd.Flags().String(pusher.MetricsAddressFlag, "", pusher.MetricsAddressDesc)
+	pushCmd.Flags().Int(pusher.MaxAssetsPerWindowFlag, 0, pusher.MaxAssetsPerWindowDesc)
 	pushCmd.Flags().Uint64P(pusher.GasLimitFlag, "g", 0, pusher.GasLimitDesc)
 	pushCmd.Flags().String(pusher.NonceManagerFlag, "", pusher.NonceManagerTypeDesc)
 	pushCmd.Flags().BoolP(pusher.UseSyncSendFlag, "", false, pusher.UseSyncSendDesc)
 	pushCmd.Flags().BoolP(pusher.UsePackedUpdateFlag, "", false, pusher.UsePackedUpdateDesc)
+	pushCmd.Flags().String(pusher.UpdateEncodingFlag, string(UpdateEncodingAuto), pusher.UpdateEncodingDesc)
+	pushCmd.Flags().Bool(pusher.SimulateUpdatesFlag, false, pusher.SimulateUpdatesDesc)
+	pushCmd.Flags().Duration(pusher.RevertQuarantineFlag, DefaultQuarantinePeriod, pusher.RevertQuarantineDesc)
+	pushCmd.Flags().Uint64(pusher.MaxGasPerTxFlag, 0, pusher.MaxGasPerTxDesc)
+	pushCmd.Flags().Int(pusher.MaxCalldataBytesFlag, 0, pusher.MaxCalldataBytesDesc)
+	pushCmd.Flags().Duration(pusher.LogPollIntervalFlag, DefaultLogPollInterval, pusher.LogPollIntervalDesc)
+	pushCmd.Flags().Uint64(pusher.LogConfirmationsFlag, DefaultLogConfirmations, pusher.LogConfirmationsDesc)
 
 	pushCmd.MarkFlagsMutuallyExclusive(pusher.BatchingWindowFlag, pusher.BatchingWindowStrFlag)
+	pushCmd.MarkFlagsMutuallyExclusive(pusher.UsePackedUpdateFlag, pusher.UpdateEncodingFlag)
+	_ = pushCmd.Flags().MarkDeprecated(pusher.UsePackedUpdateFlag, "use --"+pusher.UpdateEncodingFlag+"=packed instead")
 
 	_ = pushCmd.MarkFlagRequired(pusher.StorkWebsocketEndpointFlag)
 	_ = pushCmd.MarkFlagRequired(pusher.StorkAuthCredentialsFlag)
@@ -55,10 +69,22 @@ func runPush(cmd *cobra.Command, args []string) {
 	batchingWindow, _ := cmd.Flags().GetInt(pusher.BatchingWindowFlag)
 	batchingWindowStr, _ := cmd.Flags().GetString(pusher.BatchingWindowStrFlag)
 	pollingPeriod, _ := cmd.Flags().GetInt(pusher.PollingPeriodFlag)
+	maxSpendPerHour, _ := cmd.Flags().GetFloat64(pusher.MaxSpendPerHourFlag)
+	maxSpendPerDay, _ := cmd.Flags().GetFloat64(pusher.MaxSpendPerDayFlag)
+	spendStateFile, _ := cmd.Flags().GetString(pusher.SpendStateFileFlag)
+	metricsAddress, _ := cmd.Flags().GetString(pusher.MetricsAddressFlag)
+	maxAssetsPerWindow, _ := cmd.Flags().GetInt(pusher.MaxAssetsPerWindowFlag)
 	gasLimit, _ := cmd.Flags().GetUint64(pusher.GasLimitFlag)
 	nonceManagerType, _ := cmd.Flags().GetString(pusher.NonceManagerFlag)
 	useSyncSend, _ := cmd.Flags().GetBool(pusher.UseSyncSendFlag)
 	usePackedUpdate, _ := cmd.Flags().GetBool(pusher.UsePackedUpdateFlag)
+	updateEncodingStr, _ := cmd.Flags().GetString(pusher.UpdateEncodingFlag)
+	simulateUpdates, _ := cmd.Flags().GetBool(pusher.SimulateUpdatesFlag)
+	revertQuarantine, _ := cmd.Flags().GetDuration(pusher.RevertQuarantineFlag)
+	maxGasPerTx, _ := cmd.Flags().GetUint64(pusher.MaxGasPerTxFlag)
+	maxCalldataBytes, _ := cmd.Flags().GetInt(pusher.MaxCalldataBytesFlag)
+	logPollInterval, _ := cmd.Flags().GetDuration(pusher.LogPollIntervalFlag)
+	logConfirmations, _ := cmd.Flags().GetUint64(pusher.LogConfirmationsFlag)
 
 	logger := PusherLogger(chainRpcUrl, contractAddress)
 
@@ -67,6 +93,15 @@ func runPush(cmd *cobra.Command, args []string) {
 		logger.Fatal().Err(err).Msg("Failed to read private key file")
 	}
 
+	updateEncoding, err := ParseUpdateEncoding(updateEncodingStr)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to parse update encoding")
+	}
+
+	if usePackedUpdate {
+		updateEncoding = UpdateEncodingPacked
+	}
+
 	nonceManager, err := NewNonceManagerFromType(NonceManagerType(nonceManagerType))
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Failed to initialize nonce manager")
@@ -76,16 +111,28 @@ func runPush(cmd *cobra.Command, args []string) {
 		contractAddress,
 		keyFileContent,
 		nonceManager,
-		verifyPublishers,
 		logger,
 		gasLimit,
 		useSyncSend,
-		usePackedUpdate,
+		updateEncoding,
+		simulateUpdates,
+		revertQuarantine,
+		maxGasPerTx,
+		maxCalldataBytes,
+		logPollInterval,
+		logConfirmations,
 	)
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Failed to initialize contract interactor")
 	}
 
+	pusher.StartMetricsServer(metricsAddress, logger)
+
+	budget, err := pusher.NewSpendBudget(maxSpendPerHour, maxSpendPerDay, spendStateFile, logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to initialize spend budget")
+	}
+
 	pusher := pusher.NewPusher(
 		storkWsEndpoint,
 		storkAuth,
@@ -96,7 +143,10 @@ func runPush(cmd *cobra.Command, args []string) {
 		batchingWindowStr,
 		batchingWindow,
 		pollingPeriod,
+		verifyPublishers,
+		maxAssetsPerWindow,
 		interactor,
+		budget,
 		&logger,
 	)
 	pusher.Run(context.Background())
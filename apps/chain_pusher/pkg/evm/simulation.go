@@ -0,0 +1,261 @@
+package evm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/evm/bindings"
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+var ErrUnknownRevertReason = errors.New("unknown revert reason")
+
+const (
+	// DefaultQuarantinePeriod is how long an asset whose update reverted in simulation is excluded from pushes.
+	DefaultQuarantinePeriod = 5 * time.Minute
+
+	errorSelectorLength = 4
+)
+
+// RevertError is a contract revert decoded against the custom errors in the Stork contract ABI.
+type RevertError struct {
+	Name string
+	Args []any
+}
+
+func (e *RevertError) Error() string {
+	if len(e.Args) == 0 {
+		return fmt.Sprintf("execution reverted: %s()", e.Name)
+	}
+
+	return fmt.Sprintf("execution reverted: %s%v", e.Name, e.Args)
+}
+
+// decodeRevertReason maps raw revert data onto the matching custom error in the Stork contract ABI.
+func decodeRevertReason(contractAbi *abi.ABI, revertData []byte) (*RevertError, error) {
+	if len(revertData) < errorSelectorLength {
+		return nil, fmt.Errorf("%w: revert data too short", ErrUnknownRevertReason)
+	}
+
+	for name, abiErr := range contractAbi.Errors {
+		if !bytes.Equal(abiErr.ID[:errorSelectorLength], revertData[:errorSelectorLength]) {
+			continue
+		}
+
+		unpacked, err := abiErr.Unpack(revertData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unpack revert reason %s: %w", name, err)
+		}
+
+		args, ok := unpacked.([]any)
+		if !ok {
+			args = []any{unpacked}
+		}
+
+		return &RevertError{Name: name, Args: args}, nil
+	}
+
+	return nil, fmt.Errorf("%w: selector 0x%x", ErrUnknownRevertReason, revertData[:errorSelectorLength])
+}
+
+// isSimulationRevert reports whether a simulation error is a revert of the update itself
+// rather than a transport or node failure that says nothing about the batch contents.
+func isSimulationRevert(err error) bool {
+	var revertErr *RevertError
+	if errors.As(err, &revertErr) {
+		return true
+	}
+
+	return strings.Contains(err.Error(), "execution reverted")
+}
+
+// bisectRevertingUpdates finds the updates that make simulate fail by recursively halving the batch.
+// Halves that simulate successfully are not split further, so a batch with a single bad update
+// needs O(log n) simulations.
+func bisectRevertingUpdates(
+	updates []bindings.StorkStructsTemporalNumericValueInput,
+	simulate func([]bindings.StorkStructsTemporalNumericValueInput) error,
+) (map[types.InternalEncodedAssetID]error, error) {
+	reverting := make(map[types.InternalEncodedAssetID]error)
+
+	var bisect func([]bindings.StorkStructsTemporalNumericValueInput) error
+
+	bisect = func(subset []bindings.StorkStructsTemporalNumericValueInput) error {
+		if len(subset) == 0 {
+			return nil
+		}
+
+		err := simulate(subset)
+		if err == nil {
+			return nil
+		}
+
+		if !isSimulationRevert(err) {
+			return err
+		}
+
+		if len(subset) == 1 {
+			reverting[subset[0].Id] = err
+
+			return nil
+		}
+
+		mid := len(subset) / 2 //nolint:mnd // halving the batch.
+
+		err = bisect(subset[:mid])
+		if err != nil {
+			return err
+		}
+
+		return bisect(subset[mid:])
+	}
+
+	err := bisect(updates)
+	if err != nil {
+		return nil, err
+	}
+
+	return reverting, nil
+}
+
+// updateCallData encodes the update the way submitTransaction sends it, so simulations exercise the same method.
+func (eci *ContractInteractor) updateCallData(
+	contractAbi *abi.ABI,
+	updatePayload []bindings.StorkStructsTemporalNumericValueInput,
+) ([]byte, error) {
+	if eci.packedUpdateSupported() {
+		packed, err := packUpdatePayload(updatePayload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pack update payload: %w", err)
+		}
+
+		callData, err := contractAbi.Pack("updateTemporalNumericValuesV1Packed", packed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pack packed update payload: %w", err)
+		}
+
+		return callData, nil
+	}
+
+	callData, err := contractAbi.Pack("updateTemporalNumericValuesV1", updatePayload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack update payload: %w", err)
+	}
+
+	return callData, nil
+}
+
+// simulateUpdate runs the update as an eth_call against the latest block and returns the decoded
+// revert reason if the contract would reject it.
+func (eci *ContractInteractor) simulateUpdate(
+	ctx context.Context,
+	updatePayload []bindings.StorkStructsTemporalNumericValueInput,
+) error {
+	contractAbi, err := bindings.StorkContractMetaData.GetAbi()
+	if err != nil {
+		return fmt.Errorf("failed to load contract abi: %w", err)
+	}
+
+	callData, err := eci.updateCallData(contractAbi, updatePayload)
+	if err != nil {
+		return err
+	}
+
+	msg := ethereum.CallMsg{
+		From:  crypto.PubkeyToAddress(eci.privateKey.PublicKey),
+		To:    &eci.contractAddress,
+		Value: eci.getUpdateFee(updatePayload),
+		Data:  callData,
+	}
+
+	_, err = eci.client.CallContract(ctx, msg, nil)
+	if err == nil {
+		return nil
+	}
+
+	revertData, ok := ethclient.RevertErrorData(err)
+	if !ok {
+		return fmt.Errorf("failed to simulate update: %w", err)
+	}
+
+	revertErr, decodeErr := decodeRevertReason(contractAbi, revertData)
+	if decodeErr != nil {
+		return fmt.Errorf("failed to simulate update: %w (%w)", err, decodeErr)
+	}
+
+	return revertErr
+}
+
+// preflightUpdates simulates the batch and quarantines any updates that would revert it,
+// returning the remaining updates that are safe to submit.
+func (eci *ContractInteractor) preflightUpdates(
+	ctx context.Context,
+	updatePayload []bindings.StorkStructsTemporalNumericValueInput,
+) ([]bindings.StorkStructsTemporalNumericValueInput, error) {
+	reverting, err := bisectRevertingUpdates(updatePayload, func(subset []bindings.StorkStructsTemporalNumericValueInput) error {
+		return eci.simulateUpdate(ctx, subset)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate update batch: %w", err)
+	}
+
+	if len(reverting) == 0 {
+		return updatePayload, nil
+	}
+
+	until := time.Now().Add(eci.quarantinePeriod)
+	for encodedAssetID, revertErr := range reverting {
+		eci.quarantined[encodedAssetID] = until
+
+		eci.logger.Warn().
+			Err(revertErr).
+			Str("encodedAssetID", fmt.Sprintf("0x%x", encodedAssetID)).
+			Time("until", until).
+			Msg("Update reverted in simulation, quarantining asset")
+	}
+
+	remaining := make([]bindings.StorkStructsTemporalNumericValueInput, 0, len(updatePayload)-len(reverting))
+	for _, update := range updatePayload {
+		if _, ok := reverting[update.Id]; !ok {
+			remaining = append(remaining, update)
+		}
+	}
+
+	return remaining, nil
+}
+
+// filterQuarantined drops updates for assets that are still quarantined and releases expired quarantines.
+func (eci *ContractInteractor) filterQuarantined(
+	priceUpdates map[types.InternalEncodedAssetID]types.AggregatedSignedPrice,
+	now time.Time,
+) map[types.InternalEncodedAssetID]types.AggregatedSignedPrice {
+	if len(eci.quarantined) == 0 {
+		return priceUpdates
+	}
+
+	filtered := make(map[types.InternalEncodedAssetID]types.AggregatedSignedPrice, len(priceUpdates))
+
+	for encodedAssetID, priceUpdate := range priceUpdates {
+		until, ok := eci.quarantined[encodedAssetID]
+		if ok && now.Before(until) {
+			continue
+		}
+
+		if ok {
+			delete(eci.quarantined, encodedAssetID)
+			eci.logger.Info().Str("assetID", string(priceUpdate.AssetID)).Msg("Asset released from quarantine")
+		}
+
+		filtered[encodedAssetID] = priceUpdate
+	}
+
+	return filtered
+}
@@ -0,0 +1,168 @@
+package evm
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/evm/bindings"
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTransport = errors.New("connection refused")
+
+func makeSimulationInputs(ids ...byte) []bindings.StorkStructsTemporalNumericValueInput {
+	inputs := make([]bindings.StorkStructsTemporalNumericValueInput, len(ids))
+	for i, id := range ids {
+		inputs[i].Id[31] = id
+	}
+
+	return inputs
+}
+
+func TestBisectRevertingUpdates(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		ids       []byte
+		bad       []byte
+		wantCalls int
+	}{
+		{name: "no reverts", ids: []byte{1, 2, 3, 4}, bad: nil, wantCalls: 1},
+		{name: "single bad update", ids: []byte{1, 2, 3, 4, 5, 6, 7, 8}, bad: []byte{6}, wantCalls: 7},
+		{name: "multiple bad updates", ids: []byte{1, 2, 3, 4}, bad: []byte{1, 4}, wantCalls: 7},
+		{name: "all bad updates", ids: []byte{1, 2}, bad: []byte{1, 2}, wantCalls: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			badSet := make(map[byte]bool)
+			for _, id := range tt.bad {
+				badSet[id] = true
+			}
+
+			calls := 0
+			simulate := func(subset []bindings.StorkStructsTemporalNumericValueInput) error {
+				calls++
+
+				for _, update := range subset {
+					if badSet[update.Id[31]] {
+						return &RevertError{Name: "InvalidSignature"}
+					}
+				}
+
+				return nil
+			}
+
+			reverting, err := bisectRevertingUpdates(makeSimulationInputs(tt.ids...), simulate)
+			require.NoError(t, err)
+			assert.Len(t, reverting, len(tt.bad))
+
+			for _, id := range tt.bad {
+				var encoded types.InternalEncodedAssetID
+				encoded[31] = id
+
+				assert.Contains(t, reverting, encoded)
+			}
+
+			assert.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
+
+func TestBisectRevertingUpdatesTransportError(t *testing.T) {
+	t.Parallel()
+
+	simulate := func(_ []bindings.StorkStructsTemporalNumericValueInput) error {
+		return errTransport
+	}
+
+	reverting, err := bisectRevertingUpdates(makeSimulationInputs(1, 2, 3), simulate)
+	require.ErrorIs(t, err, errTransport)
+	assert.Nil(t, reverting)
+}
+
+func TestDecodeRevertReason(t *testing.T) {
+	t.Parallel()
+
+	contractAbi, err := bindings.StorkContractMetaData.GetAbi()
+	require.NoError(t, err)
+
+	staleValue := contractAbi.Errors["StaleValue"]
+	revertErr, err := decodeRevertReason(contractAbi, staleValue.ID[:4])
+	require.NoError(t, err)
+	assert.Equal(t, "StaleValue", revertErr.Name)
+	assert.Equal(t, "execution reverted: StaleValue()", revertErr.Error())
+
+	_, err = decodeRevertReason(contractAbi, []byte{0xde, 0xad, 0xbe, 0xef})
+	require.ErrorIs(t, err, ErrUnknownRevertReason)
+
+	_, err = decodeRevertReason(contractAbi, []byte{0x01})
+	require.ErrorIs(t, err, ErrUnknownRevertReason)
+}
+
+func TestUpdateCallDataMatchesEncoding(t *testing.T) {
+	t.Parallel()
+
+	contractAbi, err := bindings.StorkContractMetaData.GetAbi()
+	require.NoError(t, err)
+
+	inputs := makeBatchingInputs(2)
+
+	tests := []struct {
+		encoding UpdateEncoding
+		method   string
+	}{
+		{UpdateEncodingAuto, "updateTemporalNumericValuesV1Packed"},
+		{UpdateEncodingPacked, "updateTemporalNumericValuesV1Packed"},
+		{UpdateEncodingUnpacked, "updateTemporalNumericValuesV1"},
+	}
+
+	for _, tt := range tests {
+		eci := &ContractInteractor{
+			updateEncoding: tt.encoding,
+			capabilities:   ContractCapabilities{PackedUpdates: true},
+		}
+
+		callData, err := eci.updateCallData(contractAbi, inputs)
+		require.NoError(t, err)
+		assert.Equal(t, contractAbi.Methods[tt.method].ID, callData[:4], "encoding %s", tt.encoding)
+	}
+}
+
+func TestFilterQuarantined(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	var quarantinedID, expiredID, freeID types.InternalEncodedAssetID
+	quarantinedID[31] = 1
+	expiredID[31] = 2
+	freeID[31] = 3
+
+	eci := &ContractInteractor{
+		logger: zerolog.Nop(),
+		quarantined: map[types.InternalEncodedAssetID]time.Time{
+			quarantinedID: now.Add(time.Minute),
+			expiredID:     now.Add(-time.Second),
+		},
+	}
+
+	filtered := eci.filterQuarantined(map[types.InternalEncodedAssetID]types.AggregatedSignedPrice{
+		quarantinedID: {AssetID: "A"},
+		expiredID:     {AssetID: "B"},
+		freeID:        {AssetID: "C"},
+	}, now)
+
+	assert.NotContains(t, filtered, quarantinedID)
+	assert.Contains(t, filtered, expiredID)
+	assert.Contains(t, filtered, freeID)
+	assert.NotContains(t, eci.quarantined, expiredID)
+	assert.Contains(t, eci.quarantined, quarantinedID)
+}
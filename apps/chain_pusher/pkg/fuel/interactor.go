@@ -22,12 +22,16 @@ type ContractInteractor struct {
 	privateKey      string
 	contractAddress string
 	contract        *bindings.StorkContract
+	puller          *pusher.ConcurrentPuller
 }
 
 func NewContractInteractor(
 	contractAddress string,
 	keyFileContent []byte,
 	logger zerolog.Logger,
+	pullConcurrency int,
+	limitPerSecond int,
+	burstLimit int,
 ) (*ContractInteractor, error) {
 	logger = logger.With().Str("component", "fuel-contract-interactor").Logger()
 
@@ -41,6 +45,7 @@ func NewContractInteractor(
 		logger:          logger,
 		privateKey:      privateKey,
 		contractAddress: contractAddress,
+		puller:          pusher.NewConcurrentPuller(pullConcurrency, limitPerSecond, burstLimit),
 	}, nil
 }
 
@@ -74,66 +79,42 @@ func (fci *ContractInteractor) ListenContractEvents(
 }
 
 func (fci *ContractInteractor) PullValues(
-	_ context.Context, // a 5 second timeout is hardcoded in the ffi library
+	ctx context.Context,
 	encodedAssetIDs []types.InternalEncodedAssetID,
 ) (map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue, error) {
-	result := make(map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue)
-
-	var failedToGetLatestValueErr error
-
-	for _, assetID := range encodedAssetIDs {
-		// Convert asset ID to hex string
-		idHex := hex.EncodeToString(assetID[:])
-
-		// Convert hex string to bytes for FFI call
-		idBytes, err := hex.DecodeString(idHex)
-		if err != nil {
-			fci.logger.Error().Err(err).Str("asset_id", idHex).Msg("Failed to decode asset ID")
-
-			continue
-		}
-
-		// Ensure we have exactly 32 bytes
-		//nolint:mnd // 32 bytes is the expected length for a Fuel asset ID.
-		if len(idBytes) != 32 {
-			fci.logger.Error().Str("asset_id", idHex).Msg("Asset ID must be 32 bytes")
-
-			continue
-		}
+	result, err := fci.puller.Pull(ctx, encodedAssetIDs, fci.pullValue)
+	if err != nil {
+		return result, fmt.Errorf("failed to pull values: %w", err)
+	}
 
-		// Call FFI function
-		valueJSON, err := fci.contract.GetTemporalNumericValueUncheckedV1([32]byte(idBytes))
-		if err != nil {
-			if strings.Contains(err.Error(), "feed not found") {
-				fci.logger.Warn().Err(err).Str("asset_id", idHex).Msg("No value found")
-			} else {
-				fci.logger.Warn().Err(err).Str("asset_id", idHex).Msg("Failed to get temporal numeric value")
-				failedToGetLatestValueErr = err
-			}
+	return result, nil
+}
 
-			continue
-		}
+// pullValue reads a single feed, as the contract has no function for reading multiple feeds.
+// The context is only checked between reads, as a 5 second timeout is hardcoded in the ffi library.
+func (fci *ContractInteractor) pullValue(
+	_ context.Context,
+	assetID types.InternalEncodedAssetID,
+) (types.InternalTemporalNumericValue, error) {
+	idHex := hex.EncodeToString(assetID[:])
 
-		// Convert to internal format
+	valueJSON, err := fci.contract.GetTemporalNumericValueUncheckedV1(assetID)
+	if err != nil {
+		if strings.Contains(err.Error(), "feed not found") {
+			fci.logger.Warn().Err(err).Str("asset_id", idHex).Msg("No value found")
 
-		internalValue := types.InternalTemporalNumericValue{
-			TimestampNs:    valueJSON.TimestampNs,
-			QuantizedValue: valueJSON.QuantizedValue,
+			return types.InternalTemporalNumericValue{}, fmt.Errorf("%w: %w", pusher.ErrFeedNotFound, err)
 		}
 
-		result[assetID] = internalValue
-	}
-
-	if failedToGetLatestValueErr != nil {
-		err := fmt.Errorf(
-			"failed to pull at least one value from the contract. Last error: %w",
-			failedToGetLatestValueErr,
-		)
+		fci.logger.Warn().Err(err).Str("asset_id", idHex).Msg("Failed to get temporal numeric value")
 
-		return result, err
+		return types.InternalTemporalNumericValue{}, fmt.Errorf("failed to get temporal numeric value: %w", err)
 	}
 
-	return result, nil
+	return types.InternalTemporalNumericValue{
+		TimestampNs:    valueJSON.TimestampNs,
+		QuantizedValue: valueJSON.QuantizedValue,
+	}, nil
 }
 
 func (fci *ContractInteractor) BatchPushToContract(
@@ -24,6 +24,15 @@ func NewPushCmd() *cobra.Command {
 	pushCmd.Flags().IntP(pusher.BatchingWindowFlag, "b", pusher.DefaultBatchingWindow, pusher.BatchingWindowDesc)
 	pushCmd.Flags().String(pusher.BatchingWindowStrFlag, "", pusher.BatchingWindowStrDesc)
 	pushCmd.Flags().IntP(pusher.PollingPeriodFlag, "p", pusher.DefaultPollingPeriod, pusher.PollingPeriodDesc)
+	pushCmd.Flags().Float64(pusher.MaxSpendPerHourFlag, 0, pusher.MaxSpendPerHourDesc)
+	pushCmd.Flags().Float64(pusher.MaxSpendPerDayFlag, 0, pusher.MaxSpendPerDayDesc)
+	pushCmd.Flags().String(pusher.SpendStateFileFlag, "", pusher.SpendStateFileDesc)
+	pushCmd.Flags().String(pusher.MetricsAddressFlag, "", pusher.MetricsAddressDesc)
+	pushCmd.Flags().Int(pusher.MaxAssetsPerWindowFlag, 0, pusher.MaxAssetsPerWindowDesc)
+	pushCmd.Flags().BoolP(pusher.VerifyPublishersFlag, "v", false, pusher.VerifyPublishersDesc)
+	pushCmd.Flags().Int(pusher.PullConcurrencyFlag, pusher.DefaultPullConcurrency, pusher.PullConcurrencyDesc)
+	pushCmd.Flags().IntP(pusher.LimitPerSecondFlag, "l", pusher.DefaultPullLimitPerSecond, pusher.LimitPerSecondDesc)
+	pushCmd.Flags().Int(pusher.BurstLimitFlag, pusher.DefaultPullBurstLimit, pusher.BurstLimitDesc)
 
 	pushCmd.MarkFlagsMutuallyExclusive(pusher.BatchingWindowFlag, pusher.BatchingWindowStrFlag)
 
@@ -47,6 +56,15 @@ func runPush(cmd *cobra.Command, args []string) {
 	batchingWindow, _ := cmd.Flags().GetInt(pusher.BatchingWindowFlag)
 	batchingWindowStr, _ := cmd.Flags().GetString(pusher.BatchingWindowStrFlag)
 	pollingPeriod, _ := cmd.Flags().GetInt(pusher.PollingPeriodFlag)
+	maxSpendPerHour, _ := cmd.Flags().GetFloat64(pusher.MaxSpendPerHourFlag)
+	maxSpendPerDay, _ := cmd.Flags().GetFloat64(pusher.MaxSpendPerDayFlag)
+	spendStateFile, _ := cmd.Flags().GetString(pusher.SpendStateFileFlag)
+	metricsAddress, _ := cmd.Flags().GetString(pusher.MetricsAddressFlag)
+	maxAssetsPerWindow, _ := cmd.Flags().GetInt(pusher.MaxAssetsPerWindowFlag)
+	verifyPublishers, _ := cmd.Flags().GetBool(pusher.VerifyPublishersFlag)
+	pullConcurrency, _ := cmd.Flags().GetInt(pusher.PullConcurrencyFlag)
+	limitPerSecond, _ := cmd.Flags().GetInt(pusher.LimitPerSecondFlag)
+	burstLimit, _ := cmd.Flags().GetInt(pusher.BurstLimitFlag)
 
 	logger := PusherLogger(chainRpcUrl, contractAddress)
 
@@ -55,7 +73,14 @@ func runPush(cmd *cobra.Command, args []string) {
 		logger.Fatal().Err(err).Msg("Failed to read private key file")
 	}
 
-	interactor, err := NewContractInteractor(contractAddress, keyFileContent, logger)
+	interactor, err := NewContractInteractor(
+		contractAddress,
+		keyFileContent,
+		logger,
+		pullConcurrency,
+		limitPerSecond,
+		burstLimit,
+	)
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Failed to initialize contract interactor")
 	}
@@ -63,6 +88,13 @@ func runPush(cmd *cobra.Command, args []string) {
 	// Ensure cleanup on exit
 	defer interactor.Close()
 
+	pusher.StartMetricsServer(metricsAddress, logger)
+
+	budget, err := pusher.NewSpendBudget(maxSpendPerHour, maxSpendPerDay, spendStateFile, logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to initialize spend budget")
+	}
+
 	pusher := pusher.NewPusher(
 		storkWsEndpoint,
 		storkAuth,
@@ -73,7 +105,10 @@ func runPush(cmd *cobra.Command, args []string) {
 		batchingWindowStr,
 		batchingWindow,
 		pollingPeriod,
+		verifyPublishers,
+		maxAssetsPerWindow,
 		interactor,
+		budget,
 		&logger,
 	)
 	pusher.Run(context.Background())
@@ -165,6 +165,17 @@ type TemporalNumericValue struct {
 	QuantizedValue I128   `json:"quantized_value"`
 }
 
+// UpdateEventTypeSuffix ends the type tag of the contract's update event, after the module address.
+const UpdateEventTypeSuffix = "::event::TemporalNumericValueUpdateEvent"
+
+// TemporalNumericValueUpdateEvent is the JSON data of the event emitted when a feed is updated.
+type TemporalNumericValueUpdateEvent struct {
+	AssetID struct {
+		Bytes string `json:"bytes"`
+	} `json:"asset_id"`
+	TemporalNumericValue TemporalNumericValue `json:"temporal_numeric_value"`
+}
+
 // UpdateData is a data structure for an update to a Stork feed.
 type UpdateData struct {
 	ID                              []byte
@@ -0,0 +1,157 @@
+package initia_minimove
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/cometbft"
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/initia_minimove/bindings"
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/types"
+	abci "github.com/cometbft/cometbft/abci/types"
+)
+
+var ErrInvalidUpdateEvent = errors.New("invalid temporal numeric value update event")
+
+const (
+	// moveEventType is the ABCI event type MoveVM events are emitted under, with the Move type tag and JSON data
+	// as attributes.
+	moveEventType       = "move"
+	typeTagAttrKey      = "type_tag"
+	eventDataAttrKey    = "data"
+	addressSeparator    = "::"
+	hexPrefix           = "0x"
+	normalizedZeroAddr  = "0"
+	updateEventTypeAttr = moveEventType + "." + typeTagAttrKey
+)
+
+// ListenContractEvents subscribes to the contract's update events over the CometBFT websocket, reconnecting
+// whenever the connection drops.
+func (ici *ContractInteractor) ListenContractEvents(
+	ctx context.Context, ch chan map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue,
+) {
+	if ici.wsUrl == "" {
+		ici.logger.Warn().Msg("No websocket url configured, relying on contract polling only")
+
+		return
+	}
+
+	// type tags may print the module address in a different form than configured, so the address is matched
+	// when parsing rather than in the query
+	query := fmt.Sprintf("tm.event='Tx' AND %s CONTAINS '%s'", updateEventTypeAttr, bindings.UpdateEventTypeSuffix)
+	subscriber := cometbft.NewTxEventSubscriber(ici.wsUrl, query, cometbft.DefaultReconnectDelay, ici.logger)
+
+	subscriber.Run(ctx, func(events []abci.Event) {
+		updates, err := parseUpdateEvents(events, ici.contractAddress)
+		if err != nil {
+			ici.logger.Warn().Err(err).Msg("Failed to parse contract update events")
+		}
+
+		if len(updates) == 0 {
+			return
+		}
+
+		select {
+		case ch <- updates:
+		case <-ctx.Done():
+		}
+	})
+}
+
+// parseUpdateEvents extracts the newest value per asset from the contract's update events in a transaction,
+// skipping malformed events.
+func parseUpdateEvents(
+	events []abci.Event,
+	contractAddress string,
+) (map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue, error) {
+	updates := make(map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue)
+	expectedTypeTag := normalizeAddress(contractAddress) + bindings.UpdateEventTypeSuffix
+
+	var parseErr error
+
+	for _, event := range events {
+		if event.Type != moveEventType {
+			continue
+		}
+
+		var typeTag, data string
+
+		for _, attr := range event.Attributes {
+			switch attr.Key {
+			case typeTagAttrKey:
+				typeTag = attr.Value
+			case eventDataAttrKey:
+				data = attr.Value
+			}
+		}
+
+		if normalizeTypeTag(typeTag) != expectedTypeTag {
+			continue
+		}
+
+		encodedAssetID, value, err := parseUpdateEventData(data)
+		if err != nil {
+			parseErr = err
+
+			continue
+		}
+
+		existing, ok := updates[encodedAssetID]
+		if ok && existing.TimestampNs >= value.TimestampNs {
+			continue
+		}
+
+		updates[encodedAssetID] = value
+	}
+
+	return updates, parseErr
+}
+
+func parseUpdateEventData(
+	data string,
+) (types.InternalEncodedAssetID, types.InternalTemporalNumericValue, error) {
+	var (
+		event          bindings.TemporalNumericValueUpdateEvent
+		encodedAssetID types.InternalEncodedAssetID
+	)
+
+	err := json.Unmarshal([]byte(data), &event)
+	if err != nil {
+		return encodedAssetID, types.InternalTemporalNumericValue{}, fmt.Errorf("%w: %w", ErrInvalidUpdateEvent, err)
+	}
+
+	assetID, err := hex.DecodeString(strings.TrimPrefix(event.AssetID.Bytes, hexPrefix))
+	if err != nil || len(assetID) != len(encodedAssetID) {
+		return encodedAssetID, types.InternalTemporalNumericValue{}, fmt.Errorf(
+			"%w: invalid asset id %q", ErrInvalidUpdateEvent, event.AssetID.Bytes,
+		)
+	}
+
+	copy(encodedAssetID[:], assetID)
+
+	return encodedAssetID, temporalNumericValueToInternal(event.TemporalNumericValue), nil
+}
+
+// normalizeTypeTag normalizes the address of a Move type tag like 0x00ab::module::Struct.
+func normalizeTypeTag(typeTag string) string {
+	address, rest, ok := strings.Cut(typeTag, addressSeparator)
+	if !ok {
+		return typeTag
+	}
+
+	return normalizeAddress(address) + addressSeparator + rest
+}
+
+// normalizeAddress formats a hex address in lower case without leading zeros, as addresses are printed both
+// in full and in short form.
+func normalizeAddress(address string) string {
+	trimmed := strings.TrimLeft(strings.TrimPrefix(strings.ToLower(address), hexPrefix), "0")
+	if trimmed == "" {
+		trimmed = normalizedZeroAddr
+	}
+
+	return hexPrefix + trimmed
+}
@@ -0,0 +1,93 @@
+package initia_minimove
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/types"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testContractAddress = "0x0a4c8e2b0f5f16a02e3c7b9c28b4b0e1d2e3f4a5b6c7d8e9f0a1b2c3d4e5f6a7"
+
+func moveEvent(typeTag string, idByte string, timestampNs string, magnitude string, negative string) abci.Event {
+	return abci.Event{
+		Type: moveEventType,
+		Attributes: []abci.EventAttribute{
+			{Key: typeTagAttrKey, Value: typeTag},
+			{Key: eventDataAttrKey, Value: `{"asset_id":{"bytes":"0x` + idByte + strings.Repeat("00", 31) + `"},` +
+				`"temporal_numeric_value":{"timestamp_ns":"` + timestampNs + `",` +
+				`"quantized_value":{"magnitude":"` + magnitude + `","negative":` + negative + `}}}`},
+		},
+	}
+}
+
+func TestParseUpdateEvents(t *testing.T) {
+	t.Parallel()
+
+	var first, second types.InternalEncodedAssetID
+	first[0] = 1
+	second[0] = 2
+
+	fullTypeTag := testContractAddress + "::event::TemporalNumericValueUpdateEvent"
+	shortTypeTag := "0xA4C8E2B0F5F16A02E3C7B9C28B4B0E1D2E3F4A5B6C7D8E9F0A1B2C3D4E5F6A7::event::TemporalNumericValueUpdateEvent"
+
+	tests := []struct {
+		name      string
+		events    []abci.Event
+		expected  map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue
+		wantError bool
+	}{
+		{
+			name: "newest value per asset",
+			events: []abci.Event{
+				{Type: "message", Attributes: []abci.EventAttribute{{Key: "action", Value: "execute"}}},
+				moveEvent(fullTypeTag, "01", "10", "100", "false"),
+				moveEvent(shortTypeTag, "02", "5", "50", "true"),
+				moveEvent(fullTypeTag, "01", "20", "200", "false"),
+				moveEvent(fullTypeTag, "01", "15", "150", "false"),
+			},
+			expected: map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue{
+				first:  {TimestampNs: 20, QuantizedValue: big.NewInt(200)},
+				second: {TimestampNs: 5, QuantizedValue: big.NewInt(-50)},
+			},
+		},
+		{
+			name: "other module",
+			events: []abci.Event{
+				moveEvent("0x1::event::TemporalNumericValueUpdateEvent", "01", "10", "100", "false"),
+				moveEvent(testContractAddress+"::event::StorkInitializationEvent", "01", "10", "100", "false"),
+			},
+			expected: map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue{},
+		},
+		{
+			name: "malformed event is skipped",
+			events: []abci.Event{
+				moveEvent(fullTypeTag, "01", "not a timestamp", "100", "false"),
+				moveEvent(fullTypeTag, "02", "5", "50", "false"),
+			},
+			expected: map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue{
+				second: {TimestampNs: 5, QuantizedValue: big.NewInt(50)},
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			updates, err := parseUpdateEvents(tt.events, testContractAddress)
+			if tt.wantError {
+				require.ErrorIs(t, err, ErrInvalidUpdateEvent)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.expected, updates)
+		})
+	}
+}
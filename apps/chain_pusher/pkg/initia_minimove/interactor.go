@@ -22,12 +22,14 @@ type ContractInteractor struct {
 	pollingPeriodSec int
 	mnemonic         string
 	contractAddress  string
+	wsUrl            string
 	gasPrice         float64
 	gasAdjustment    float64
 	denom            string
 	chainID          string
 
 	contract *bindings.StorkContract
+	puller   *pusher.ConcurrentPuller
 }
 
 func NewContractInteractor(
@@ -39,6 +41,9 @@ func NewContractInteractor(
 	gasAdjustment float64,
 	denom string,
 	chainID string,
+	pullConcurrency int,
+	limitPerSecond int,
+	burstLimit int,
 ) (*ContractInteractor, error) {
 	logger = logger.With().Str("component", "initia-minimove-contract-interactor").Logger()
 
@@ -54,6 +59,7 @@ func NewContractInteractor(
 		gasAdjustment:    gasAdjustment,
 		denom:            denom,
 		chainID:          chainID,
+		puller:           pusher.NewConcurrentPuller(pullConcurrency, limitPerSecond, burstLimit),
 	}, nil
 }
 
@@ -76,70 +82,48 @@ func (ici *ContractInteractor) ConnectHTTP(_ context.Context, url string) error
 	return nil
 }
 
-func (ici *ContractInteractor) ConnectWs(ctx context.Context, url string) error {
-	// not implemented
-	return nil
-}
+// ConnectWs records the CometBFT RPC url to subscribe to events on. The connection itself is made, and remade
+// after drops, by ListenContractEvents.
+func (ici *ContractInteractor) ConnectWs(_ context.Context, url string) error {
+	ici.wsUrl = url
 
-// ListenContractEvents is a placeholder function for the contract interactor.
-// unfortunately, Initia MiniMove doesn't currently support websocket RPCs
-// this contract does emit events, so this can be implemented in the future if Initia re-adds websocket support.
-func (ici *ContractInteractor) ListenContractEvents(
-	ctx context.Context, ch chan map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue,
-) {
-	ici.logger.Warn().
-		Msg("Initia MiniMove does not currently support listening to events via websocket, falling back to polling")
+	return nil
 }
 
 func (ici *ContractInteractor) PullValues(
 	ctx context.Context,
 	encodedAssetIDs []types.InternalEncodedAssetID,
 ) (map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue, error) {
-	polledVals := make(map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue)
-
-	var failedToGetLatestValueErr error
+	polledVals, err := ici.puller.Pull(ctx, encodedAssetIDs, ici.pullValue)
 
-	for _, encodedAssetID := range encodedAssetIDs {
-		value, err := ici.contract.GetTemporalNumericValueUnchecked(ctx, encodedAssetID[:])
-		if err != nil {
-			if errors.Is(err, bindings.ErrFeedNotFound) {
-				ici.logger.Warn().Err(err).Str("assetID", hex.EncodeToString(encodedAssetID[:])).Msg("No value found")
-			} else {
-				ici.logger.Warn().Err(err).Str("assetID", hex.EncodeToString(encodedAssetID[:])).Msg("Failed to get latest value")
-				failedToGetLatestValueErr = err
-			}
-
-			continue
-		}
+	ici.logger.Debug().Msgf("Pulled %d values from contract", len(polledVals))
 
-		magnitude := value.QuantizedValue.Magnitude
-		negative := value.QuantizedValue.Negative
+	if err != nil {
+		return polledVals, fmt.Errorf("failed to pull values: %w", err)
+	}
 
-		signMultiplier := 1
-		if negative {
-			signMultiplier = -1
-		}
+	return polledVals, nil
+}
 
-		quantizedValue := new(big.Int).Mul(magnitude, big.NewInt(int64(signMultiplier)))
+// pullValue reads a single feed, as the contract has no view function for multiple feeds.
+func (ici *ContractInteractor) pullValue(
+	ctx context.Context,
+	encodedAssetID types.InternalEncodedAssetID,
+) (types.InternalTemporalNumericValue, error) {
+	value, err := ici.contract.GetTemporalNumericValueUnchecked(ctx, encodedAssetID[:])
+	if err != nil {
+		if errors.Is(err, bindings.ErrFeedNotFound) {
+			ici.logger.Warn().Err(err).Str("assetID", hex.EncodeToString(encodedAssetID[:])).Msg("No value found")
 
-		polledVals[encodedAssetID] = types.InternalTemporalNumericValue{
-			TimestampNs:    value.TimestampNs,
-			QuantizedValue: quantizedValue,
+			return types.InternalTemporalNumericValue{}, fmt.Errorf("%w: %w", pusher.ErrFeedNotFound, err)
 		}
-	}
-
-	ici.logger.Debug().Msgf("Pulled %d values from contract", len(polledVals))
 
-	if failedToGetLatestValueErr != nil {
-		err := fmt.Errorf(
-			"failed to pull at least one value from the contract. Last error: %w",
-			failedToGetLatestValueErr,
-		)
+		ici.logger.Warn().Err(err).Str("assetID", hex.EncodeToString(encodedAssetID[:])).Msg("Failed to get latest value")
 
-		return polledVals, err
+		return types.InternalTemporalNumericValue{}, fmt.Errorf("failed to get latest value: %w", err)
 	}
 
-	return polledVals, nil
+	return temporalNumericValueToInternal(*value), nil
 }
 
 func (ici *ContractInteractor) BatchPushToContract(
@@ -180,6 +164,23 @@ func (ici *ContractInteractor) GetWalletBalance(ctx context.Context) (float64, e
 	return -1, nil
 }
 
+func temporalNumericValueToInternal(value bindings.TemporalNumericValue) types.InternalTemporalNumericValue {
+	magnitude := value.QuantizedValue.Magnitude
+	negative := value.QuantizedValue.Negative
+
+	signMultiplier := 1
+	if negative {
+		signMultiplier = -1
+	}
+
+	quantizedValue := new(big.Int).Mul(magnitude, big.NewInt(int64(signMultiplier)))
+
+	return types.InternalTemporalNumericValue{
+		TimestampNs:    value.TimestampNs,
+		QuantizedValue: quantizedValue,
+	}
+}
+
 func aggregatedSignedPriceToUpdateData(
 	price types.AggregatedSignedPrice,
 ) (bindings.UpdateData, error) {
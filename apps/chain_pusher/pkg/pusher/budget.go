@@ -0,0 +1,304 @@
+package pusher
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog"
+)
+
+var (
+	ErrInvalidSpendLimit    = errors.New("spend limit must not be negative")
+	ErrUnknownWalletBalance = errors.New("wallet balance is not reported by this chain")
+)
+
+const (
+	spendHourWindow = time.Hour
+	spendDayWindow  = 24 * time.Hour
+)
+
+// updateTrigger is the reason an asset was added to a batch.
+type updateTrigger string
+
+const (
+	triggerPushEveryBatch updateTrigger = "push_every_batch"
+	triggerMissingValue   updateTrigger = "missing_value"
+	triggerFallback       updateTrigger = "fallback"
+	triggerDelta          updateTrigger = "delta"
+)
+
+// triggerForReason maps the reason an asset's update trigger fired to the batch trigger.
+func triggerForReason(reason types.UpdateReason) updateTrigger {
+	if reason == types.UpdateReasonHeartbeat {
+		return triggerFallback
+	}
+
+	return triggerDelta
+}
+
+// throttleable reports whether updates with this trigger may be dropped to stay within the spend budget. Fallback
+// updates keep feeds from going stale and assets without an on chain value have never been pushed, so both are
+// always kept.
+func (t updateTrigger) throttleable() bool {
+	return t == triggerDelta || t == triggerPushEveryBatch
+}
+
+var (
+	budgetSpend = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "stork",
+		Subsystem: "chain_pusher",
+		Name:      "budget_spend",
+		Help:      "Native token spent on pushes within the rolling budget window, in the wallet balance unit",
+	}, []string{"window"})
+	budgetThrottledUpdatesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "stork",
+		Subsystem: "chain_pusher",
+		Name:      "budget_throttled_updates_total",
+		Help:      "Updates dropped from a batch to stay within the spend budget",
+	}, []string{"trigger"})
+)
+
+// spendRecord is the native token spent between two wallet balance observations, and the number of updates pushed
+// in that time.
+type spendRecord struct {
+	Time       time.Time `json:"time"`
+	Amount     float64   `json:"amount"`
+	NumUpdates int       `json:"numUpdates"`
+}
+
+type spendBudgetState struct {
+	Spends []spendRecord `json:"spends"`
+}
+
+// SpendBudget caps the native token spent on pushes over a rolling hour and day. Spend is measured as the drop in
+// wallet balance after each push, in whatever unit the interactor reports its balance in, so interactors that do
+// not report a balance are never throttled. Once a push would exceed the budget, delta triggered updates are
+// dropped while fallback updates are still pushed.
+type SpendBudget struct {
+	maxPerHour float64
+	maxPerDay  float64
+	stateFile  string
+	logger     zerolog.Logger
+
+	mu          sync.Mutex
+	state       spendBudgetState
+	lastBalance float64
+	hasBalance  bool
+}
+
+// NewSpendBudget creates a spend budget, restoring the spend recorded in stateFile. A limit of 0 disables that
+// window, and an empty state file disables persistence.
+func NewSpendBudget(maxPerHour, maxPerDay float64, stateFile string, logger zerolog.Logger) (*SpendBudget, error) {
+	if maxPerHour < 0 || maxPerDay < 0 {
+		return nil, ErrInvalidSpendLimit
+	}
+
+	budget := &SpendBudget{
+		maxPerHour:  maxPerHour,
+		maxPerDay:   maxPerDay,
+		stateFile:   stateFile,
+		logger:      logger.With().Str("component", "spend-budget").Logger(),
+		mu:          sync.Mutex{},
+		state:       spendBudgetState{Spends: nil},
+		lastBalance: 0,
+		hasBalance:  false,
+	}
+
+	found, err := ReadStateFile(stateFile, &budget.state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore spend budget state: %w", err)
+	}
+
+	if found {
+		budget.prune(time.Now())
+		budget.logger.Info().
+			Float64("spentLastHour", budget.spentSince(time.Now(), spendHourWindow)).
+			Float64("spentLastDay", budget.spentSince(time.Now(), spendDayWindow)).
+			Msg("Restored spend budget state")
+	}
+
+	return budget, nil
+}
+
+// Enabled reports whether any spend limit is set.
+func (b *SpendBudget) Enabled() bool {
+	return b != nil && (b.maxPerHour > 0 || b.maxPerDay > 0)
+}
+
+// RecordBalance records the spend since the previous balance observation, attributing it to the numUpdates just
+// pushed. Negative balances are treated as unknown and balance increases, e.g. top ups, only reset the baseline.
+func (b *SpendBudget) RecordBalance(now time.Time, balance float64, numUpdates int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if balance < 0 {
+		return
+	}
+
+	previousBalance, hadBalance := b.lastBalance, b.hasBalance
+	b.lastBalance, b.hasBalance = balance, true
+
+	if !hadBalance {
+		return
+	}
+
+	spent := max(previousBalance-balance, 0)
+	b.state.Spends = append(b.state.Spends, spendRecord{Time: now, Amount: spent, NumUpdates: numUpdates})
+	b.prune(now)
+
+	hourSpend := b.spentSince(now, spendHourWindow)
+	daySpend := b.spentSince(now, spendDayWindow)
+
+	budgetSpend.WithLabelValues("hour").Set(hourSpend)
+	budgetSpend.WithLabelValues("day").Set(daySpend)
+
+	b.logger.Debug().
+		Float64("spent", spent).
+		Float64("spentLastHour", hourSpend).
+		Float64("spentLastDay", daySpend).
+		Msg("Recorded push spend")
+
+	err := WriteStateFile(b.stateFile, b.state)
+	if err != nil {
+		b.logger.Error().Err(err).Msg("Failed to persist spend budget state")
+	}
+}
+
+// Apply drops throttleable updates that would take the spend past either limit, given the average cost per update
+// seen over the last day. Updates that are never throttled are counted against the budget first, and the rest are
+// kept in asset ID order while they fit.
+func (b *SpendBudget) Apply(
+	now time.Time,
+	updates updateBatch,
+	triggers map[types.InternalEncodedAssetID]updateTrigger,
+) updateBatch {
+	if !b.Enabled() || len(updates) == 0 {
+		return updates
+	}
+
+	b.mu.Lock()
+	remaining := b.remaining(now)
+	costPerUpdate := b.costPerUpdate(now)
+	b.mu.Unlock()
+
+	if remaining > 0 && costPerUpdate*float64(len(updates)) <= remaining {
+		return updates
+	}
+
+	kept := make(updateBatch, len(updates))
+	throttleable := make([]types.InternalEncodedAssetID, 0, len(updates))
+
+	for encodedAssetID, update := range updates {
+		if triggers[encodedAssetID].throttleable() {
+			throttleable = append(throttleable, encodedAssetID)
+
+			continue
+		}
+
+		kept[encodedAssetID] = update
+	}
+
+	slices.SortFunc(throttleable, func(a, b types.InternalEncodedAssetID) int {
+		return bytes.Compare(a[:], b[:])
+	})
+
+	budgetLeft := remaining - costPerUpdate*float64(len(kept))
+	throttled := make(map[updateTrigger]int)
+
+	for _, encodedAssetID := range throttleable {
+		if budgetLeft > 0 && costPerUpdate <= budgetLeft {
+			kept[encodedAssetID] = updates[encodedAssetID]
+			budgetLeft -= costPerUpdate
+
+			continue
+		}
+
+		throttled[triggers[encodedAssetID]]++
+	}
+
+	if len(throttled) > 0 {
+		for trigger, count := range throttled {
+			budgetThrottledUpdatesTotal.WithLabelValues(string(trigger)).Add(float64(count))
+		}
+
+		b.logger.Warn().
+			Int("kept", len(kept)).
+			Int("throttledDelta", throttled[triggerDelta]).
+			Int("throttledPushEveryBatch", throttled[triggerPushEveryBatch]).
+			Float64("remainingBudget", remaining).
+			Float64("estimatedCostPerUpdate", costPerUpdate).
+			Msg("Spend budget exceeded, throttling updates")
+	}
+
+	return kept
+}
+
+// remaining returns the spend left before the tighter of the two limits is reached.
+func (b *SpendBudget) remaining(now time.Time) float64 {
+	var remaining float64
+
+	set := false
+
+	for _, limit := range []struct {
+		max    float64
+		window time.Duration
+	}{{b.maxPerHour, spendHourWindow}, {b.maxPerDay, spendDayWindow}} {
+		if limit.max == 0 {
+			continue
+		}
+
+		windowRemaining := limit.max - b.spentSince(now, limit.window)
+		if !set || windowRemaining < remaining {
+			remaining, set = windowRemaining, true
+		}
+	}
+
+	return remaining
+}
+
+// costPerUpdate returns the average spend per pushed update over the last day, or 0 before anything was recorded.
+func (b *SpendBudget) costPerUpdate(now time.Time) float64 {
+	var (
+		spent      float64
+		numUpdates int
+	)
+
+	for _, record := range b.state.Spends {
+		if now.Sub(record.Time) <= spendDayWindow {
+			spent += record.Amount
+			numUpdates += record.NumUpdates
+		}
+	}
+
+	if numUpdates == 0 {
+		return 0
+	}
+
+	return spent / float64(numUpdates)
+}
+
+func (b *SpendBudget) spentSince(now time.Time, window time.Duration) float64 {
+	var spent float64
+
+	for _, record := range b.state.Spends {
+		if now.Sub(record.Time) <= window {
+			spent += record.Amount
+		}
+	}
+
+	return spent
+}
+
+// prune drops records that have left the day window.
+func (b *SpendBudget) prune(now time.Time) {
+	b.state.Spends = slices.DeleteFunc(b.state.Spends, func(record spendRecord) bool {
+		return now.Sub(record.Time) > spendDayWindow
+	})
+}
@@ -0,0 +1,151 @@
+package pusher
+
+import (
+	"maps"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpendBudgetApply(t *testing.T) {
+	t.Parallel()
+
+	fallbackID := types.InternalEncodedAssetID{1}
+	missingID := types.InternalEncodedAssetID{2}
+	deltaID := types.InternalEncodedAssetID{3}
+	everyBatchID := types.InternalEncodedAssetID{4}
+
+	updates := updateBatch{
+		fallbackID:   types.AggregatedSignedPrice{AssetID: "FALLBACK"},
+		missingID:    types.AggregatedSignedPrice{AssetID: "MISSING"},
+		deltaID:      types.AggregatedSignedPrice{AssetID: "DELTA"},
+		everyBatchID: types.AggregatedSignedPrice{AssetID: "EVERYBATCH"},
+	}
+	triggers := map[types.InternalEncodedAssetID]updateTrigger{
+		fallbackID:   triggerFallback,
+		missingID:    triggerMissingValue,
+		deltaID:      triggerDelta,
+		everyBatchID: triggerPushEveryBatch,
+	}
+
+	tests := []struct {
+		name       string
+		maxPerHour float64
+		maxPerDay  float64
+		// balances observed after pushes of two updates each, one minute apart
+		balances []float64
+		expected []types.InternalEncodedAssetID
+	}{
+		{
+			name:       "no limits",
+			maxPerHour: 0,
+			maxPerDay:  0,
+			balances:   []float64{1000, 0},
+			expected:   []types.InternalEncodedAssetID{fallbackID, missingID, deltaID, everyBatchID},
+		},
+		{
+			name:       "within budget",
+			maxPerHour: 100,
+			maxPerDay:  1000,
+			balances:   []float64{1000, 980},
+			expected:   []types.InternalEncodedAssetID{fallbackID, missingID, deltaID, everyBatchID},
+		},
+		{
+			name:       "budget fits one throttleable update",
+			maxPerHour: 100,
+			maxPerDay:  1000,
+			balances:   []float64{1000, 960},
+			expected:   []types.InternalEncodedAssetID{fallbackID, missingID, deltaID},
+		},
+		{
+			name:       "hourly budget exhausted",
+			maxPerHour: 100,
+			maxPerDay:  1000,
+			balances:   []float64{1000, 880},
+			expected:   []types.InternalEncodedAssetID{fallbackID, missingID},
+		},
+		{
+			name:       "daily budget exhausted",
+			maxPerHour: 0,
+			maxPerDay:  50,
+			balances:   []float64{1000, 940},
+			expected:   []types.InternalEncodedAssetID{fallbackID, missingID},
+		},
+		{
+			name:       "top up is not spend",
+			maxPerHour: 100,
+			maxPerDay:  0,
+			balances:   []float64{1000, 5000},
+			expected:   []types.InternalEncodedAssetID{fallbackID, missingID, deltaID, everyBatchID},
+		},
+		{
+			name:       "unknown balance is ignored",
+			maxPerHour: 100,
+			maxPerDay:  0,
+			balances:   []float64{-1, -1},
+			expected:   []types.InternalEncodedAssetID{fallbackID, missingID, deltaID, everyBatchID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			budget, err := NewSpendBudget(tt.maxPerHour, tt.maxPerDay, "", zerolog.Nop())
+			require.NoError(t, err)
+
+			now := time.Now()
+			for i, balance := range tt.balances {
+				budget.RecordBalance(now.Add(time.Duration(i)*time.Minute), balance, 2)
+			}
+
+			kept := budget.Apply(now.Add(time.Duration(len(tt.balances))*time.Minute), updates, triggers)
+
+			assert.ElementsMatch(t, tt.expected, slices.Collect(maps.Keys(kept)))
+		})
+	}
+}
+
+func TestSpendBudgetWindows(t *testing.T) {
+	t.Parallel()
+
+	budget, err := NewSpendBudget(100, 150, "", zerolog.Nop())
+	require.NoError(t, err)
+
+	now := time.Now()
+	budget.RecordBalance(now, 1000, 0)
+	budget.RecordBalance(now, 900, 1)
+
+	assert.InDelta(t, 0.0, budget.remaining(now), 1e-9)
+	// the hourly spend expires first, leaving the daily limit as the tighter one
+	assert.InDelta(t, 50.0, budget.remaining(now.Add(2*time.Hour)), 1e-9)
+	assert.InDelta(t, 100.0, budget.remaining(now.Add(25*time.Hour)), 1e-9)
+}
+
+func TestSpendBudgetPersistence(t *testing.T) {
+	t.Parallel()
+
+	stateFile := filepath.Join(t.TempDir(), "spend.json")
+
+	budget, err := NewSpendBudget(100, 0, stateFile, zerolog.Nop())
+	require.NoError(t, err)
+
+	now := time.Now()
+	budget.RecordBalance(now, 1000, 0)
+	budget.RecordBalance(now, 960, 4)
+
+	restored, err := NewSpendBudget(100, 0, stateFile, zerolog.Nop())
+	require.NoError(t, err)
+
+	assert.InDelta(t, 60.0, restored.remaining(now), 1e-9)
+	assert.InDelta(t, 10.0, restored.costPerUpdate(now), 1e-9)
+
+	_, err = NewSpendBudget(-1, 0, "", zerolog.Nop())
+	require.ErrorIs(t, err, ErrInvalidSpendLimit)
+}
@@ -0,0 +1,332 @@
+package pusher
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/caarlos0/env/v11"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	ErrInvalidConfigFile = errors.New("invalid config file")
+	ErrUnknownConfigKey  = errors.New("unknown config key")
+)
+
+const (
+	// EnvPrefix prefixes the environment variable of every flag, e.g. STORK_CHAIN_RPC_URL for --chain-rpc-url.
+	EnvPrefix = "STORK_"
+
+	configSourceAnnotation = "stork_config_source"
+	redacted               = "REDACTED"
+)
+
+// Where a resolved setting came from, in order of precedence.
+const (
+	ConfigSourceFlag    = "flag"
+	ConfigSourceEnv     = "env"
+	ConfigSourceFile    = "file"
+	ConfigSourceDefault = "default"
+)
+
+// secretFlags are redacted entirely when printing the resolved config.
+//
+//nolint:gochecknoglobals
+var secretFlags = []string{StorkAuthCredentialsFlag}
+
+// EnvVarName returns the environment variable a flag can be set from.
+func EnvVarName(flagName string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+// ApplyConfig fills in every flag of cmd not set on the command line from its STORK_ environment variable, or
+// failing that from the config file. Keys at the top level of the config file apply to every subcommand, and keys
+// in a section named after the subcommand override them. Top-level keys must be a flag or the name of some
+// subcommand, as a subcommand ignores the keys meant for its siblings.
+func ApplyConfig(cmd *cobra.Command) error {
+	environment := env.ToMap(os.Environ())
+	flags := cmd.Flags()
+
+	configFile := ""
+	if configFlag := flags.Lookup(ConfigFlag); configFlag != nil {
+		err := resolveFromEnv(flags, configFlag, environment)
+		if err != nil {
+			return err
+		}
+
+		configFile = configFlag.Value.String()
+	}
+
+	fileValues, err := readConfigFile(configFile, cmd.Name())
+	if err != nil {
+		return err
+	}
+
+	for name := range fileValues.section {
+		if flags.Lookup(name) == nil {
+			return fmt.Errorf("%w: %q in the %s section", ErrUnknownConfigKey, name, cmd.Name())
+		}
+	}
+
+	knownFlags, knownSections := knownConfigKeys(cmd.Root())
+
+	for name := range fileValues.shared {
+		if _, ok := knownFlags[name]; !ok {
+			return fmt.Errorf("%w: %q", ErrUnknownConfigKey, name)
+		}
+	}
+
+	for _, name := range fileValues.otherSections {
+		if _, ok := knownSections[name]; !ok {
+			return fmt.Errorf("%w: section %q does not match any subcommand", ErrUnknownConfigKey, name)
+		}
+	}
+
+	var applyErr error
+
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if applyErr != nil || flag.Name == ConfigFlag {
+			return
+		}
+
+		applyErr = resolveFromEnv(flags, flag, environment)
+		if applyErr != nil || configSource(flag) != ConfigSourceDefault {
+			return
+		}
+
+		value, ok := fileValues.lookup(flag.Name)
+		if !ok {
+			return
+		}
+
+		applyErr = setFlag(flags, flag, value, ConfigSourceFile)
+	})
+
+	return applyErr
+}
+
+// knownConfigKeys returns the names of the flags of every command under root, and the names of the commands.
+func knownConfigKeys(root *cobra.Command) (map[string]struct{}, map[string]struct{}) {
+	flags := make(map[string]struct{})
+	commands := make(map[string]struct{})
+
+	addFlag := func(flag *pflag.Flag) {
+		flags[flag.Name] = struct{}{}
+	}
+
+	var visit func(cmd *cobra.Command)
+
+	visit = func(cmd *cobra.Command) {
+		commands[cmd.Name()] = struct{}{}
+
+		cmd.Flags().VisitAll(addFlag)
+		cmd.PersistentFlags().VisitAll(addFlag)
+
+		for _, child := range cmd.Commands() {
+			visit(child)
+		}
+	}
+
+	visit(root)
+
+	return flags, commands
+}
+
+// resolveFromEnv records whether the flag was set on the command line, and otherwise sets it from the environment.
+func resolveFromEnv(flags *pflag.FlagSet, flag *pflag.Flag, environment map[string]string) error {
+	if flag.Changed {
+		setConfigSource(flag, ConfigSourceFlag)
+
+		return nil
+	}
+
+	setConfigSource(flag, ConfigSourceDefault)
+
+	value, ok := environment[EnvVarName(flag.Name)]
+	if !ok {
+		return nil
+	}
+
+	return setFlag(flags, flag, value, ConfigSourceEnv)
+}
+
+func setFlag(flags *pflag.FlagSet, flag *pflag.Flag, value string, source string) error {
+	err := flags.Set(flag.Name, value)
+	if err != nil {
+		return fmt.Errorf("invalid %s value for %s: %w", source, flag.Name, err)
+	}
+
+	setConfigSource(flag, source)
+
+	return nil
+}
+
+func setConfigSource(flag *pflag.Flag, source string) {
+	if flag.Annotations == nil {
+		flag.Annotations = make(map[string][]string)
+	}
+
+	flag.Annotations[configSourceAnnotation] = []string{source}
+}
+
+func configSource(flag *pflag.Flag) string {
+	source := flag.Annotations[configSourceAnnotation]
+	if len(source) == 0 {
+		return ConfigSourceDefault
+	}
+
+	return source[0]
+}
+
+// configFileValues are the flag values of a config file, keyed by flag name.
+type configFileValues struct {
+	shared  map[string]string
+	section map[string]string
+	// otherSections are the names of the sections of other subcommands.
+	otherSections []string
+}
+
+func (c configFileValues) lookup(name string) (string, bool) {
+	if value, ok := c.section[name]; ok {
+		return value, true
+	}
+
+	value, ok := c.shared[name]
+
+	return value, ok
+}
+
+func readConfigFile(path string, section string) (configFileValues, error) {
+	values := configFileValues{shared: map[string]string{}, section: map[string]string{}}
+	if path == "" {
+		return values, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return values, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var raw map[string]any
+
+	err = yaml.Unmarshal(content, &raw)
+	if err != nil {
+		return values, fmt.Errorf("%w: %w", ErrInvalidConfigFile, err)
+	}
+
+	for key, value := range raw {
+		nested, isSection := value.(map[any]any)
+		if !isSection {
+			values.shared[key], err = configValueString(key, value)
+			if err != nil {
+				return values, err
+			}
+
+			continue
+		}
+
+		// sections of other subcommands are ignored
+		if key != section {
+			values.otherSections = append(values.otherSections, key)
+
+			continue
+		}
+
+		for nestedKey, nestedValue := range nested {
+			name := fmt.Sprint(nestedKey)
+
+			values.section[name], err = configValueString(name, nestedValue)
+			if err != nil {
+				return values, err
+			}
+		}
+	}
+
+	return values, nil
+}
+
+// configValueString converts a YAML value to the string form its flag parses, joining lists with commas.
+func configValueString(key string, value any) (string, error) {
+	switch typed := value.(type) {
+	case nil:
+		return "", nil
+	case []any:
+		items := make([]string, 0, len(typed))
+		for _, item := range typed {
+			items = append(items, fmt.Sprint(item))
+		}
+
+		return strings.Join(items, ","), nil
+	case map[any]any:
+		return "", fmt.Errorf("%w: %q must not be a section", ErrInvalidConfigFile, key)
+	default:
+		return fmt.Sprint(typed), nil
+	}
+}
+
+// PrintConfig writes the resolved settings of cmd as a YAML config file, annotated with where each came from, with
+// secrets and the credentials commonly embedded in RPC URLs redacted.
+func PrintConfig(cmd *cobra.Command, w io.Writer) error {
+	var lines []string
+
+	// flags are visited sorted by name
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if flag.Name == "help" || flag.Name == ConfigFlag {
+			return
+		}
+
+		lines = append(lines, fmt.Sprintf(
+			"%s: %q # %s, %s", flag.Name, redactFlagValue(flag), configSource(flag), EnvVarName(flag.Name),
+		))
+	})
+
+	_, err := fmt.Fprintf(w, "# %s\n%s\n", cmd.CommandPath(), strings.Join(lines, "\n"))
+	if err != nil {
+		return fmt.Errorf("failed to print config: %w", err)
+	}
+
+	return nil
+}
+
+func redactFlagValue(flag *pflag.Flag) string {
+	value := flag.Value.String()
+	if sliceValue, ok := flag.Value.(pflag.SliceValue); ok {
+		value = strings.Join(sliceValue.GetSlice(), ",")
+	}
+
+	if value == "" {
+		return value
+	}
+
+	if slices.Contains(secretFlags, flag.Name) {
+		return redacted
+	}
+
+	if strings.HasSuffix(flag.Name, "-url") || strings.HasSuffix(flag.Name, "-endpoint") {
+		return redactURL(value)
+	}
+
+	return value
+}
+
+// redactURL keeps only the scheme and host of a URL, as providers commonly embed API keys in the user info, path or
+// query.
+func redactURL(value string) string {
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Host == "" {
+		return redacted
+	}
+
+	if parsed.User == nil && (parsed.Path == "" || parsed.Path == "/") && parsed.RawQuery == "" {
+		return value
+	}
+
+	return parsed.Scheme + "://" + parsed.Host + "/" + redacted
+}
@@ -2,11 +2,15 @@ package pusher
 
 // Utility defaults for the pusher.
 const (
-	DefaultBatchingWindow = 5
-	DefaultPollingPeriod  = 3
+	DefaultBatchingWindow     = 5
+	DefaultPollingPeriod      = 3
+	DefaultPullConcurrency    = 16
+	DefaultPullLimitPerSecond = 100
+	DefaultPullBurstLimit     = 16
 )
 
 const (
+	ConfigFlag                 = "config"
 	StorkWebsocketEndpointFlag = "stork-ws-endpoint"
 	//nolint:gosec // This is not a credential
 	StorkAuthCredentialsFlag = "stork-auth-credentials"
@@ -20,17 +24,72 @@ const (
 )
 
 const (
-	VerifyPublishersFlag  = "verify-publishers"
-	BatchingWindowFlag    = "batching-window"
-	BatchingWindowStrFlag = "batching-window-str"
-	PollingPeriodFlag     = "polling-period"
-	LimitPerSecondFlag    = "limit-per-second"
-	BurstLimitFlag        = "burst-limit"
-	BatchSizeFlag         = "batch-size"
-	GasLimitFlag          = "gas-limit"
-	NonceManagerFlag      = "nonce-manager"
-	UseSyncSendFlag       = "use-sync-send"
-	UsePackedUpdateFlag   = "use-packed-update"
+	VerifyPublishersFlag   = "verify-publishers"
+	BatchingWindowFlag     = "batching-window"
+	BatchingWindowStrFlag  = "batching-window-str"
+	PollingPeriodFlag      = "polling-period"
+	LimitPerSecondFlag     = "limit-per-second"
+	BurstLimitFlag         = "burst-limit"
+	BatchSizeFlag          = "batch-size"
+	GasLimitFlag           = "gas-limit"
+	NonceManagerFlag       = "nonce-manager"
+	UseSyncSendFlag        = "use-sync-send"
+	UsePackedUpdateFlag    = "use-packed-update"
+	MetricsAddressFlag     = "metrics-address"
+	PullConcurrencyFlag    = "pull-concurrency"
+	MaxAssetsPerWindowFlag = "max-assets-per-window"
+)
+
+// EVM flags.
+const (
+	UpdateEncodingFlag   = "update-encoding"
+	SimulateUpdatesFlag  = "simulate-updates"
+	RevertQuarantineFlag = "revert-quarantine"
+	MaxGasPerTxFlag      = "max-gas-per-tx"
+	MaxCalldataBytesFlag = "max-calldata-bytes"
+	LogPollIntervalFlag  = "log-poll-interval"
+	LogConfirmationsFlag = "log-confirmations"
+)
+
+// Solana flags.
+const (
+	ComputeUnitLimitFlag       = "compute-unit-limit"
+	PriorityFeeModeFlag        = "priority-fee-mode"
+	PriorityFeeFlag            = "priority-fee"
+	PriorityFeePercentileFlag  = "priority-fee-percentile"
+	MaxPriorityFeeLamportsFlag = "max-priority-fee-lamports"
+	PriorityFeeRetriesFlag     = "priority-fee-retries"
+	PriorityFeeEscalationFlag  = "priority-fee-escalation"
+	UseLookupTablesFlag        = "use-lookup-tables"
+	LookupTablesFlag           = "lookup-tables"
+	DerivationPathFlag         = "derivation-path"
+	IncludeTreasuryBalanceFlag = "include-treasury-balance"
+)
+
+// Event polling flags, for chains without websocket event subscriptions.
+const (
+	EventPollIntervalFlag = "event-poll-interval"
+	EventCursorFileFlag   = "event-cursor-file"
+)
+
+// Spend budget flags.
+const (
+	MaxSpendPerHourFlag = "max-spend-per-hour"
+	MaxSpendPerDayFlag  = "max-spend-per-day"
+	SpendStateFileFlag  = "spend-state-file"
+)
+
+// Sui flags.
+const (
+	SponsorKeyFileFlag = "sponsor-key-file"
+	GasCoinsFlag       = "gas-coins"
+	GasCoinBalanceFlag = "gas-coin-balance"
+)
+
+// Aptos flags.
+const (
+	IndexerUrlFlag      = "indexer-url"
+	FeePayerKeyFileFlag = "fee-payer-key-file"
 )
 
 // Cosmwasm flags.
@@ -40,10 +99,20 @@ const (
 	DenomFlag         = "denom"
 	ChainIDFlag       = "chain-id"
 	ChainPrefixFlag   = "chain-prefix"
+	AuthzGranterFlag  = "authz-granter"
+	FeeGranterFlag    = "fee-granter"
+)
+
+// Starknet flags.
+const (
+	AccountAddressFlag  = "account-address"
+	FeeTokenAddressFlag = "fee-token-address"
+	FeeMultiplierFlag   = "fee-multiplier"
 )
 
 // Descriptions for the flags.
 const (
+	ConfigDesc                 = "YAML config file of flag values, keyed by flag name at the top level or in a section named after the chain"
 	StorkWebsocketEndpointDesc = "Stork WebSocket endpoint"
 	//nolint:gosec // This is an example of credentials, not actual credentials
 	StorkAuthCredentialsDesc = "Stork auth credentials - base64(username:password)"
@@ -60,11 +129,66 @@ const (
 	PollingPeriodDesc        = "Asset Polling Period (seconds)"
 	LimitPerSecondDesc       = "JSON RPC call limit per second"
 	BurstLimitDesc           = "JSON RPC call Burst limit"
-	BatchSizeDesc            = "Batch size between 1 and 4"
+	BatchSizeDesc            = "Maximum updates per transaction (0 to fit as many as the transaction size allows)"
 	GasLimitDesc             = "Gas limit (0 to use estimate)"
 	NonceManagerTypeDesc     = "Nonce manager type (server|serverPending|local), defaults to noop"
 	UseSyncSendDesc          = "Use sync send for transactions, defaults to false"
 	UsePackedUpdateDesc      = "Use packed calldata update (requires contract version >= 1.0.6), defaults to false"
+	MetricsAddressDesc       = "Address to serve Prometheus metrics on, e.g. ':9090' (disabled if empty)"
+	PullConcurrencyDesc      = "Maximum concurrent contract reads when pulling feed values"
+	MaxAssetsPerWindowDesc   = "Maximum assets pushed per batching window; the least urgent updates are deferred to the next window (0 for no limit)"
+)
+
+// EVM descriptions.
+const (
+	UpdateEncodingDesc   = "Update calldata encoding (auto|packed|unpacked); auto uses packed updates when the contract supports them"
+	SimulateUpdatesDesc  = "Simulate each batch with eth_call before sending and quarantine assets whose updates revert"
+	RevertQuarantineDesc = "How long an asset whose update reverted in simulation is excluded from pushes"
+	MaxGasPerTxDesc      = "Maximum estimated gas per update transaction; larger batches are split (0 for no limit)"
+	MaxCalldataBytesDesc = "Maximum calldata bytes per update transaction; larger batches are split (0 for no limit)"
+	LogPollIntervalDesc  = "eth_getLogs polling interval used when no WebSocket subscription is available (0 to disable)"
+	LogConfirmationsDesc = "Number of blocks behind head before polled contract events are processed"
+)
+
+// Solana descriptions.
+const (
+	ComputeUnitLimitDesc       = "Compute unit limit per update transaction (0 to use the runtime default)"
+	PriorityFeeModeDesc        = "Priority fee mode (none|static|percentile|max)"
+	PriorityFeeDesc            = "Compute unit price in micro-lamports used in static mode"
+	PriorityFeePercentileDesc  = "Percentile of recent prioritization fees for the feed and config accounts used in percentile mode"
+	MaxPriorityFeeLamportsDesc = "Maximum total priority fee per transaction in lamports; max mode always pays this (0 for no cap)"
+	PriorityFeeRetriesDesc     = "Number of times a batch that fails confirmation is resent with an escalated priority fee"
+	PriorityFeeEscalationDesc  = "Factor the compute unit price is multiplied by on each retry"
+	UseLookupTablesDesc        = "Push with v0 transactions using address lookup tables, creating and extending them as needed"
+	LookupTablesDesc           = "Existing address lookup tables owned by the payer to reuse (comma separated)"
+	DerivationPathDesc         = "Derivation path used when the private key file contains a BIP-39 mnemonic"
+	IncludeTreasuryBalanceDesc = "Include the lamports held by the contract treasury accounts in the reported wallet balance"
+)
+
+// Event polling descriptions.
+const (
+	EventPollIntervalDesc = "Polling interval for contract feed update events (0 to disable)"
+	EventCursorFileDesc   = "File the last processed event cursor is persisted to, so restarts resume from it"
+)
+
+// Spend budget descriptions.
+const (
+	MaxSpendPerHourDesc = "Maximum native token spent on pushes per rolling hour, in the unit the wallet balance is reported in; delta triggered updates are throttled beyond it (0 for no limit)"
+	MaxSpendPerDayDesc  = "Maximum native token spent on pushes per rolling day, in the unit the wallet balance is reported in; delta triggered updates are throttled beyond it (0 for no limit)"
+	SpendStateFileDesc  = "File recent push spend is persisted to, so the spend budget survives restarts"
+)
+
+// Sui descriptions.
+const (
+	SponsorKeyFileDesc = "Key file of a sponsor that pays the gas of update transactions (disabled if empty)"
+	GasCoinsDesc       = "Number of gas coins kept for concurrent update transactions"
+	GasCoinBalanceDesc = "Balance in MIST gas coins are split to when the gas coin pool is refilled"
+)
+
+// Aptos descriptions.
+const (
+	IndexerUrlDesc      = "Indexer GraphQL URL feed update events are read from (event listening disabled if empty)"
+	FeePayerKeyFileDesc = "Key file of a fee payer that pays the gas of update transactions (disabled if empty)"
 )
 
 // Cosmwasm descriptions.
@@ -74,4 +198,14 @@ const (
 	DenomDesc         = "Denom"
 	ChainIDDesc       = "Chain ID"
 	ChainPrefixDesc   = "Chain prefix"
+	CometBFTWsUrlDesc = "CometBFT RPC URL contract events are subscribed to over /websocket (defaults to the chain RPC URL)"
+	AuthzGranterDesc  = "Bech32 address the pusher key executes updates on behalf of through an authz grant"
+	FeeGranterDesc    = "Bech32 address paying the transaction fees through an x/feegrant allowance"
+)
+
+// Starknet descriptions.
+const (
+	AccountAddressDesc  = "Address of the deployed account contract the private key signs for"
+	FeeTokenAddressDesc = "ERC20 token the update fee is approved in and the wallet balance is reported in (defaults to STRK)"
+	FeeMultiplierDesc   = "Factor the estimated resource amounts and prices are multiplied by for the transaction bounds"
 )
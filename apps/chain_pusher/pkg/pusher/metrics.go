@@ -0,0 +1,35 @@
+package pusher
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog"
+)
+
+const metricsReadHeaderTimeout = 10 * time.Second
+
+// StartMetricsServer serves the registered prometheus metrics on /metrics in the background.
+// An empty address disables the server.
+func StartMetricsServer(address string, logger zerolog.Logger) {
+	if address == "" {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	server := &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ReadHeaderTimeout: metricsReadHeaderTimeout,
+	}
+
+	go func() {
+		logger.Info().Str("address", address).Msg("Serving metrics")
+
+		err := server.ListenAndServe()
+		logger.Error().Err(err).Msg("Metrics server stopped")
+	}()
+}
@@ -0,0 +1,98 @@
+package pusher
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/types"
+	"github.com/Stork-Oracle/stork-external/shared/signer/evm"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrMissingStorkSignedPrice = errors.New("missing stork signed price")
+	ErrNoPublisherSignatures   = errors.New("no publisher signatures")
+	ErrMissingSignature        = errors.New("missing publisher signature")
+	ErrMerkleRootMismatch      = errors.New("publisher merkle root mismatch")
+)
+
+// verifyPublisherSignatures checks every publisher signature on the price and that the publisher
+// merkle root signed by Stork commits to exactly those publisher prices. This mirrors
+// verifyPublisherSignaturesV1 on the EVM contract, but runs locally so that it works on every chain.
+func verifyPublisherSignatures(price types.AggregatedSignedPrice) error {
+	if price.StorkSignedPrice == nil {
+		return ErrMissingStorkSignedPrice
+	}
+
+	if len(price.SignedPrices) == 0 {
+		return ErrNoPublisherSignatures
+	}
+
+	leaves := make([]common.Hash, len(price.SignedPrices))
+
+	for i, signedPrice := range price.SignedPrices {
+		if signedPrice == nil || signedPrice.TimestampedSignature.Signature == nil {
+			return ErrMissingSignature
+		}
+
+		timestampNano := signedPrice.TimestampedSignature.TimestampNano
+		if timestampNano > math.MaxInt64 {
+			return ErrInputTooLarge
+		}
+
+		err := evm.VerifyPublisherPrice(
+			int64(timestampNano),
+			signedPrice.ExternalAssetID,
+			string(signedPrice.QuantizedPrice),
+			signedPrice.PublisherKey,
+			*signedPrice.TimestampedSignature.Signature,
+		)
+		if err != nil {
+			return fmt.Errorf("publisher %s: %w", signedPrice.PublisherKey, err)
+		}
+
+		leaves[i] = evm.PublisherPriceMessageHash(
+			int64(timestampNano),
+			signedPrice.ExternalAssetID,
+			string(signedPrice.QuantizedPrice),
+			signedPrice.PublisherKey,
+		)
+	}
+
+	root, err := evm.ComputeMerkleRoot(leaves)
+	if err != nil {
+		return fmt.Errorf("failed to compute publisher merkle root: %w", err)
+	}
+
+	expectedRoot, err := HexStringToByte32(price.StorkSignedPrice.PublisherMerkleRoot)
+	if err != nil {
+		return fmt.Errorf("failed to parse publisher merkle root: %w", err)
+	}
+
+	if root != expectedRoot {
+		return fmt.Errorf("%w: computed %s, expected %s", ErrMerkleRootMismatch, root.Hex(), common.Hash(expectedRoot).Hex())
+	}
+
+	return nil
+}
+
+// filterVerifiedUpdates drops updates whose publisher signatures do not verify, keeping the rest of the batch.
+func (p *Pusher) filterVerifiedUpdates(updates updateBatch) updateBatch {
+	verified := make(updateBatch, len(updates))
+
+	for encodedAssetID, update := range updates {
+		err := verifyPublisherSignatures(update)
+		if err != nil {
+			p.logger.Error().Err(err).
+				Str("assetID", string(update.AssetID)).
+				Msg("Publisher signatures not verified, excluding asset from batch")
+
+			continue
+		}
+
+		verified[encodedAssetID] = update
+	}
+
+	return verified
+}
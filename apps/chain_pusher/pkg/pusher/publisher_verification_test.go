@@ -0,0 +1,129 @@
+package pusher
+
+import (
+	"testing"
+
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/types"
+	"github.com/Stork-Oracle/stork-external/shared"
+	"github.com/Stork-Oracle/stork-external/shared/signer/evm"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPublisherKeys = []evm.PrivateKey{
+	"0x8b558d5fc31eb64bb51d44b4b28658180e96764d5d5ac68e6d124f86f576d9de",
+	"0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80",
+	"0x59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d",
+}
+
+// makeVerifiablePrice builds an aggregated price signed by each publisher key, with the matching merkle root.
+func makeVerifiablePrice(t *testing.T, assetID shared.AssetID, values ...string) types.AggregatedSignedPrice {
+	t.Helper()
+
+	const timestampNano = 1757543080505451010
+
+	signedPrices := make([]*types.PublisherSignedPrice, len(values))
+	leaves := make([]common.Hash, len(values))
+
+	for i, value := range values {
+		signer, err := evm.NewSigner(testPublisherKeys[i], zerolog.Nop())
+		require.NoError(t, err)
+
+		timestampedSignature, _, err := signer.SignPublisherPrice(timestampNano, string(assetID), value)
+		require.NoError(t, err)
+
+		signedPrices[i] = &types.PublisherSignedPrice{
+			PublisherKey:         signer.GetPublisherKey(),
+			ExternalAssetID:      string(assetID),
+			QuantizedPrice:       shared.QuantizedPrice(value),
+			TimestampedSignature: *timestampedSignature,
+		}
+		leaves[i] = common.HexToHash(timestampedSignature.MsgHash)
+	}
+
+	root, err := evm.ComputeMerkleRoot(leaves)
+	require.NoError(t, err)
+
+	return types.AggregatedSignedPrice{
+		TimestampNano: timestampNano,
+		AssetID:       assetID,
+		StorkSignedPrice: &types.StorkSignedPrice{
+			QuantizedPrice:      shared.QuantizedPrice(values[0]),
+			PublisherMerkleRoot: root.Hex(),
+		},
+		SignedPrices: signedPrices,
+	}
+}
+
+func TestVerifyPublisherSignatures(t *testing.T) {
+	t.Parallel()
+
+	t.Run("single publisher", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, verifyPublisherSignatures(makeVerifiablePrice(t, "BTCUSD", "100002000000000000000000")))
+	})
+
+	t.Run("multiple publishers with odd merkle level", func(t *testing.T) {
+		t.Parallel()
+
+		price := makeVerifiablePrice(t, "ETHUSD", "3000000000000000000000", "3001000000000000000000", "-1")
+		require.NoError(t, verifyPublisherSignatures(price))
+	})
+
+	t.Run("tampered publisher price", func(t *testing.T) {
+		t.Parallel()
+
+		price := makeVerifiablePrice(t, "BTCUSD", "100002000000000000000000", "100003000000000000000000")
+		price.SignedPrices[1].QuantizedPrice = "1"
+		require.Error(t, verifyPublisherSignatures(price))
+	})
+
+	t.Run("merkle root mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		price := makeVerifiablePrice(t, "BTCUSD", "100002000000000000000000", "100003000000000000000000")
+		price.SignedPrices = price.SignedPrices[:1]
+		require.ErrorIs(t, verifyPublisherSignatures(price), ErrMerkleRootMismatch)
+	})
+
+	t.Run("no publisher signatures", func(t *testing.T) {
+		t.Parallel()
+
+		price := makeVerifiablePrice(t, "BTCUSD", "1")
+		price.SignedPrices = nil
+		require.ErrorIs(t, verifyPublisherSignatures(price), ErrNoPublisherSignatures)
+	})
+
+	t.Run("missing stork signed price", func(t *testing.T) {
+		t.Parallel()
+
+		price := makeVerifiablePrice(t, "BTCUSD", "1")
+		price.StorkSignedPrice = nil
+		require.ErrorIs(t, verifyPublisherSignatures(price), ErrMissingStorkSignedPrice)
+	})
+}
+
+func TestFilterVerifiedUpdates(t *testing.T) {
+	t.Parallel()
+
+	logger := zerolog.Nop()
+	p := &Pusher{logger: &logger}
+
+	var validID, invalidID types.InternalEncodedAssetID
+	validID[31] = 1
+	invalidID[31] = 2
+
+	invalid := makeVerifiablePrice(t, "ETHUSD", "3000000000000000000000")
+	invalid.StorkSignedPrice.PublisherMerkleRoot = common.Hash{}.Hex()
+
+	verified := p.filterVerifiedUpdates(updateBatch{
+		validID:   makeVerifiablePrice(t, "BTCUSD", "100002000000000000000000"),
+		invalidID: invalid,
+	})
+
+	assert.Contains(t, verified, validID)
+	assert.NotContains(t, verified, invalidID)
+}
@@ -0,0 +1,127 @@
+package pusher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/types"
+	"golang.org/x/time/rate"
+)
+
+// ErrFeedNotFound is returned by a FeedReader for feeds that have no value on the contract yet.
+var ErrFeedNotFound = errors.New("feed not found")
+
+// FeedReader reads the latest value of a single feed from the contract.
+type FeedReader func(
+	ctx context.Context, encodedAssetID types.InternalEncodedAssetID,
+) (types.InternalTemporalNumericValue, error)
+
+// ConcurrentPuller reads many feeds for contracts that only expose single feed reads, using a bounded number of
+// concurrent calls and an optional rate limit.
+type ConcurrentPuller struct {
+	concurrency int
+	limiter     *rate.Limiter
+}
+
+type pullResult struct {
+	encodedAssetID types.InternalEncodedAssetID
+	value          types.InternalTemporalNumericValue
+	err            error
+}
+
+// NewConcurrentPuller creates a puller making at most concurrency calls at once and limitPerSecond calls per second
+// with bursts of burstLimit. A limitPerSecond of 0 disables rate limiting.
+func NewConcurrentPuller(concurrency int, limitPerSecond int, burstLimit int) *ConcurrentPuller {
+	var limiter *rate.Limiter
+	if limitPerSecond > 0 {
+		limiter = rate.NewLimiter(rate.Every(time.Second/time.Duration(limitPerSecond)), max(burstLimit, 1))
+	}
+
+	return &ConcurrentPuller{
+		concurrency: max(concurrency, 1),
+		limiter:     limiter,
+	}
+}
+
+// Pull reads the feeds with read. Feeds that are not found are left out of the result. If the context is done
+// before all reads complete, the values read so far are returned along with the context error.
+func (p *ConcurrentPuller) Pull(
+	ctx context.Context,
+	encodedAssetIDs []types.InternalEncodedAssetID,
+	read FeedReader,
+) (map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue, error) {
+	values := make(map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue)
+	if len(encodedAssetIDs) == 0 {
+		return values, nil
+	}
+
+	pullCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	queue := make(chan types.InternalEncodedAssetID, len(encodedAssetIDs))
+	for _, encodedAssetID := range encodedAssetIDs {
+		queue <- encodedAssetID
+	}
+
+	close(queue)
+
+	// buffered for every feed so that workers never block on results that are no longer collected
+	results := make(chan pullResult, len(encodedAssetIDs))
+
+	for range min(p.concurrency, len(encodedAssetIDs)) {
+		go p.work(pullCtx, queue, results, read)
+	}
+
+	var lastErr error
+
+	for range encodedAssetIDs {
+		select {
+		case <-ctx.Done():
+			return values, fmt.Errorf(
+				"pulled %d of %d values before the context was done: %w", len(values), len(encodedAssetIDs), ctx.Err(),
+			)
+		case result := <-results:
+			switch {
+			case result.err == nil:
+				values[result.encodedAssetID] = result.value
+			case !errors.Is(result.err, ErrFeedNotFound):
+				lastErr = result.err
+			}
+		}
+	}
+
+	if lastErr != nil {
+		return values, fmt.Errorf("failed to pull at least one value from the contract. Last error: %w", lastErr)
+	}
+
+	return values, nil
+}
+
+func (p *ConcurrentPuller) work(
+	ctx context.Context,
+	queue <-chan types.InternalEncodedAssetID,
+	results chan<- pullResult,
+	read FeedReader,
+) {
+	for encodedAssetID := range queue {
+		if p.limiter != nil {
+			err := p.limiter.Wait(ctx)
+			if err != nil {
+				results <- pullResult{encodedAssetID: encodedAssetID, err: fmt.Errorf("rate limiter error: %w", err)}
+
+				continue
+			}
+		}
+
+		if ctx.Err() != nil {
+			results <- pullResult{encodedAssetID: encodedAssetID, err: ctx.Err()}
+
+			continue
+		}
+
+		value, err := read(ctx, encodedAssetID)
+		results <- pullResult{encodedAssetID: encodedAssetID, value: value, err: err}
+	}
+}
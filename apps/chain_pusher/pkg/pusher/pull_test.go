@@ -0,0 +1,109 @@
+package pusher
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errReadFailed = errors.New("read failed")
+
+func testAssetIDs(n int) []types.InternalEncodedAssetID {
+	ids := make([]types.InternalEncodedAssetID, n)
+	for i := range ids {
+		ids[i][0] = byte(i)
+	}
+
+	return ids
+}
+
+func TestConcurrentPullerPull(t *testing.T) {
+	t.Parallel()
+
+	ids := testAssetIDs(20)
+
+	var inFlight, maxInFlight atomic.Int32
+
+	read := func(_ context.Context, id types.InternalEncodedAssetID) (types.InternalTemporalNumericValue, error) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+
+		time.Sleep(5 * time.Millisecond)
+
+		switch id[0] {
+		case 3:
+			return types.InternalTemporalNumericValue{}, ErrFeedNotFound
+		case 4:
+			return types.InternalTemporalNumericValue{}, errReadFailed
+		}
+
+		return types.InternalTemporalNumericValue{TimestampNs: uint64(id[0]), QuantizedValue: big.NewInt(1)}, nil
+	}
+
+	values, err := NewConcurrentPuller(4, 0, 0).Pull(context.Background(), ids, read)
+	require.ErrorIs(t, err, errReadFailed)
+	assert.Len(t, values, 18)
+	assert.NotContains(t, values, ids[3])
+	assert.NotContains(t, values, ids[4])
+	assert.Equal(t, uint64(7), values[ids[7]].TimestampNs)
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(4))
+}
+
+func TestConcurrentPullerPartialResultsOnTimeout(t *testing.T) {
+	t.Parallel()
+
+	ids := testAssetIDs(10)
+
+	read := func(ctx context.Context, id types.InternalEncodedAssetID) (types.InternalTemporalNumericValue, error) {
+		if id[0] >= 5 {
+			<-ctx.Done()
+
+			return types.InternalTemporalNumericValue{}, ctx.Err()
+		}
+
+		return types.InternalTemporalNumericValue{TimestampNs: 1, QuantizedValue: big.NewInt(1)}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	values, err := NewConcurrentPuller(len(ids), 0, 0).Pull(ctx, ids, read)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Len(t, values, 5)
+}
+
+func TestConcurrentPullerRateLimit(t *testing.T) {
+	t.Parallel()
+
+	ids := testAssetIDs(5)
+
+	read := func(_ context.Context, _ types.InternalEncodedAssetID) (types.InternalTemporalNumericValue, error) {
+		return types.InternalTemporalNumericValue{TimestampNs: 1, QuantizedValue: big.NewInt(1)}, nil
+	}
+
+	start := time.Now()
+
+	values, err := NewConcurrentPuller(5, 50, 1).Pull(context.Background(), ids, read)
+	require.NoError(t, err)
+	assert.Len(t, values, 5)
+	// the burst covers the first read and every further read waits 20ms
+	assert.GreaterOrEqual(t, time.Since(start), 70*time.Millisecond)
+
+	values, err = NewConcurrentPuller(5, 0, 0).Pull(context.Background(), nil, read)
+	require.NoError(t, err)
+	assert.Empty(t, values)
+}
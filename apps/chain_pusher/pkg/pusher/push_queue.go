@@ -0,0 +1,216 @@
+package pusher
+
+import (
+	"cmp"
+	"math"
+	"math/big"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	pushQueueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "stork",
+		Subsystem: "chain_pusher",
+		Name:      "push_queue_depth",
+		Help:      "Number of assets with an update waiting to be pushed",
+	})
+	deferredUpdatesTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "stork",
+		Subsystem: "chain_pusher",
+		Name:      "deferred_updates_total",
+		Help:      "Times an update was left in the push queue for a later window by the per window asset cap",
+	})
+)
+
+// queuedUpdate is an update waiting to be pushed, along with what decides how urgent it is.
+type queuedUpdate struct {
+	encodedAssetID types.InternalEncodedAssetID
+	update         types.AggregatedSignedPrice
+	// deadline is when the value on chain breaches the asset's fallback period, if it has one
+	deadline    time.Time
+	hasDeadline bool
+	// deviation is the relative change from the value on chain
+	deviation float64
+}
+
+func (u queuedUpdate) overdue(now time.Time) bool {
+	return u.hasDeadline && !u.deadline.After(now)
+}
+
+// compareUrgency orders updates whose fallback deadline has passed first, the most overdue leading, then the rest
+// by largest deviation and nearest deadline.
+func compareUrgency(now time.Time, a, b queuedUpdate) int {
+	aOverdue, bOverdue := a.overdue(now), b.overdue(now)
+
+	switch {
+	case aOverdue && !bOverdue:
+		return -1
+	case bOverdue && !aOverdue:
+		return 1
+	case aOverdue:
+		return a.deadline.Compare(b.deadline)
+	}
+
+	c := cmp.Compare(b.deviation, a.deviation)
+	if c != 0 {
+		return c
+	}
+
+	switch {
+	case a.hasDeadline && !b.hasDeadline:
+		return -1
+	case b.hasDeadline && !a.hasDeadline:
+		return 1
+	}
+
+	return a.deadline.Compare(b.deadline)
+}
+
+// pushQueue holds the updates waiting to be pushed, keeping only the latest update per asset, so that a pusher
+// that falls behind pushes the most urgent updates first instead of one ever growing batch.
+type pushQueue struct {
+	// maxAssets caps the updates handed out per pop, 0 for no cap
+	maxAssets int
+
+	mu      sync.Mutex
+	pending map[types.InternalEncodedAssetID]queuedUpdate
+	ready   chan struct{}
+}
+
+func newPushQueue(maxAssets int) *pushQueue {
+	return &pushQueue{
+		maxAssets: maxAssets,
+		mu:        sync.Mutex{},
+		pending:   make(map[types.InternalEncodedAssetID]queuedUpdate),
+		ready:     make(chan struct{}, 1),
+	}
+}
+
+// add queues updates, replacing any update still queued for the same asset, and signals ready while anything is
+// queued, so that deferred updates are handed out in the next window.
+func (q *pushQueue) add(updates []queuedUpdate) {
+	q.mu.Lock()
+	for _, update := range updates {
+		q.pending[update.encodedAssetID] = update
+	}
+
+	depth := len(q.pending)
+	q.mu.Unlock()
+
+	pushQueueDepth.Set(float64(depth))
+
+	if depth == 0 {
+		return
+	}
+
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// pop removes and returns the most urgent updates, up to the cap, along with the number of updates left queued.
+func (q *pushQueue) pop(now time.Time) (updateBatch, int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	queued := make([]queuedUpdate, 0, len(q.pending))
+	for _, update := range q.pending {
+		queued = append(queued, update)
+	}
+
+	slices.SortFunc(queued, func(a, b queuedUpdate) int {
+		return compareUrgency(now, a, b)
+	})
+
+	if q.maxAssets > 0 && len(queued) > q.maxAssets {
+		queued = queued[:q.maxAssets]
+	}
+
+	batch := make(updateBatch, len(queued))
+	for _, update := range queued {
+		batch[update.encodedAssetID] = update.update
+		delete(q.pending, update.encodedAssetID)
+	}
+
+	deferred := len(q.pending)
+	if deferred > 0 {
+		deferredUpdatesTotal.Add(float64(deferred))
+	}
+
+	pushQueueDepth.Set(float64(deferred))
+
+	return batch, deferred
+}
+
+// prioritise attaches the urgency of each update, given the values on chain it was collated against.
+func prioritise(
+	updates updateBatch,
+	latestContractValueMap map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue,
+	priceConfig *types.AssetConfig,
+) []queuedUpdate {
+	queued := make([]queuedUpdate, 0, len(updates))
+
+	for encodedAssetID, update := range updates {
+		item := queuedUpdate{
+			encodedAssetID: encodedAssetID,
+			update:         update,
+			deadline:       time.Time{},
+			hasDeadline:    false,
+			deviation:      math.Inf(1),
+		}
+
+		onChain, onChainKnown := latestContractValueMap[encodedAssetID]
+		if onChainKnown {
+			item.deviation = relativeDeviation(onChain, update)
+		}
+
+		// assets without a value on chain are treated as last pushed at the epoch, so they lead the queue
+		period, hasPeriod := priceConfig.Assets[update.AssetID].FallbackPeriod()
+
+		switch {
+		case hasPeriod:
+			//nolint:gosec // Timestamps on chain fit in an int64 until 2262.
+			item.deadline, item.hasDeadline = time.Unix(0, int64(onChain.TimestampNs)).Add(period), true
+		case !onChainKnown:
+			item.deadline, item.hasDeadline = time.Unix(0, 0), true
+		}
+
+		queued = append(queued, item)
+	}
+
+	return queued
+}
+
+// relativeDeviation returns the absolute change of the latest value relative to the value on chain.
+func relativeDeviation(onChain types.InternalTemporalNumericValue, latest types.AggregatedSignedPrice) float64 {
+	if onChain.QuantizedValue == nil || latest.StorkSignedPrice == nil {
+		return 0
+	}
+
+	latestValue, ok := new(big.Float).SetString(string(latest.StorkSignedPrice.QuantizedPrice))
+	if !ok {
+		return 0
+	}
+
+	onChainValue := new(big.Float).SetInt(onChain.QuantizedValue)
+	difference := new(big.Float).Sub(latestValue, onChainValue)
+
+	if onChainValue.Sign() == 0 {
+		if difference.Sign() == 0 {
+			return 0
+		}
+
+		return math.Inf(1)
+	}
+
+	deviation, _ := new(big.Float).Quo(difference.Abs(difference), onChainValue.Abs(onChainValue)).Float64()
+
+	return deviation
+}
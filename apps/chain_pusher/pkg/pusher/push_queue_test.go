@@ -0,0 +1,179 @@
+package pusher
+
+import (
+	"maps"
+	"math"
+	"math/big"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/types"
+	"github.com/Stork-Oracle/stork-external/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushQueuePop(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	mostOverdue := queuedUpdate{
+		encodedAssetID: types.InternalEncodedAssetID{1},
+		deadline:       now.Add(-time.Minute),
+		hasDeadline:    true,
+		deviation:      0,
+	}
+	overdue := queuedUpdate{
+		encodedAssetID: types.InternalEncodedAssetID{2},
+		deadline:       now.Add(-time.Second),
+		hasDeadline:    true,
+		deviation:      0.5,
+	}
+	largeDeviation := queuedUpdate{
+		encodedAssetID: types.InternalEncodedAssetID{3},
+		deadline:       now.Add(time.Hour),
+		hasDeadline:    true,
+		deviation:      0.05,
+	}
+	nearDeadline := queuedUpdate{
+		encodedAssetID: types.InternalEncodedAssetID{4},
+		deadline:       now.Add(time.Minute),
+		hasDeadline:    true,
+		deviation:      0.01,
+	}
+	noDeadline := queuedUpdate{
+		encodedAssetID: types.InternalEncodedAssetID{5},
+		deadline:       time.Time{},
+		hasDeadline:    false,
+		deviation:      0.01,
+	}
+
+	tests := []struct {
+		name      string
+		maxAssets int
+		expected  [][]queuedUpdate
+	}{
+		{
+			name:      "no cap",
+			maxAssets: 0,
+			expected:  [][]queuedUpdate{{mostOverdue, overdue, largeDeviation, nearDeadline, noDeadline}},
+		},
+		{
+			name:      "cap defers least urgent",
+			maxAssets: 2,
+			expected: [][]queuedUpdate{
+				{mostOverdue, overdue},
+				{largeDeviation, nearDeadline},
+				{noDeadline},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			queue := newPushQueue(tt.maxAssets)
+			queue.add([]queuedUpdate{noDeadline, nearDeadline, largeDeviation, overdue, mostOverdue})
+
+			for i, expected := range tt.expected {
+				select {
+				case <-queue.ready:
+				default:
+					require.Fail(t, "queue not ready")
+				}
+
+				batch, deferred := queue.pop(now)
+
+				expectedIDs := make([]types.InternalEncodedAssetID, 0, len(expected))
+				for _, update := range expected {
+					expectedIDs = append(expectedIDs, update.encodedAssetID)
+				}
+
+				assert.ElementsMatch(t, expectedIDs, slices.Collect(maps.Keys(batch)))
+
+				remaining := 0
+				for _, later := range tt.expected[i+1:] {
+					remaining += len(later)
+				}
+
+				assert.Equal(t, remaining, deferred)
+
+				// the next window adds nothing new, which still hands out the deferred updates
+				queue.add(nil)
+			}
+		})
+	}
+}
+
+func TestPushQueueKeepsLatestUpdate(t *testing.T) {
+	t.Parallel()
+
+	id := types.InternalEncodedAssetID{1}
+	queue := newPushQueue(0)
+
+	queue.add([]queuedUpdate{{encodedAssetID: id, update: types.AggregatedSignedPrice{TimestampNano: 1}}})
+	queue.add([]queuedUpdate{{encodedAssetID: id, update: types.AggregatedSignedPrice{TimestampNano: 2}}})
+
+	batch, deferred := queue.pop(time.Now())
+	assert.Equal(t, updateBatch{id: types.AggregatedSignedPrice{TimestampNano: 2}}, batch)
+	assert.Zero(t, deferred)
+}
+
+func TestPrioritise(t *testing.T) {
+	t.Parallel()
+
+	knownID := types.InternalEncodedAssetID{1}
+	missingID := types.InternalEncodedAssetID{2}
+	noFallbackID := types.InternalEncodedAssetID{3}
+
+	priceConfig := &types.AssetConfig{
+		Assets: map[shared.AssetID]types.AssetEntry{
+			"KNOWN":   {AssetID: "KNOWN", FallbackPeriodSecs: 60},
+			"MISSING": {AssetID: "MISSING", FallbackPeriodSecs: 60},
+			"NOFALLBACK": {
+				AssetID:  "NOFALLBACK",
+				Triggers: []types.TriggerSpec{{Type: "basis_points", Trigger: &types.BasisPointsTrigger{BandBps: 10}}},
+			},
+		},
+	}
+
+	price := func(assetID shared.AssetID, value string) types.AggregatedSignedPrice {
+		return types.AggregatedSignedPrice{
+			AssetID:          assetID,
+			StorkSignedPrice: &types.StorkSignedPrice{QuantizedPrice: shared.QuantizedPrice(value)},
+		}
+	}
+
+	onChainTs := uint64(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC).UnixNano())
+	latestContractValueMap := map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue{
+		knownID:      {TimestampNs: onChainTs, QuantizedValue: big.NewInt(200)},
+		noFallbackID: {TimestampNs: onChainTs, QuantizedValue: big.NewInt(-100)},
+	}
+
+	queued := prioritise(updateBatch{
+		knownID:      price("KNOWN", "210"),
+		missingID:    price("MISSING", "100"),
+		noFallbackID: price("NOFALLBACK", "-90"),
+	}, latestContractValueMap, priceConfig)
+
+	byID := make(map[types.InternalEncodedAssetID]queuedUpdate)
+	for _, update := range queued {
+		byID[update.encodedAssetID] = update
+	}
+
+	require.Len(t, byID, 3)
+
+	assert.True(t, byID[knownID].hasDeadline)
+	assert.Equal(t, time.Unix(0, int64(onChainTs)).Add(time.Minute), byID[knownID].deadline)
+	assert.InDelta(t, 0.05, byID[knownID].deviation, 1e-9)
+
+	assert.True(t, byID[missingID].hasDeadline)
+	assert.Equal(t, time.Unix(0, 0).Add(time.Minute), byID[missingID].deadline)
+	assert.Equal(t, math.Inf(1), byID[missingID].deviation)
+
+	assert.False(t, byID[noFallbackID].hasDeadline)
+	assert.InDelta(t, 0.1, byID[noFallbackID].deviation, 1e-9)
+}
@@ -2,8 +2,8 @@ package pusher
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"maps"
 	"math/big"
 	"time"
 
@@ -30,7 +30,10 @@ type Pusher struct {
 	assetConfigFile        string
 	batchingWindowDuration time.Duration
 	pollingPeriod          int
+	verifyPublishers       bool
+	maxAssetsPerWindow     int
 	interactor             types.ContractInteractor
+	budget                 *SpendBudget
 	logger                 *zerolog.Logger
 }
 
@@ -38,7 +41,10 @@ type Pusher struct {
 func NewPusher(
 	storkWsEndpoint, storkAuth, chainRpcUrl, chainWsRpcUrl, contractAddress, assetConfigFile, batchingWindowStr string,
 	batchingWindow, pollingPeriod int,
+	verifyPublishers bool,
+	maxAssetsPerWindow int,
 	interactor types.ContractInteractor,
+	budget *SpendBudget,
 	logger *zerolog.Logger,
 ) *Pusher {
 	var batchingWindowDuration time.Duration
@@ -63,7 +69,10 @@ func NewPusher(
 		assetConfigFile:        assetConfigFile,
 		batchingWindowDuration: batchingWindowDuration,
 		pollingPeriod:          pollingPeriod,
+		verifyPublishers:       verifyPublishers,
+		maxAssetsPerWindow:     maxAssetsPerWindow,
 		interactor:             interactor,
+		budget:                 budget,
 		logger:                 logger,
 	}
 }
@@ -113,13 +122,25 @@ func (p *Pusher) Run(ctx context.Context) {
 
 	p.logger.Info().Msgf("Pulled initial values for %d assets", len(initialValues))
 
+	// take a balance baseline so the spend of the first push is accounted for
+	err = p.recordSpend(ctx, 0)
+	if errors.Is(err, ErrUnknownWalletBalance) {
+		p.logger.Fatal().Err(err).Msg("Spend limits require a chain that reports its wallet balance")
+	}
+
 	go p.interactor.ListenContractEvents(ctx, contractCh)
 	go p.poll(ctx, encodedAssetIDs, contractCh)
 
 	ticker := time.NewTicker(p.batchingWindowDuration)
 	defer ticker.Stop()
 
-	pushCh := make(chan updateBatch, 128)
+	queue := newPushQueue(p.maxAssetsPerWindow)
+
+	// a nil channel never receives, so interactors that confirm synchronously skip this case
+	var confirmationCh <-chan types.ConfirmationResult
+	if reporter, ok := p.interactor.(types.ConfirmationReporter); ok {
+		confirmationCh = reporter.ConfirmationResults()
+	}
 
 	// use separate goroutine to handle push updates to avoid blocking the main loop
 	go func() {
@@ -127,10 +148,16 @@ func (p *Pusher) Run(ctx context.Context) {
 			select {
 			case <-ctx.Done():
 				return
-			case pushUpdates := <-pushCh:
-				// drain the channel and merge all pending batches so only the latest update per asset is pushed
-				merged := drainAndMerge(pushUpdates, pushCh)
-				p.handlePushUpdates(ctx, merged, contractCh)
+			case <-queue.ready:
+				updates, deferred := queue.pop(time.Now())
+				if deferred > 0 {
+					p.logger.Warn().
+						Int("pushing", len(updates)).
+						Int("deferred", deferred).
+						Msg("Asset cap reached, deferring less urgent updates to the next window")
+				}
+
+				p.handlePushUpdates(ctx, updates, contractCh)
 			}
 		}
 	}()
@@ -142,34 +169,19 @@ func (p *Pusher) Run(ctx context.Context) {
 
 			return
 		case <-ticker.C:
-			updates := p.collateUpdates(latestContractValueMap, latestStorkValueMap, priceConfig)
-			select {
-			case pushCh <- updates:
-			default:
-				p.logger.Error().Msg("pushCh is full, skipping push")
-			}
+			updates, triggers := p.collateUpdates(latestContractValueMap, latestStorkValueMap, priceConfig)
+			updates = p.budget.Apply(time.Now(), updates, triggers)
+
+			queue.add(prioritise(updates, latestContractValueMap, priceConfig))
 		// Handle stork updates
 		case valueUpdate := <-storkWsCh:
 			p.handleStorkUpdate(valueUpdate, latestStorkValueMap)
 		// Handle contract updates
 		case chainUpdate := <-contractCh:
 			p.handleContractUpdate(chainUpdate, latestContractValueMap)
-		}
-	}
-}
-
-// drainAndMerge takes an initial batch and drains any additional pending batches from the channel,
-// merging them so that only the latest update per asset is kept.
-func drainAndMerge(initial updateBatch, ch <-chan updateBatch) updateBatch {
-	merged := make(updateBatch, len(initial))
-	maps.Copy(merged, initial)
-
-	for {
-		select {
-		case batch := <-ch:
-			maps.Copy(merged, batch)
-		default:
-			return merged
+		// Handle confirmation results for interactors that confirm asynchronously
+		case result := <-confirmationCh:
+			p.handleConfirmationResult(result, latestContractValueMap)
 		}
 	}
 }
@@ -206,71 +218,38 @@ func (p *Pusher) collateUpdates(
 	latestContractValueMap map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue,
 	latestStorkValueMap map[types.InternalEncodedAssetID]types.AggregatedSignedPrice,
 	priceConfig *types.AssetConfig,
-) map[types.InternalEncodedAssetID]types.AggregatedSignedPrice {
-	updates := make(map[types.InternalEncodedAssetID]types.AggregatedSignedPrice)
+) (updateBatch, map[types.InternalEncodedAssetID]updateTrigger) {
+	updates := make(updateBatch)
+	triggers := make(map[types.InternalEncodedAssetID]updateTrigger)
+	now := time.Now()
 
 	for encodedAssetID, latestStorkPrice := range latestStorkValueMap {
 		pushEveryBatch := priceConfig.Assets[latestStorkPrice.AssetID].PushEveryBatch
 		if pushEveryBatch {
 			updates[encodedAssetID] = latestStorkPrice
+			triggers[encodedAssetID] = triggerPushEveryBatch
 		} else {
 			latestValue, ok := latestContractValueMap[encodedAssetID]
 			if !ok {
 				p.logger.Debug().
 					Msgf("No current value for asset %s", latestStorkPrice.StorkSignedPrice.EncodedAssetID)
 				updates[encodedAssetID] = latestStorkPrice
+				triggers[encodedAssetID] = triggerMissingValue
 
 				continue
 			}
 
-			if shouldUpdateAsset(
-				latestValue,
-				latestStorkPrice,
-				priceConfig.Assets[latestStorkPrice.AssetID].FallbackPeriodSecs,
-				priceConfig.Assets[latestStorkPrice.AssetID].PercentChangeThreshold,
-			) {
+			reason, ok := priceConfig.Assets[latestStorkPrice.AssetID].EvaluateTriggers(
+				now, latestValue, latestStorkPrice,
+			)
+			if ok {
 				updates[encodedAssetID] = latestStorkPrice
+				triggers[encodedAssetID] = triggerForReason(reason)
 			}
 		}
 	}
 
-	return updates
-}
-
-func shouldUpdateAsset(
-	latestValue types.InternalTemporalNumericValue,
-	latestStorkPrice types.AggregatedSignedPrice,
-	fallbackPeriodSecs uint64,
-	changeThreshold float64,
-) bool {
-	if latestStorkPrice.TimestampNano-latestValue.TimestampNs > fallbackPeriodSecs*uint64(time.Second) {
-		return true
-	}
-
-	quantizedVal := new(big.Float)
-	quantizedVal.SetString(string(latestStorkPrice.StorkSignedPrice.QuantizedPrice))
-
-	quantizedCurrVal := new(big.Float)
-	quantizedCurrVal.SetInt(latestValue.QuantizedValue)
-
-	// Calculate the absolute difference
-	difference := new(big.Float).Sub(quantizedVal, quantizedCurrVal)
-	absDifference := new(big.Float).Abs(difference)
-
-	if quantizedCurrVal.Sign() == 0 {
-		return quantizedVal.Sign() != 0
-	}
-
-	// Calculate the ratio
-	ratio := new(big.Float).Quo(absDifference, quantizedCurrVal)
-	absRatio := new(big.Float).Abs(ratio)
-
-	//nolint:mnd // The purpose of 100 here is evident in order to get a percentage
-	percentChange := new(big.Float).Mul(absRatio, big.NewFloat(100))
-
-	thresholdBig := big.NewFloat(changeThreshold)
-
-	return percentChange.Cmp(thresholdBig) > 0
+	return updates, triggers
 }
 
 func (p *Pusher) poll(
@@ -333,6 +312,10 @@ func (p *Pusher) handlePushUpdates(
 	updates updateBatch,
 	contractCh chan<- map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue,
 ) {
+	if p.verifyPublishers {
+		updates = p.filterVerifiedUpdates(updates)
+	}
+
 	if len(updates) > 0 {
 		err := p.pushWithTimeout(ctx, updates)
 		if err != nil {
@@ -345,6 +328,8 @@ func (p *Pusher) handlePushUpdates(
 				p.logger.Error().Err(err).Msg("failed to reconnect to HTTP RPC")
 			}
 		} else {
+			_ = p.recordSpend(ctx, len(updates))
+
 			// assume updates land successfully
 			contractUpdates := make(map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue)
 
@@ -364,6 +349,34 @@ func (p *Pusher) handlePushUpdates(
 	}
 }
 
+// recordSpend reads the wallet balance after a push so the spend budget can account for it.
+// It returns ErrUnknownWalletBalance when the interactor reports a negative balance, which the budget cannot use.
+func (p *Pusher) recordSpend(ctx context.Context, numUpdates int) error {
+	if !p.budget.Enabled() {
+		return nil
+	}
+
+	balanceCtx, balanceCancel := context.WithTimeout(ctx, defaultNetworkTimeout)
+	defer balanceCancel()
+
+	balance, err := p.interactor.GetWalletBalance(balanceCtx)
+	if err != nil {
+		p.logger.Warn().Err(err).Msg("Failed to get wallet balance for spend budget")
+
+		return fmt.Errorf("failed to get wallet balance: %w", err)
+	}
+
+	if balance < 0 {
+		p.logger.Warn().Float64("balance", balance).Msg("Spend budget is enabled but the wallet balance is unknown")
+
+		return ErrUnknownWalletBalance
+	}
+
+	p.budget.RecordBalance(time.Now(), balance, numUpdates)
+
+	return nil
+}
+
 // handleStorkUpdate processes updates from the Stork websocket.
 func (p *Pusher) handleStorkUpdate(
 	valueUpdate types.AggregatedSignedPrice,
@@ -379,6 +392,25 @@ func (p *Pusher) handleStorkUpdate(
 	latestStorkValueMap[encoded] = valueUpdate
 }
 
+// handleConfirmationResult forgets the value assumed to have landed for assets whose transaction failed to confirm,
+// so that they are pushed again in the next batching window.
+func (p *Pusher) handleConfirmationResult(
+	result types.ConfirmationResult,
+	latestContractValueMap map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue,
+) {
+	if result.Confirmed {
+		return
+	}
+
+	p.logger.Warn().Err(result.Err).
+		Int("numAssets", len(result.EncodedAssetIDs)).
+		Msg("Pushed updates did not land, pushing them again")
+
+	for _, encodedAssetID := range result.EncodedAssetIDs {
+		delete(latestContractValueMap, encodedAssetID)
+	}
+}
+
 // handleContractUpdate processes updates from contract events.
 func (p *Pusher) handleContractUpdate(
 	chainUpdate map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue,
@@ -5,15 +5,18 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/types"
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/types/mocks"
 	"github.com/Stork-Oracle/stork-external/shared"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
-func TestShouldUpdateAsset(t *testing.T) {
+func TestEvaluateThresholdAndFallbackTriggers(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
@@ -198,7 +201,11 @@ func TestShouldUpdateAsset(t *testing.T) {
 				},
 			}
 
-			result := shouldUpdateAsset(latestValue, latestStorkPrice, tt.fallbackPeriodSecs, tt.changeThreshold)
+			entry := types.AssetEntry{
+				FallbackPeriodSecs:     tt.fallbackPeriodSecs,
+				PercentChangeThreshold: tt.changeThreshold,
+			}
+			_, result := entry.EvaluateTriggers(time.Now(), latestValue, latestStorkPrice)
 
 			assert.Equal(t, tt.expected, result)
 		})
@@ -734,3 +741,48 @@ func TestHandleContractUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleConfirmationResult(t *testing.T) {
+	t.Parallel()
+
+	logger := zerolog.Nop()
+	pusher := &Pusher{logger: &logger}
+
+	landed := types.InternalEncodedAssetID{0x12, 0x34}
+	dropped := types.InternalEncodedAssetID{0xab, 0xcd}
+
+	latestContractValueMap := map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue{
+		landed:  {TimestampNs: 1, QuantizedValue: big.NewInt(1)},
+		dropped: {TimestampNs: 2, QuantizedValue: big.NewInt(2)},
+	}
+
+	pusher.handleConfirmationResult(types.ConfirmationResult{
+		EncodedAssetIDs: []types.InternalEncodedAssetID{landed},
+		Confirmed:       true,
+	}, latestContractValueMap)
+	assert.Len(t, latestContractValueMap, 2)
+
+	pusher.handleConfirmationResult(types.ConfirmationResult{
+		EncodedAssetIDs: []types.InternalEncodedAssetID{dropped},
+		Err:             assert.AnError,
+	}, latestContractValueMap)
+	assert.Contains(t, latestContractValueMap, landed)
+	assert.NotContains(t, latestContractValueMap, dropped)
+}
+
+func TestRecordSpend_UnknownWalletBalance(t *testing.T) {
+	t.Parallel()
+
+	logger := zerolog.Nop()
+
+	budget, err := NewSpendBudget(100, 0, "", logger)
+	require.NoError(t, err)
+
+	interactor := mocks.NewMockContractInteractor(t)
+	interactor.EXPECT().GetWalletBalance(mock.Anything).Return(-1, nil)
+
+	pusher := &Pusher{logger: &logger, interactor: interactor, budget: budget}
+
+	err = pusher.recordSpend(t.Context(), 0)
+	require.ErrorIs(t, err, ErrUnknownWalletBalance)
+}
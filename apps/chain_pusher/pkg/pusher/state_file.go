@@ -0,0 +1,71 @@
+package pusher
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ReadStateFile decodes JSON state persisted with WriteStateFile into v, reporting whether any state was found.
+// An empty path disables persistence and is never found.
+func ReadStateFile(path string, v any) (bool, error) {
+	if path == "" {
+		return false, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	err = json.Unmarshal(content, v)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse state file: %w", err)
+	}
+
+	return true, nil
+}
+
+// WriteStateFile persists v as JSON by writing a temporary file and renaming it, so a crash mid-write never
+// leaves truncated state behind. An empty path disables persistence.
+func WriteStateFile(path string, v any) error {
+	if path == "" {
+		return nil
+	}
+
+	content, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary state file: %w", err)
+	}
+
+	_, err = tmpFile.Write(content)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		_ = os.Remove(tmpFile.Name())
+
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+
+	err = os.Rename(tmpFile.Name(), path)
+	if err != nil {
+		_ = os.Remove(tmpFile.Name())
+
+		return fmt.Errorf("failed to replace state file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package pusher
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateFile(t *testing.T) {
+	t.Parallel()
+
+	type state struct {
+		Version uint64 `json:"version"`
+		Index   uint64 `json:"index"`
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+
+	var loaded state
+
+	found, err := ReadStateFile(path, &loaded)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	found, err = ReadStateFile("", &loaded)
+	require.NoError(t, err)
+	assert.False(t, found)
+	require.NoError(t, WriteStateFile("", state{}))
+
+	require.NoError(t, WriteStateFile(path, state{Version: 10, Index: 2}))
+	require.NoError(t, WriteStateFile(path, state{Version: 12, Index: 1}))
+
+	found, err = ReadStateFile(path, &loaded)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, state{Version: 12, Index: 1}, loaded)
+
+	// no temporary files are left behind
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+
+	_, err = ReadStateFile(path, &loaded)
+	require.Error(t, err)
+}
@@ -0,0 +1,269 @@
+package solana
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/types"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	confirm "github.com/gagliardetto/solana-go/rpc/sendAndConfirmTransaction"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	ErrTransactionFailed   = errors.New("transaction failed")
+	ErrBlockhashExpired    = errors.New("blockhash expired before the transaction landed")
+	ErrConfirmationTimeout = errors.New("timed out waiting for confirmation")
+)
+
+// NumConfirmationWorkers is the number of confirmation workers to run.
+const NumConfirmationWorkers = 10
+
+// ConfirmationQueueSize bounds the number of sent transactions awaiting confirmation. Pushes block
+// once the queue is full.
+const ConfirmationQueueSize = 256
+
+const (
+	// confirmationWaitInterval is how long to wait on the signature subscription before checking the
+	// signature status and blockhash expiry over RPC.
+	confirmationWaitInterval = 15 * time.Second
+	confirmationPollInterval = 2 * time.Second
+	// maxConfirmationWait bounds the wait for a single transaction in case the RPC is unreachable.
+	maxConfirmationWait = 3 * time.Minute
+)
+
+var (
+	confirmationQueueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "stork",
+		Subsystem: "chain_pusher_solana",
+		Name:      "confirmation_queue_depth",
+		Help:      "Number of sent transactions waiting for a confirmation worker",
+	})
+	confirmationsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "stork",
+		Subsystem: "chain_pusher_solana",
+		Name:      "confirmations_total",
+		Help:      "Confirmation outcomes of sent transactions",
+	}, []string{"result"})
+)
+
+// pendingConfirmation is a sent update transaction awaiting confirmation, along with the batch it carries
+// so that it can be resubmitted if it does not land.
+type pendingConfirmation struct {
+	signature            solana.Signature
+	priceUpdates         map[types.InternalEncodedAssetID]types.AggregatedSignedPrice
+	attempt              int
+	lastValidBlockHeight uint64
+}
+
+// ConfirmationResults returns the confirmation outcome of every pushed batch, once it is known.
+func (sci *ContractInteractor) ConfirmationResults() <-chan types.ConfirmationResult {
+	return sci.confirmationResults
+}
+
+// enqueueConfirmation queues a sent transaction for confirmation, blocking while the queue is full.
+func (sci *ContractInteractor) enqueueConfirmation(ctx context.Context, pending pendingConfirmation) error {
+	select {
+	case sci.confirmationQueue <- pending:
+		confirmationQueueDepth.Set(float64(len(sci.confirmationQueue)))
+
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("confirmation queue full: %w", ctx.Err())
+	}
+}
+
+func (sci *ContractInteractor) startConfirmationWorkers(ctx context.Context, numWorkers int) {
+	for range numWorkers {
+		go sci.confirmationWorker(ctx)
+	}
+
+	sci.logger.Info().Int("numWorkers", numWorkers).Msg("Started confirmation workers")
+}
+
+func (sci *ContractInteractor) confirmationWorker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case pending := <-sci.confirmationQueue:
+			confirmationQueueDepth.Set(float64(len(sci.confirmationQueue)))
+			sci.confirmBatch(ctx, pending)
+		}
+	}
+}
+
+// confirmBatch waits for the batch to land, resubmitting it with a fresh blockhash and an escalated priority fee
+// when its transaction fails or expires, and reports the final outcome for the assets it carries.
+func (sci *ContractInteractor) confirmBatch(ctx context.Context, pending pendingConfirmation) {
+	for {
+		err := sci.waitForConfirmation(ctx, pending)
+		if err == nil {
+			sci.logger.Debug().Str("signature", pending.signature.String()).Msg("confirmed transaction")
+			confirmationsTotal.WithLabelValues("confirmed").Inc()
+			sci.reportConfirmation(pending, nil)
+
+			return
+		}
+
+		sci.logger.Error().Str("signature", pending.signature.String()).Err(err).Msg("failed to confirm transaction")
+
+		if ctx.Err() != nil || pending.attempt >= sci.priorityFees.MaxRetries {
+			confirmationsTotal.WithLabelValues("failed").Inc()
+			sci.reportConfirmation(pending, err)
+
+			return
+		}
+
+		next, err := sci.resubmitBatch(ctx, pending)
+		if err != nil {
+			sci.logger.Error().Err(err).Int("attempt", pending.attempt+1).Msg("Failed to resubmit batch")
+			confirmationsTotal.WithLabelValues("failed").Inc()
+			sci.reportConfirmation(pending, err)
+
+			return
+		}
+
+		confirmationsTotal.WithLabelValues("resubmitted").Inc()
+		sci.logger.Info().
+			Str("previousSignature", pending.signature.String()).
+			Str("signature", next.signature.String()).
+			Int("attempt", next.attempt).
+			Msg("Resubmitted batch with a fresh blockhash")
+
+		pending = next
+	}
+}
+
+func (sci *ContractInteractor) resubmitBatch(
+	ctx context.Context,
+	pending pendingConfirmation,
+) (pendingConfirmation, error) {
+	err := sci.limiter.Wait(ctx)
+	if err != nil {
+		return pendingConfirmation{}, fmt.Errorf("rate limiter error: %w", err)
+	}
+
+	return sci.sendBatch(ctx, pending.priceUpdates, pending.attempt+1)
+}
+
+// waitForConfirmation returns nil once the transaction is finalized without error, ErrTransactionFailed if it
+// landed but failed, and ErrBlockhashExpired once it can no longer land.
+func (sci *ContractInteractor) waitForConfirmation(ctx context.Context, pending pendingConfirmation) error {
+	ctx, cancel := context.WithTimeout(ctx, maxConfirmationWait)
+	defer cancel()
+
+	timeout := confirmationWaitInterval
+
+	for {
+		if sci.wsClient != nil {
+			confirmed, err := confirm.WaitForConfirmation(ctx, sci.wsClient, pending.signature, &timeout)
+			if err == nil {
+				return nil
+			}
+
+			if confirmed {
+				return fmt.Errorf("%w: %w", ErrTransactionFailed, err)
+			}
+
+			if !errors.Is(err, confirm.ErrTimeout) {
+				sci.logger.Debug().Err(err).Msg("Signature subscription failed, polling signature status")
+
+				_ = sleepWithContext(ctx, confirmationPollInterval)
+			}
+		} else {
+			_ = sleepWithContext(ctx, confirmationPollInterval)
+		}
+
+		if ctx.Err() != nil {
+			return fmt.Errorf("%w: %w", ErrConfirmationTimeout, ctx.Err())
+		}
+
+		// the subscription can miss transactions, so check the status directly before deciding it expired
+		done, err := sci.checkSignatureStatus(ctx, pending)
+		if done {
+			return err
+		}
+	}
+}
+
+// checkSignatureStatus queries the signature status and block height, reporting whether the outcome is final.
+func (sci *ContractInteractor) checkSignatureStatus(ctx context.Context, pending pendingConfirmation) (bool, error) {
+	statuses, err := sci.client.GetSignatureStatuses(ctx, false, pending.signature)
+	if err != nil {
+		sci.logger.Warn().Err(err).Msg("Failed to get signature status")
+
+		return false, nil
+	}
+
+	blockHeight, err := sci.client.GetBlockHeight(ctx, rpc.CommitmentConfirmed)
+	if err != nil {
+		sci.logger.Warn().Err(err).Msg("Failed to get block height")
+
+		return false, nil
+	}
+
+	var status *rpc.SignatureStatusesResult
+	if len(statuses.Value) > 0 {
+		status = statuses.Value[0]
+	}
+
+	return classifySignatureStatus(status, blockHeight, pending.lastValidBlockHeight)
+}
+
+// classifySignatureStatus reports whether a transaction has a final outcome, and the error if it did not succeed.
+func classifySignatureStatus(
+	status *rpc.SignatureStatusesResult,
+	blockHeight uint64,
+	lastValidBlockHeight uint64,
+) (bool, error) {
+	switch {
+	case status != nil && status.Err != nil:
+		return true, fmt.Errorf("%w: %v", ErrTransactionFailed, status.Err)
+	case status != nil && status.ConfirmationStatus == rpc.ConfirmationStatusFinalized:
+		return true, nil
+	case status != nil:
+		// landed but not yet finalized, so it no longer depends on the blockhash
+		return false, nil
+	case blockHeight > lastValidBlockHeight:
+		return true, ErrBlockhashExpired
+	default:
+		return false, nil
+	}
+}
+
+// reportConfirmation publishes the outcome of a batch without blocking the confirmation worker.
+func (sci *ContractInteractor) reportConfirmation(pending pendingConfirmation, err error) {
+	encodedAssetIDs := make([]types.InternalEncodedAssetID, 0, len(pending.priceUpdates))
+	for encodedAssetID := range pending.priceUpdates {
+		encodedAssetIDs = append(encodedAssetIDs, encodedAssetID)
+	}
+
+	result := types.ConfirmationResult{
+		EncodedAssetIDs: encodedAssetIDs,
+		Confirmed:       err == nil,
+		Err:             err,
+	}
+
+	select {
+	case sci.confirmationResults <- result:
+	default:
+		sci.logger.Warn().Str("signature", pending.signature.String()).Msg("Confirmation results full, dropping result")
+	}
+}
+
+func sleepWithContext(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
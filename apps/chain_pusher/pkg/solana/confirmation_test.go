@@ -0,0 +1,110 @@
+package solana
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/types"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifySignatureStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		status      *rpc.SignatureStatusesResult
+		blockHeight uint64
+		wantDone    bool
+		wantErr     error
+	}{
+		{
+			name:        "not landed, blockhash valid",
+			blockHeight: 100,
+		},
+		{
+			name:        "not landed, blockhash expired",
+			blockHeight: 151,
+			wantDone:    true,
+			wantErr:     ErrBlockhashExpired,
+		},
+		{
+			name:        "landed but not finalized after blockhash expiry",
+			status:      &rpc.SignatureStatusesResult{ConfirmationStatus: rpc.ConfirmationStatusConfirmed},
+			blockHeight: 151,
+		},
+		{
+			name:     "finalized",
+			status:   &rpc.SignatureStatusesResult{ConfirmationStatus: rpc.ConfirmationStatusFinalized},
+			wantDone: true,
+		},
+		{
+			name: "failed",
+			status: &rpc.SignatureStatusesResult{
+				ConfirmationStatus: rpc.ConfirmationStatusConfirmed,
+				Err:                map[string]any{"InstructionError": []any{0, "Custom"}},
+			},
+			wantDone: true,
+			wantErr:  ErrTransactionFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			done, err := classifySignatureStatus(tt.status, tt.blockHeight, 150)
+			assert.Equal(t, tt.wantDone, done)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestEnqueueConfirmationBackpressure(t *testing.T) {
+	t.Parallel()
+
+	sci := &ContractInteractor{confirmationQueue: make(chan pendingConfirmation, 1)}
+
+	require.NoError(t, sci.enqueueConfirmation(t.Context(), pendingConfirmation{}))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	require.ErrorIs(t, sci.enqueueConfirmation(ctx, pendingConfirmation{}), context.Canceled)
+	assert.Len(t, sci.confirmationQueue, 1)
+}
+
+func TestReportConfirmation(t *testing.T) {
+	t.Parallel()
+
+	sci := &ContractInteractor{
+		logger:              zerolog.Nop(),
+		confirmationResults: make(chan types.ConfirmationResult, 1),
+	}
+
+	var first, second types.InternalEncodedAssetID
+	first[0] = 1
+	second[0] = 2
+
+	pending := pendingConfirmation{
+		priceUpdates: map[types.InternalEncodedAssetID]types.AggregatedSignedPrice{first: {}, second: {}},
+	}
+
+	sci.reportConfirmation(pending, ErrBlockhashExpired)
+	// the results channel is full, so this result is dropped instead of blocking
+	sci.reportConfirmation(pending, nil)
+
+	result := <-sci.ConfirmationResults()
+	assert.False(t, result.Confirmed)
+	require.ErrorIs(t, result.Err, ErrBlockhashExpired)
+	assert.ElementsMatch(t, []types.InternalEncodedAssetID{first, second}, result.EncodedAssetIDs)
+	assert.Empty(t, sci.ConfirmationResults())
+}
@@ -1,12 +1,14 @@
 package solana
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/big"
+	"slices"
 	"sync"
 	"time"
 
@@ -17,7 +19,6 @@ import (
 	bin "github.com/gagliardetto/binary"
 	"github.com/gagliardetto/solana-go"
 	"github.com/gagliardetto/solana-go/rpc"
-	confirm "github.com/gagliardetto/solana-go/rpc/sendAndConfirmTransaction"
 	"github.com/gagliardetto/solana-go/rpc/ws"
 	"github.com/rs/zerolog"
 	"golang.org/x/time/rate"
@@ -26,28 +27,36 @@ import (
 // NumTreasuryAccounts is the number of treasury accounts to use.
 const NumTreasuryAccounts = 256
 
-var ErrBatchSizeExceedsLimit = errors.New("batch size exceeds limit, skipping update")
+var ErrTransactionTooLarge = errors.New("update transaction exceeds the maximum transaction size, skipping update")
 
-type ContractInteractor struct {
-	logger             zerolog.Logger
-	client             *rpc.Client
-	wsClient           *ws.Client
-	contractAddr       solana.PublicKey
-	feedAccounts       map[types.InternalEncodedAssetID]solana.PublicKey
-	treasuryAccounts   map[uint8]solana.PublicKey
-	configAccount      solana.PublicKey
-	payer              solana.PrivateKey
-	limiter            *rate.Limiter
-	pollingPeriodSec   int
-	batchSize          int
-	confirmationInChan chan solana.Signature
-}
+// maxAccountsPerRequest is the maximum number of accounts getMultipleAccounts returns per request.
+const maxAccountsPerRequest = 100
 
-// MaxBatchSize is a limit imposed by the Solana blockchain and the size our update instruction.
-const MaxBatchSize = 4
+// MaxTransactionSize is the maximum serialized transaction size accepted by the Solana network.
+const MaxTransactionSize = 1232
 
-// NumConfirmationWorkers is the number of confirmation workers to run.
-const NumConfirmationWorkers = 10
+type ContractInteractor struct {
+	logger              zerolog.Logger
+	client              *rpc.Client
+	wsClient            *ws.Client
+	contractAddr        solana.PublicKey
+	feedAccounts        map[types.InternalEncodedAssetID]solana.PublicKey
+	treasuryAccounts    map[uint8]solana.PublicKey
+	configAccount       solana.PublicKey
+	payer               solana.PrivateKey
+	limiter             *rate.Limiter
+	pollingPeriodSec    int
+	batchSize           int
+	priorityFees        PriorityFeeConfig
+	confirmationQueue   chan pendingConfirmation
+	confirmationResults chan types.ConfirmationResult
+	useLookupTables     bool
+	lookupTableKeys     solana.PublicKeySlice
+	lookupTableSetup    sync.Once
+	lookupTablesMu      sync.RWMutex
+	lookupTables        map[solana.PublicKey]solana.PublicKeySlice
+	includeTreasury     bool
+}
 
 func NewContractInteractor(
 	ctx context.Context,
@@ -55,11 +64,20 @@ func NewContractInteractor(
 	payer []byte,
 	assetConfigFile string,
 	pollingPeriodSec int, logger zerolog.Logger, limitPerSecond int, burstLimit int, batchSize int,
+	priorityFees PriorityFeeConfig,
+	useLookupTables bool,
+	lookupTableAddresses []string,
+	includeTreasuryBalance bool,
 ) (*ContractInteractor, error) {
 	logger = logger.With().Str("component", "solana-contract-interactor").Logger()
 
-	if 0 < batchSize && batchSize < MaxBatchSize {
-		logger.Fatal().Msgf("Batch size must be between 1 and %d", MaxBatchSize)
+	if batchSize < 0 {
+		logger.Fatal().Msg("Batch size must not be negative")
+	}
+
+	err := priorityFees.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid priority fee config: %w", err)
 	}
 	// calculate the time between requests bases on limitPerSecond
 	timeBetweenRequests := time.Second / time.Duration(limitPerSecond)
@@ -72,6 +90,19 @@ func NewContractInteractor(
 		return nil, fmt.Errorf("invalid contract address: %w", err)
 	}
 
+	lookupTableKeys := make(solana.PublicKeySlice, 0, len(lookupTableAddresses))
+
+	for _, address := range lookupTableAddresses {
+		var lookupTableKey solana.PublicKey
+
+		lookupTableKey, err = solana.PublicKeyFromBase58(address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid lookup table address %q: %w", address, err)
+		}
+
+		lookupTableKeys = append(lookupTableKeys, lookupTableKey)
+	}
+
 	assetConfig, err := types.LoadConfig(assetConfigFile)
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Failed to load asset config")
@@ -97,36 +128,43 @@ func NewContractInteractor(
 		return nil, fmt.Errorf("failed to derive PDA for config account: %w", err)
 	}
 
-	confirmationInChan := make(chan solana.Signature)
-	confirmationOutChan := make(chan solana.Signature)
-
 	bindings.SetProgramID(contractPubKey)
 	sci := &ContractInteractor{
-		logger:             logger,
-		client:             nil,
-		wsClient:           nil,
-		contractAddr:       contractPubKey,
-		feedAccounts:       feedAccounts,
-		treasuryAccounts:   treasuryAccounts,
-		configAccount:      configAccount,
-		payer:              payer,
-		limiter:            limiter,
-		pollingPeriodSec:   pollingPeriodSec,
-		batchSize:          batchSize,
-		confirmationInChan: confirmationInChan,
-	}
-
-	go sci.runUnboundedConfirmationBuffer(confirmationOutChan)
-
-	sci.startConfirmationWorkers(ctx, confirmationOutChan, NumConfirmationWorkers)
+		logger:              logger,
+		client:              nil,
+		wsClient:            nil,
+		contractAddr:        contractPubKey,
+		feedAccounts:        feedAccounts,
+		treasuryAccounts:    treasuryAccounts,
+		configAccount:       configAccount,
+		payer:               payer,
+		limiter:             limiter,
+		pollingPeriodSec:    pollingPeriodSec,
+		batchSize:           batchSize,
+		priorityFees:        priorityFees,
+		confirmationQueue:   make(chan pendingConfirmation, ConfirmationQueueSize),
+		confirmationResults: make(chan types.ConfirmationResult, ConfirmationQueueSize),
+		useLookupTables:     useLookupTables,
+		lookupTableKeys:     lookupTableKeys,
+		includeTreasury:     includeTreasuryBalance,
+	}
+
+	sci.startConfirmationWorkers(ctx, NumConfirmationWorkers)
 
 	return sci, nil
 }
 
-func (sci *ContractInteractor) ConnectHTTP(_ context.Context, url string) error {
+func (sci *ContractInteractor) ConnectHTTP(ctx context.Context, url string) error {
 	client := rpc.New(url)
 	sci.client = client
 
+	// set up once in the background rather than on every reconnect, as setup sends transactions that pay rent
+	if sci.useLookupTables {
+		sci.lookupTableSetup.Do(func() {
+			go sci.setupLookupTablesWithRetry(ctx, client)
+		})
+	}
+
 	return nil
 }
 
@@ -228,7 +266,11 @@ func (sci *ContractInteractor) BatchPushToContract(
 	errChan := make(chan error, len(priceUpdates))
 	sigChan := make(chan solana.Signature, len(priceUpdates))
 
-	priceUpdatesBatches := sci.batchPriceUpdates(priceUpdates)
+	priceUpdatesBatches, err := sci.batchPriceUpdates(priceUpdates)
+	if err != nil {
+		return fmt.Errorf("failed to batch price updates: %w", err)
+	}
+
 	for _, priceUpdateBatch := range priceUpdatesBatches {
 		wg.Add(1)
 
@@ -278,12 +320,62 @@ func (sci *ContractInteractor) BatchPushToContract(
 	return nil
 }
 
-// GetWalletBalance is a placeholder function to get the balance of the wallet being used to push to the contract.
-// todo: implement
-//
-//nolint:godox // This function has unmet criteria to be implemented.
+// GetWalletBalance returns the payer balance in lamports, plus the lamports held by the treasury accounts
+// when the treasury balance is included.
 func (sci *ContractInteractor) GetWalletBalance(ctx context.Context) (float64, error) {
-	return -1, nil
+	balance, err := sci.client.GetBalance(ctx, sci.payer.PublicKey(), rpc.CommitmentConfirmed)
+	if err != nil {
+		return -1, fmt.Errorf("failed to get payer balance: %w", err)
+	}
+
+	lamports := balance.Value
+
+	if sci.includeTreasury {
+		treasuryLamports, err := sci.getTreasuryBalance(ctx)
+		if err != nil {
+			return -1, err
+		}
+
+		lamports += treasuryLamports
+	}
+
+	return float64(lamports), nil
+}
+
+// getTreasuryBalance sums the lamports held by all treasury accounts, skipping accounts that do not exist yet.
+func (sci *ContractInteractor) getTreasuryBalance(ctx context.Context) (uint64, error) {
+	treasuryAccounts := make(solana.PublicKeySlice, 0, len(sci.treasuryAccounts))
+	for _, treasuryAccount := range sci.treasuryAccounts {
+		treasuryAccounts = append(treasuryAccounts, treasuryAccount)
+	}
+
+	var (
+		lamports  uint64
+		zero      uint64
+		sliceSize uint64
+	)
+
+	for start := 0; start < len(treasuryAccounts); start += maxAccountsPerRequest {
+		end := min(start+maxAccountsPerRequest, len(treasuryAccounts))
+
+		// only the lamports are needed, so skip the account data
+		accounts, err := sci.client.GetMultipleAccountsWithOpts(ctx, treasuryAccounts[start:end], &rpc.GetMultipleAccountsOpts{
+			Encoding:   solana.EncodingBase64,
+			Commitment: rpc.CommitmentConfirmed,
+			DataSlice:  &rpc.DataSlice{Offset: &zero, Length: &sliceSize},
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to get treasury accounts: %w", err)
+		}
+
+		for _, account := range accounts.Value {
+			if account != nil {
+				lamports += account.Lamports
+			}
+		}
+	}
+
+	return lamports, nil
 }
 
 func getFeedAccountsFromAssets(
@@ -362,42 +454,6 @@ func getTreasuryAccounts(
 	return treasuryAccounts, nil
 }
 
-func (sci *ContractInteractor) runUnboundedConfirmationBuffer(confirmationOutChan chan solana.Signature) {
-	var queue []solana.Signature
-	for {
-		if len(queue) == 0 {
-			sig := <-sci.confirmationInChan
-			queue = append(queue, sig)
-		}
-
-		select {
-		case sig := <-sci.confirmationInChan:
-			queue = append(queue, sig)
-		case confirmationOutChan <- queue[0]:
-			queue = queue[1:]
-		}
-	}
-}
-
-func (sci *ContractInteractor) startConfirmationWorkers(ctx context.Context, ch chan solana.Signature, numWorkers int) {
-	for range numWorkers {
-		go sci.confirmationWorker(ctx, ch)
-	}
-
-	sci.logger.Info().Int("numWorkers", numWorkers).Msg("Started confirmation workers")
-}
-
-func (sci *ContractInteractor) confirmationWorker(ctx context.Context, ch chan solana.Signature) {
-	for sig := range ch {
-		_, err := confirm.WaitForConfirmation(ctx, sci.wsClient, sig, nil)
-		if err != nil {
-			sci.logger.Error().Str("signature", sig.String()).Err(err).Msg("failed to confirm transaction")
-		} else {
-			sci.logger.Debug().Str("signature", sig.String()).Msg("confirmed transaction")
-		}
-	}
-}
-
 func (sci *ContractInteractor) listenSingleContractEvent(
 	ctx context.Context,
 	ch chan map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue,
@@ -451,57 +507,100 @@ func (sci *ContractInteractor) listenSingleContractEvent(
 	}
 }
 
+// batchPriceUpdates groups price updates into batches whose transactions fit within MaxTransactionSize,
+// capped at batchSize updates per batch when it is set.
 func (sci *ContractInteractor) batchPriceUpdates(
 	priceUpdates map[types.InternalEncodedAssetID]types.AggregatedSignedPrice,
-) []map[types.InternalEncodedAssetID]types.AggregatedSignedPrice {
+) ([]map[types.InternalEncodedAssetID]types.AggregatedSignedPrice, error) {
 	priceUpdatesBatches := []map[types.InternalEncodedAssetID]types.AggregatedSignedPrice{}
 
+	encodedAssetIDs := make([]types.InternalEncodedAssetID, 0, len(priceUpdates))
+	for encodedAssetID := range priceUpdates {
+		encodedAssetIDs = append(encodedAssetIDs, encodedAssetID)
+	}
+
+	slices.SortFunc(encodedAssetIDs, func(a, b types.InternalEncodedAssetID) int {
+		return bytes.Compare(a[:], b[:])
+	})
+
 	priceUpdatesBatch := make(map[types.InternalEncodedAssetID]types.AggregatedSignedPrice)
-	i := 0
 
-	for encodedAssetID, priceUpdate := range priceUpdates {
-		priceUpdatesBatch[encodedAssetID] = priceUpdate
+	for _, encodedAssetID := range encodedAssetIDs {
+		priceUpdatesBatch[encodedAssetID] = priceUpdates[encodedAssetID]
 
-		i++
-		if len(priceUpdatesBatch) == sci.batchSize || i == len(priceUpdates) {
-			batchCopy := make(map[types.InternalEncodedAssetID]types.AggregatedSignedPrice, len(priceUpdatesBatch))
-			for k, v := range priceUpdatesBatch {
-				batchCopy[k] = v
+		fits, err := sci.batchFitsTransaction(priceUpdatesBatch)
+		if err != nil {
+			return nil, err
+		}
+
+		if !fits {
+			delete(priceUpdatesBatch, encodedAssetID)
+
+			if len(priceUpdatesBatch) == 0 {
+				return nil, ErrTransactionTooLarge
 			}
 
-			priceUpdatesBatches = append(priceUpdatesBatches, batchCopy)
+			priceUpdatesBatches = append(priceUpdatesBatches, priceUpdatesBatch)
+			priceUpdatesBatch = map[types.InternalEncodedAssetID]types.AggregatedSignedPrice{
+				encodedAssetID: priceUpdates[encodedAssetID],
+			}
+		}
+
+		if len(priceUpdatesBatch) == sci.batchSize {
+			priceUpdatesBatches = append(priceUpdatesBatches, priceUpdatesBatch)
 			priceUpdatesBatch = make(map[types.InternalEncodedAssetID]types.AggregatedSignedPrice)
 		}
 	}
 
-	return priceUpdatesBatches
+	if len(priceUpdatesBatch) > 0 {
+		priceUpdatesBatches = append(priceUpdatesBatches, priceUpdatesBatch)
+	}
+
+	return priceUpdatesBatches, nil
 }
 
-func (sci *ContractInteractor) pushLimitedBatchUpdateToContract(
-	ctx context.Context,
+// batchFitsTransaction reports whether an update transaction for the batch fits within MaxTransactionSize,
+// including the compute budget instructions it may carry.
+func (sci *ContractInteractor) batchFitsTransaction(
 	priceUpdates map[types.InternalEncodedAssetID]types.AggregatedSignedPrice,
-) (solana.Signature, error) {
-	if len(priceUpdates) > MaxBatchSize {
-		return solana.Signature{}, ErrBatchSizeExceedsLimit
+) (bool, error) {
+	updateInstructions, _, err := sci.buildUpdateInstructions(priceUpdates, 0)
+	if err != nil {
+		return false, err
 	}
 
-	randomID, err := rand.Int(rand.Reader, big.NewInt(NumTreasuryAccounts))
+	instructions, err := sci.placeholderComputeBudgetInstructions()
 	if err != nil {
-		return solana.Signature{}, fmt.Errorf("failed to generate random ID: %w", err)
+		return false, err
 	}
 
-	//nolint:gosec // "randomID" is clearly constrained to uint8 range
-	treasuryID := uint8(randomID.Uint64())
+	tx, err := sci.newTransaction(append(instructions, updateInstructions...), solana.Hash{})
+	if err != nil {
+		return false, err
+	}
+
+	size, err := transactionSize(tx)
+	if err != nil {
+		return false, err
+	}
 
+	return size <= MaxTransactionSize, nil
+}
+
+// buildUpdateInstructions builds an update instruction per price update, returning them along with
+// the accounts they write to.
+func (sci *ContractInteractor) buildUpdateInstructions(
+	priceUpdates map[types.InternalEncodedAssetID]types.AggregatedSignedPrice,
+	treasuryID uint8,
+) ([]solana.Instruction, solana.PublicKeySlice, error) {
 	treasuryAccount := sci.treasuryAccounts[treasuryID]
 	instructions := []solana.Instruction{}
-	assetIDs := []string{}
+	writableAccounts := solana.PublicKeySlice{sci.configAccount}
 
-	var updateData bindings.TemporalNumericValueEvmInput
 	for encodedAssetID, priceUpdate := range priceUpdates {
-		updateData, err = sci.priceUpdateToTemporalNumericValueEvmInput(priceUpdate, treasuryID)
+		updateData, err := sci.priceUpdateToTemporalNumericValueEvmInput(priceUpdate, treasuryID)
 		if err != nil {
-			return solana.Signature{}, fmt.Errorf(
+			return nil, nil, fmt.Errorf(
 				"failed to convert price update to TemporalNumericValueEvmInput: %w",
 				err,
 			)
@@ -509,9 +608,7 @@ func (sci *ContractInteractor) pushLimitedBatchUpdateToContract(
 
 		feedAccount := sci.feedAccounts[encodedAssetID]
 
-		var instruction *bindings.Instruction
-
-		instruction, err = bindings.NewUpdateTemporalNumericValueEvmInstruction(
+		instruction, err := bindings.NewUpdateTemporalNumericValueEvmInstruction(
 			updateData,
 			sci.configAccount,
 			treasuryAccount,
@@ -520,24 +617,124 @@ func (sci *ContractInteractor) pushLimitedBatchUpdateToContract(
 			solana.SystemProgramID,
 		).ValidateAndBuild()
 		if err != nil {
-			return solana.Signature{}, fmt.Errorf("failed to build instruction: %w", err)
+			return nil, nil, fmt.Errorf("failed to build instruction: %w", err)
 		}
 
 		instructions = append(instructions, instruction)
+		writableAccounts = append(writableAccounts, feedAccount)
 	}
 
-	recentBlockHash, err := sci.client.GetLatestBlockhash(ctx, rpc.CommitmentFinalized)
+	return instructions, writableAccounts, nil
+}
+
+// newTransaction creates a transaction paid by the payer, as a v0 transaction when lookup tables are available.
+func (sci *ContractInteractor) newTransaction(
+	instructions []solana.Instruction,
+	recentBlockHash solana.Hash,
+) (*solana.Transaction, error) {
+	opts := []solana.TransactionOption{solana.TransactionPayer(sci.payer.PublicKey())}
+
+	addressTables := sci.addressTables()
+	if len(addressTables) > 0 {
+		opts = append(opts, solana.TransactionAddressTables(addressTables))
+	}
+
+	tx, err := solana.NewTransaction(instructions, recentBlockHash, opts...)
 	if err != nil {
-		return solana.Signature{}, fmt.Errorf("failed to get recent blockhash: %w", err)
+		return nil, fmt.Errorf("failed to create transaction: %w", err)
 	}
 
-	tx, err := solana.NewTransaction(
-		instructions,
-		recentBlockHash.Value.Blockhash,
-		solana.TransactionPayer(sci.payer.PublicKey()),
+	return tx, nil
+}
+
+func (sci *ContractInteractor) addressTables() map[solana.PublicKey]solana.PublicKeySlice {
+	sci.lookupTablesMu.RLock()
+	defer sci.lookupTablesMu.RUnlock()
+
+	return sci.lookupTables
+}
+
+// numTreasuryAccounts returns the number of treasury accounts updates are spread across.
+func (sci *ContractInteractor) numTreasuryAccounts() int64 {
+	if len(sci.addressTables()) > 0 {
+		return NumLookupTableTreasuryAccounts
+	}
+
+	return NumTreasuryAccounts
+}
+
+func (sci *ContractInteractor) pushLimitedBatchUpdateToContract(
+	ctx context.Context,
+	priceUpdates map[types.InternalEncodedAssetID]types.AggregatedSignedPrice,
+) (solana.Signature, error) {
+	pending, err := sci.sendBatch(ctx, priceUpdates, 0)
+	if err != nil {
+		return solana.Signature{}, err
+	}
+
+	// check for confirmation without blocking, unless the confirmation queue is full
+	err = sci.enqueueConfirmation(ctx, pending)
+	if err != nil {
+		sci.logger.Warn().Err(err).Str("signature", pending.signature.String()).Msg("Batch will not be confirmed")
+	}
+
+	return pending.signature, nil
+}
+
+// sendBatch builds, signs and sends an update transaction for the batch with a fresh blockhash.
+// attempt is the number of times the batch was sent before, and escalates the priority fee.
+func (sci *ContractInteractor) sendBatch(
+	ctx context.Context,
+	priceUpdates map[types.InternalEncodedAssetID]types.AggregatedSignedPrice,
+	attempt int,
+) (pendingConfirmation, error) {
+	randomID, err := rand.Int(rand.Reader, big.NewInt(sci.numTreasuryAccounts()))
+	if err != nil {
+		return pendingConfirmation{}, fmt.Errorf("failed to generate random ID: %w", err)
+	}
+
+	//nolint:gosec // "randomID" is clearly constrained to uint8 range
+	treasuryID := uint8(randomID.Uint64())
+
+	updateInstructions, writableAccounts, err := sci.buildUpdateInstructions(priceUpdates, treasuryID)
+	if err != nil {
+		return pendingConfirmation{}, err
+	}
+
+	instructions, computeUnitPrice, err := sci.computeBudgetInstructions(
+		ctx,
+		writableAccounts,
+		len(updateInstructions),
+		attempt,
 	)
 	if err != nil {
-		return solana.Signature{}, fmt.Errorf("failed to create transaction: %w", err)
+		return pendingConfirmation{}, err
+	}
+
+	instructions = append(instructions, updateInstructions...)
+
+	assetIDs := make([]string, 0, len(priceUpdates))
+	for _, priceUpdate := range priceUpdates {
+		assetIDs = append(assetIDs, string(priceUpdate.AssetID))
+	}
+
+	recentBlockHash, err := sci.client.GetLatestBlockhash(ctx, rpc.CommitmentFinalized)
+	if err != nil {
+		return pendingConfirmation{}, fmt.Errorf("failed to get recent blockhash: %w", err)
+	}
+
+	tx, err := sci.newTransaction(instructions, recentBlockHash.Value.Blockhash)
+	if err != nil {
+		return pendingConfirmation{}, err
+	}
+
+	size, err := transactionSize(tx)
+	if err != nil {
+		return pendingConfirmation{}, err
+	}
+
+	if size > MaxTransactionSize {
+		return pendingConfirmation{}, ErrTransactionTooLarge
 	}
 
 	_, err = tx.Sign(
@@ -549,23 +746,27 @@ func (sci *ContractInteractor) pushLimitedBatchUpdateToContract(
 			return nil
 		})
 	if err != nil {
-		return solana.Signature{}, fmt.Errorf("failed to sign transaction: %w", err)
+		return pendingConfirmation{}, fmt.Errorf("failed to sign transaction: %w", err)
 	}
 
 	sig, err := sci.client.SendTransaction(ctx, tx)
 	if err != nil {
-		return solana.Signature{}, fmt.Errorf("failed to send transaction: %w", err)
+		return pendingConfirmation{}, fmt.Errorf("failed to send transaction: %w", err)
 	}
-	// check for confirmation without blocking
-	sci.confirmationInChan <- sig
-
 	sci.logger.Debug().
 		Str("signature", sig.String()).
 		Strs("assetIDs", assetIDs).
 		Uint8("treasuryID", treasuryID).
+		Uint64("computeUnitPrice", computeUnitPrice).
+		Int("attempt", attempt).
 		Msg("Pushed batch update to contract")
 
-	return sig, nil
+	return pendingConfirmation{
+		signature:            sig,
+		priceUpdates:         priceUpdates,
+		attempt:              attempt,
+		lastValidBlockHeight: recentBlockHash.Value.LastValidBlockHeight,
+	}, nil
 }
 
 func (sci *ContractInteractor) priceUpdateToTemporalNumericValueEvmInput(
@@ -7,10 +7,47 @@ import (
 	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/types"
 	"github.com/Stork-Oracle/stork-external/shared"
 	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// newBatchingInteractor returns an interactor with distinct feed and treasury accounts for numAssets assets.
+func newBatchingInteractor(t *testing.T, numAssets int) (
+	*ContractInteractor,
+	map[types.InternalEncodedAssetID]types.AggregatedSignedPrice,
+) {
+	t.Helper()
+
+	payer, err := solana.NewRandomPrivateKey()
+	require.NoError(t, err)
+
+	sci := &ContractInteractor{
+		payer:            payer,
+		configAccount:    solana.NewWallet().PublicKey(),
+		feedAccounts:     make(map[types.InternalEncodedAssetID]solana.PublicKey),
+		treasuryAccounts: make(map[uint8]solana.PublicKey),
+		priorityFees:     PriorityFeeConfig{Mode: PriorityFeeModeNone, EscalationFactor: 2},
+	}
+
+	for i := range NumTreasuryAccounts {
+		sci.treasuryAccounts[uint8(i)] = solana.NewWallet().PublicKey()
+	}
+
+	price := testutil.StandardPriceCase()[0].Price
+	priceUpdates := make(map[types.InternalEncodedAssetID]types.AggregatedSignedPrice)
+
+	for i := range numAssets {
+		var assetID types.InternalEncodedAssetID
+
+		assetID[0] = byte(i + 1) // Create unique asset IDs
+		priceUpdates[assetID] = price
+		sci.feedAccounts[assetID] = solana.NewWallet().PublicKey()
+	}
+
+	return sci, priceUpdates
+}
+
 func TestBatchPriceUpdates(t *testing.T) {
 	t.Parallel()
 
@@ -31,20 +68,11 @@ func TestBatchPriceUpdates(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			// Create test price updates map
-			priceUpdates := make(map[types.InternalEncodedAssetID]types.AggregatedSignedPrice)
-			for i := range tt.numUpdates {
-				var assetID types.InternalEncodedAssetID
-
-				assetID[0] = byte(i + 1) // Create unique asset IDs
-				priceUpdates[assetID] = types.AggregatedSignedPrice{}
-			}
-
-			sci := &ContractInteractor{
-				batchSize: tt.batchSize,
-			}
+			sci, priceUpdates := newBatchingInteractor(t, tt.numUpdates)
+			sci.batchSize = tt.batchSize
 
-			batches := sci.batchPriceUpdates(priceUpdates)
+			batches, err := sci.batchPriceUpdates(priceUpdates)
+			require.NoError(t, err)
 
 			assert.Len(t, batches, tt.expectedBatches)
 
@@ -61,6 +89,55 @@ func TestBatchPriceUpdates(t *testing.T) {
 	}
 }
 
+func TestBatchPriceUpdatesBySize(t *testing.T) {
+	t.Parallel()
+
+	const numUpdates = 20
+
+	batchSizes := func(sci *ContractInteractor, priceUpdates map[types.InternalEncodedAssetID]types.AggregatedSignedPrice) []int {
+		batches, err := sci.batchPriceUpdates(priceUpdates)
+		require.NoError(t, err)
+
+		sizes := make([]int, len(batches))
+		total := 0
+
+		for i, batch := range batches {
+			fits, err := sci.batchFitsTransaction(batch)
+			require.NoError(t, err)
+			assert.True(t, fits)
+
+			sizes[i] = len(batch)
+			total += len(batch)
+		}
+
+		assert.Equal(t, numUpdates, total)
+
+		return sizes
+	}
+
+	sci, priceUpdates := newBatchingInteractor(t, numUpdates)
+	legacySizes := batchSizes(sci, priceUpdates)
+	assert.Greater(t, len(legacySizes), 1)
+
+	// every batch but the last is full, so adding one more update would not fit
+	for _, size := range legacySizes[:len(legacySizes)-1] {
+		assert.Equal(t, legacySizes[0], size)
+	}
+
+	// compute budget instructions take up space in every transaction
+	sci.priorityFees = PriorityFeeConfig{
+		ComputeUnitLimit: 400_000, Mode: PriorityFeeModeStatic, StaticMicroLamports: 1, EscalationFactor: 2,
+	}
+	assert.LessOrEqual(t, batchSizes(sci, priceUpdates)[0], legacySizes[0])
+
+	// loading accounts from a lookup table fits more updates per transaction
+	sci.priorityFees = PriorityFeeConfig{Mode: PriorityFeeModeNone, EscalationFactor: 2}
+	sci.lookupTables = map[solana.PublicKey]solana.PublicKeySlice{
+		solana.NewWallet().PublicKey(): sci.lookupTableAddresses(),
+	}
+	assert.Greater(t, batchSizes(sci, priceUpdates)[0], legacySizes[0])
+}
+
 func TestPriceUpdateToTemporalNumericValueEvmInput(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,178 @@
+package solana
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/go-bip39"
+	"github.com/gagliardetto/solana-go"
+)
+
+var (
+	ErrUnrecognizedKeyFormat  = errors.New("unrecognized private key format, expected a keypair JSON array, base58 key or mnemonic")
+	ErrInvalidKeypair         = errors.New("invalid keypair, public key does not match secret key")
+	ErrInvalidMnemonic        = errors.New("invalid BIP-39 mnemonic")
+	ErrInvalidDerivationPath  = errors.New("invalid derivation path")
+	ErrNonHardenedDerivation  = errors.New("ed25519 derivation only supports hardened path segments")
+	ErrKeypairByteOutOfRange  = errors.New("keypair byte out of range")
+	errDerivationPathSegments = errors.New("derivation path must start with m")
+)
+
+// DefaultDerivationPath is the derivation path used by solana-keygen and most wallets for mnemonics.
+const DefaultDerivationPath = "m/44'/501'/0'/0'"
+
+const (
+	// slip10Ed25519Curve is the HMAC key used to derive the SLIP-0010 master key for ed25519.
+	slip10Ed25519Curve = "ed25519 seed"
+	hardenedOffset     = 0x80000000
+	slip10KeyBytes     = 32
+)
+
+// LoadPrivateKeyFile reads a Solana private key from a file in any of the formats accepted by ParsePrivateKey.
+func LoadPrivateKeyFile(path string, derivationPath string) (solana.PrivateKey, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key file: %w", err)
+	}
+
+	return ParsePrivateKey(content, derivationPath)
+}
+
+// ParsePrivateKey parses a Solana private key from a solana-keygen JSON byte array, a base58 encoded secret key
+// or a BIP-39 mnemonic, which is derived along the given SLIP-0010 derivation path.
+func ParsePrivateKey(content []byte, derivationPath string) (solana.PrivateKey, error) {
+	trimmed := strings.TrimSpace(string(content))
+
+	switch {
+	case strings.HasPrefix(trimmed, "["):
+		return parseKeypairJSON([]byte(trimmed))
+	case len(strings.Fields(trimmed)) > 1:
+		return privateKeyFromMnemonic(trimmed, derivationPath)
+	default:
+		privateKey, err := solana.PrivateKeyFromBase58(trimmed)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrUnrecognizedKeyFormat, err)
+		}
+
+		return validateKeypair(privateKey)
+	}
+}
+
+func parseKeypairJSON(content []byte) (solana.PrivateKey, error) {
+	var values []int
+
+	err := json.Unmarshal(content, &values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse keypair JSON: %w", err)
+	}
+
+	privateKey := make(solana.PrivateKey, len(values))
+
+	for i, value := range values {
+		if value < 0 || value > math.MaxUint8 {
+			return nil, fmt.Errorf("%w: %d", ErrKeypairByteOutOfRange, value)
+		}
+
+		privateKey[i] = byte(value)
+	}
+
+	return validateKeypair(privateKey)
+}
+
+// validateKeypair checks that the key is a 64 byte ed25519 keypair whose public half matches its seed.
+func validateKeypair(privateKey solana.PrivateKey) (solana.PrivateKey, error) {
+	if len(privateKey) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidKeypair, ed25519.PrivateKeySize, len(privateKey))
+	}
+
+	derived := ed25519.NewKeyFromSeed(privateKey[:ed25519.SeedSize])
+	if !bytes.Equal(derived, privateKey) {
+		return nil, ErrInvalidKeypair
+	}
+
+	return privateKey, nil
+}
+
+func privateKeyFromMnemonic(mnemonic string, derivationPath string) (solana.PrivateKey, error) {
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, ErrInvalidMnemonic
+	}
+
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, "")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidMnemonic, err)
+	}
+
+	key, err := deriveEd25519Key(seed, derivationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return solana.PrivateKey(ed25519.NewKeyFromSeed(key)), nil
+}
+
+// parseDerivationPath parses a path like m/44'/501'/0'/0' into hardened child indexes.
+func parseDerivationPath(path string) ([]uint32, error) {
+	segments := strings.Split(strings.TrimSpace(path), "/")
+	if segments[0] != "m" {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDerivationPath, errDerivationPathSegments)
+	}
+
+	indexes := make([]uint32, 0, len(segments)-1)
+
+	for _, segment := range segments[1:] {
+		hardened := strings.HasSuffix(segment, "'") || strings.HasSuffix(segment, "h")
+		if !hardened {
+			return nil, fmt.Errorf("%w: %q", ErrNonHardenedDerivation, segment)
+		}
+
+		index, err := strconv.ParseUint(segment[:len(segment)-1], 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %w", ErrInvalidDerivationPath, segment, err)
+		}
+
+		indexes = append(indexes, uint32(index)+hardenedOffset)
+	}
+
+	return indexes, nil
+}
+
+// deriveEd25519Key derives an ed25519 seed from a BIP-39 seed following SLIP-0010.
+func deriveEd25519Key(seed []byte, path string) ([]byte, error) {
+	indexes, err := parseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	mac := hmac.New(sha512.New, []byte(slip10Ed25519Curve))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	key, chainCode := sum[:slip10KeyBytes], sum[slip10KeyBytes:]
+
+	for _, index := range indexes {
+		data := make([]byte, 0, 1+slip10KeyBytes+4)
+		data = append(data, 0)
+		data = append(data, key...)
+		data = binary.BigEndian.AppendUint32(data, index)
+
+		mac = hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		sum = mac.Sum(nil)
+
+		key, chainCode = sum[:slip10KeyBytes], sum[slip10KeyBytes:]
+	}
+
+	return key, nil
+}
@@ -0,0 +1,168 @@
+package solana
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestDeriveEd25519Key(t *testing.T) {
+	t.Parallel()
+
+	// SLIP-0010 ed25519 test vector 1
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	require.NoError(t, err)
+
+	tests := []struct {
+		path string
+		key  string
+	}{
+		{"m", "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7"},
+		{"m/0'", "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3"},
+		{"m/0'/1'/2'/2'/1000000000'", "8f94d394a8e8fd6b1bc2f3f49f5c47e385281d5c17e65324b0f62483e37e8793"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			key, err := deriveEd25519Key(seed, tt.path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.key, hex.EncodeToString(key))
+		})
+	}
+}
+
+func TestParseDerivationPath(t *testing.T) {
+	t.Parallel()
+
+	indexes, err := parseDerivationPath(DefaultDerivationPath)
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{44 + hardenedOffset, 501 + hardenedOffset, hardenedOffset, hardenedOffset}, indexes)
+
+	_, err = parseDerivationPath("44'/501'")
+	require.ErrorIs(t, err, ErrInvalidDerivationPath)
+
+	_, err = parseDerivationPath("m/44'/501'/0/0")
+	require.ErrorIs(t, err, ErrNonHardenedDerivation)
+
+	_, err = parseDerivationPath("m/x'")
+	require.ErrorIs(t, err, ErrInvalidDerivationPath)
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	t.Parallel()
+
+	privateKey := solana.NewWallet().PrivateKey
+
+	keypairJSON, err := json.Marshal(toInts(privateKey))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"keypair json", string(keypairJSON)},
+		{"keypair json with whitespace", "\n" + string(keypairJSON) + "\n"},
+		{"base58", privateKey.String()},
+		{"base58 with newline", privateKey.String() + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			parsed, err := ParsePrivateKey([]byte(tt.content), DefaultDerivationPath)
+			require.NoError(t, err)
+			assert.Equal(t, privateKey, parsed)
+		})
+	}
+}
+
+func TestParsePrivateKeyMnemonic(t *testing.T) {
+	t.Parallel()
+
+	first, err := ParsePrivateKey([]byte(testMnemonic+"\n"), DefaultDerivationPath)
+	require.NoError(t, err)
+	// address solana-keygen recovers for this mnemonic with the default derivation path
+	assert.Equal(t, "HAgk14JpMQLgt6rVgv7cBQFJWFto5Dqxi472uT3DKpqk", first.PublicKey().String())
+
+	again, err := ParsePrivateKey([]byte("  "+testMnemonic), DefaultDerivationPath)
+	require.NoError(t, err)
+	assert.Equal(t, first, again)
+
+	other, err := ParsePrivateKey([]byte(testMnemonic), "m/44'/501'/1'/0'")
+	require.NoError(t, err)
+	assert.NotEqual(t, first.PublicKey(), other.PublicKey())
+
+	_, err = validateKeypair(first)
+	require.NoError(t, err)
+}
+
+func TestParsePrivateKeyInvalid(t *testing.T) {
+	t.Parallel()
+
+	privateKey := solana.NewWallet().PrivateKey
+	mismatched := append(solana.PrivateKey{}, privateKey...)
+	mismatched[63] ^= 0xff
+
+	mismatchedJSON, err := json.Marshal(toInts(mismatched))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		content string
+		err     error
+	}{
+		{"mismatched public key", string(mismatchedJSON), ErrInvalidKeypair},
+		{"short keypair", "[1, 2, 3]", ErrInvalidKeypair},
+		{"byte out of range", "[256]", ErrKeypairByteOutOfRange},
+		{"invalid mnemonic checksum", "abandon abandon abandon abandon abandon abandon " +
+			"abandon abandon abandon abandon abandon abandon", ErrInvalidMnemonic},
+		{"not base58", "0OIl", ErrUnrecognizedKeyFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParsePrivateKey([]byte(tt.content), DefaultDerivationPath)
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestLoadPrivateKeyFile(t *testing.T) {
+	t.Parallel()
+
+	wallet := solana.NewWallet()
+	path := filepath.Join(t.TempDir(), "id.json")
+
+	keypairJSON, err := json.Marshal(toInts(wallet.PrivateKey))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, keypairJSON, 0o600))
+
+	privateKey, err := LoadPrivateKeyFile(path, DefaultDerivationPath)
+	require.NoError(t, err)
+	assert.Equal(t, wallet.PublicKey(), privateKey.PublicKey())
+
+	_, err = LoadPrivateKeyFile(filepath.Join(t.TempDir(), "missing.json"), DefaultDerivationPath)
+	require.Error(t, err)
+}
+
+func toInts(privateKey solana.PrivateKey) []int {
+	values := make([]int, len(privateKey))
+	for i, b := range privateKey {
+		values[i] = int(b)
+	}
+
+	return values
+}
@@ -0,0 +1,428 @@
+package solana
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	lookup "github.com/gagliardetto/solana-go/programs/address-lookup-table"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+var addressLookupTableProgramID = solana.MustPublicKeyFromBase58("AddressLookupTab1e1111111111111111111111111")
+
+var (
+	ErrLookupTableInactive     = errors.New("lookup table is deactivated")
+	ErrLookupTableAuthority    = errors.New("lookup table authority is not the payer")
+	ErrLookupTableNotActivated = errors.New("timed out waiting for lookup table to activate")
+)
+
+const (
+	// NumLookupTableTreasuryAccounts is the number of treasury accounts used when pushing with lookup tables,
+	// so that they fit in the table alongside the feed accounts.
+	NumLookupTableTreasuryAccounts = 16
+	maxLookupTableAddresses        = 256
+	// maxAddressesPerExtend keeps extend transactions well within the transaction size limit.
+	maxAddressesPerExtend        = 20
+	lookupTableActivationTimeout = 60 * time.Second
+	lookupTablePollInterval      = 500 * time.Millisecond
+	lookupTableSetupBackoff      = 5 * time.Second
+	lookupTableSetupMaxBackoff   = 5 * time.Minute
+)
+
+const (
+	lookupTableInstructionCreate     uint32 = 0
+	lookupTableInstructionExtend     uint32 = 2
+	lookupTableInstructionDeactivate uint32 = 3
+)
+
+// lookupTableInstruction is an address lookup table program instruction. solana-go only ships
+// account decoding for this program, so the instructions are encoded here.
+type lookupTableInstruction struct {
+	accounts []*solana.AccountMeta
+	data     []byte
+}
+
+func (inst *lookupTableInstruction) ProgramID() solana.PublicKey {
+	return addressLookupTableProgramID
+}
+
+func (inst *lookupTableInstruction) Accounts() []*solana.AccountMeta {
+	return inst.accounts
+}
+
+func (inst *lookupTableInstruction) Data() ([]byte, error) {
+	return inst.data, nil
+}
+
+// deriveLookupTableAddress returns the lookup table address created by the authority at the given slot.
+func deriveLookupTableAddress(authority solana.PublicKey, recentSlot uint64) (solana.PublicKey, uint8, error) {
+	slot := binary.LittleEndian.AppendUint64(nil, recentSlot)
+
+	table, bump, err := solana.FindProgramAddress([][]byte{authority[:], slot}, addressLookupTableProgramID)
+	if err != nil {
+		return solana.PublicKey{}, 0, fmt.Errorf("failed to derive lookup table address: %w", err)
+	}
+
+	return table, bump, nil
+}
+
+func newCreateLookupTableInstruction(
+	table solana.PublicKey,
+	bump uint8,
+	authority solana.PublicKey,
+	recentSlot uint64,
+) solana.Instruction {
+	data := binary.LittleEndian.AppendUint32(nil, lookupTableInstructionCreate)
+	data = binary.LittleEndian.AppendUint64(data, recentSlot)
+	data = append(data, bump)
+
+	return &lookupTableInstruction{
+		accounts: []*solana.AccountMeta{
+			solana.Meta(table).WRITE(),
+			solana.Meta(authority).SIGNER(),
+			solana.Meta(authority).WRITE().SIGNER(),
+			solana.Meta(solana.SystemProgramID),
+		},
+		data: data,
+	}
+}
+
+func newExtendLookupTableInstruction(
+	table solana.PublicKey,
+	authority solana.PublicKey,
+	addresses solana.PublicKeySlice,
+) solana.Instruction {
+	data := binary.LittleEndian.AppendUint32(nil, lookupTableInstructionExtend)
+	data = binary.LittleEndian.AppendUint64(data, uint64(len(addresses)))
+
+	for _, address := range addresses {
+		data = append(data, address[:]...)
+	}
+
+	return &lookupTableInstruction{
+		accounts: []*solana.AccountMeta{
+			solana.Meta(table).WRITE(),
+			solana.Meta(authority).SIGNER(),
+			solana.Meta(authority).WRITE().SIGNER(),
+			solana.Meta(solana.SystemProgramID),
+		},
+		data: data,
+	}
+}
+
+func newDeactivateLookupTableInstruction(table solana.PublicKey, authority solana.PublicKey) solana.Instruction {
+	return &lookupTableInstruction{
+		accounts: []*solana.AccountMeta{
+			solana.Meta(table).WRITE(),
+			solana.Meta(authority).SIGNER(),
+		},
+		data: binary.LittleEndian.AppendUint32(nil, lookupTableInstructionDeactivate),
+	}
+}
+
+// lookupTableAddresses returns the accounts referenced by update transactions that can be loaded from a lookup
+// table, in a stable order. The Stork program is not included, since invoked programs must be static keys.
+func (sci *ContractInteractor) lookupTableAddresses() solana.PublicKeySlice {
+	addresses := solana.PublicKeySlice{sci.configAccount, solana.SystemProgramID}
+
+	for i := range NumLookupTableTreasuryAccounts {
+		addresses = append(addresses, sci.treasuryAccounts[uint8(i)])
+	}
+
+	feedAccounts := make(solana.PublicKeySlice, 0, len(sci.feedAccounts))
+	for _, feedAccount := range sci.feedAccounts {
+		feedAccounts = append(feedAccounts, feedAccount)
+	}
+
+	feedAccounts.Sort()
+
+	return append(addresses, feedAccounts...)
+}
+
+// missingAddresses returns the wanted addresses that are not in any of the tables.
+func missingAddresses(
+	tables map[solana.PublicKey]solana.PublicKeySlice,
+	wanted solana.PublicKeySlice,
+) solana.PublicKeySlice {
+	present := make(map[solana.PublicKey]struct{})
+
+	for _, addresses := range tables {
+		for _, address := range addresses {
+			present[address] = struct{}{}
+		}
+	}
+
+	missing := solana.PublicKeySlice{}
+
+	for _, address := range wanted {
+		if _, ok := present[address]; !ok {
+			missing = append(missing, address)
+		}
+	}
+
+	return missing
+}
+
+// setupLookupTablesWithRetry runs setupLookupTables until it succeeds, backing off exponentially between attempts.
+// Updates are pushed with legacy transactions until then.
+func (sci *ContractInteractor) setupLookupTablesWithRetry(ctx context.Context, client *rpc.Client) {
+	backoff := lookupTableSetupBackoff
+
+	for {
+		err := sci.setupLookupTables(ctx, client)
+		if err == nil {
+			return
+		}
+
+		sci.logger.Error().Err(err).Dur("backoff", backoff).
+			Msg("Failed to set up lookup tables, pushing legacy transactions until a retry succeeds")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff = min(2*backoff, lookupTableSetupMaxBackoff)
+	}
+}
+
+// setupLookupTables loads the configured lookup tables, extends them with any accounts they are missing and
+// creates new tables once the existing ones are full. Created tables are kept with the configured ones, so that a
+// retry after a failure extends them rather than creating more.
+func (sci *ContractInteractor) setupLookupTables(ctx context.Context, client *rpc.Client) error {
+	tables := make(map[solana.PublicKey]solana.PublicKeySlice)
+	order := solana.PublicKeySlice{}
+
+	for _, table := range sci.lookupTableKeys {
+		state, err := lookup.GetAddressLookupTable(ctx, client, table)
+		if err != nil {
+			return fmt.Errorf("failed to get lookup table %s: %w", table, err)
+		}
+
+		if !state.IsActive() {
+			return fmt.Errorf("%w: %s", ErrLookupTableInactive, table)
+		}
+
+		if state.Authority == nil || !state.Authority.Equals(sci.payer.PublicKey()) {
+			return fmt.Errorf("%w: %s", ErrLookupTableAuthority, table)
+		}
+
+		tables[table] = state.Addresses
+		order = append(order, table)
+	}
+
+	missing := missingAddresses(tables, sci.lookupTableAddresses())
+
+	for len(missing) > 0 {
+		var table solana.PublicKey
+
+		if len(order) > 0 && len(tables[order[len(order)-1]]) < maxLookupTableAddresses {
+			table = order[len(order)-1]
+		} else {
+			created, err := sci.createLookupTable(ctx, client)
+			if err != nil {
+				return err
+			}
+
+			sci.lookupTableKeys = append(sci.lookupTableKeys, created)
+
+			sci.logger.Info().
+				Str("lookupTable", created.String()).
+				Msg("Created lookup table, pass it with --lookup-tables on restart to reuse it")
+
+			table = created
+			order = append(order, table)
+		}
+
+		count := min(len(missing), maxLookupTableAddresses-len(tables[table]))
+
+		err := sci.extendLookupTable(ctx, client, table, missing[:count])
+		if err != nil {
+			return err
+		}
+
+		tables[table] = append(tables[table], missing[:count]...)
+		missing = missing[count:]
+	}
+
+	for _, table := range order {
+		sci.logger.Info().
+			Str("lookupTable", table.String()).
+			Int("numAddresses", len(tables[table])).
+			Msg("Using lookup table")
+	}
+
+	sci.lookupTablesMu.Lock()
+	sci.lookupTables = tables
+	sci.lookupTablesMu.Unlock()
+
+	return nil
+}
+
+func (sci *ContractInteractor) createLookupTable(ctx context.Context, client *rpc.Client) (solana.PublicKey, error) {
+	recentSlot, err := client.GetSlot(ctx, rpc.CommitmentFinalized)
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("failed to get recent slot: %w", err)
+	}
+
+	authority := sci.payer.PublicKey()
+
+	table, bump, err := deriveLookupTableAddress(authority, recentSlot)
+	if err != nil {
+		return solana.PublicKey{}, err
+	}
+
+	_, err = sendPayerTransaction(ctx, client, sci.payer, []solana.Instruction{
+		newCreateLookupTableInstruction(table, bump, authority, recentSlot),
+	})
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("failed to create lookup table: %w", err)
+	}
+
+	err = waitForLookupTable(ctx, client, table, 0)
+	if err != nil {
+		return solana.PublicKey{}, err
+	}
+
+	return table, nil
+}
+
+// extendLookupTable appends the addresses to the table and waits until they can be used.
+func (sci *ContractInteractor) extendLookupTable(
+	ctx context.Context,
+	client *rpc.Client,
+	table solana.PublicKey,
+	addresses solana.PublicKeySlice,
+) error {
+	state, err := lookup.GetAddressLookupTable(ctx, client, table)
+	if err != nil {
+		return fmt.Errorf("failed to get lookup table %s: %w", table, err)
+	}
+
+	for start := 0; start < len(addresses); start += maxAddressesPerExtend {
+		chunk := addresses[start:min(start+maxAddressesPerExtend, len(addresses))]
+
+		_, err = sendPayerTransaction(ctx, client, sci.payer, []solana.Instruction{
+			newExtendLookupTableInstruction(table, sci.payer.PublicKey(), chunk),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to extend lookup table %s: %w", table, err)
+		}
+	}
+
+	return waitForLookupTable(ctx, client, table, len(state.Addresses)+len(addresses))
+}
+
+// waitForLookupTable waits until the table holds at least numAddresses addresses and the slot it was last
+// extended in has passed, since new addresses can only be used from the next slot.
+func waitForLookupTable(ctx context.Context, client *rpc.Client, table solana.PublicKey, numAddresses int) error {
+	ctx, cancel := context.WithTimeout(ctx, lookupTableActivationTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(lookupTablePollInterval)
+	defer ticker.Stop()
+
+	for {
+		state, err := lookup.GetAddressLookupTableStateWithOpts(ctx, client, table, &rpc.GetAccountInfoOpts{
+			Commitment: rpc.CommitmentConfirmed,
+		})
+		if err == nil && len(state.Addresses) >= numAddresses {
+			slot, err := client.GetSlot(ctx, rpc.CommitmentConfirmed)
+			if err == nil && slot > state.LastExtendedSlot {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %s", ErrLookupTableNotActivated, table)
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeactivateLookupTables deactivates lookup tables owned by the authority. Deactivated tables can no longer be
+// extended or used in new transactions, and can be closed to reclaim rent once the deactivation cooldown has passed.
+func DeactivateLookupTables(
+	ctx context.Context,
+	client *rpc.Client,
+	authority solana.PrivateKey,
+	tables solana.PublicKeySlice,
+) (solana.Signature, error) {
+	instructions := make([]solana.Instruction, 0, len(tables))
+	for _, table := range tables {
+		instructions = append(instructions, newDeactivateLookupTableInstruction(table, authority.PublicKey()))
+	}
+
+	sig, err := sendPayerTransaction(ctx, client, authority, instructions)
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("failed to deactivate lookup tables: %w", err)
+	}
+
+	return sig, nil
+}
+
+// sendPayerTransaction sends a legacy transaction signed only by the payer.
+func sendPayerTransaction(
+	ctx context.Context,
+	client *rpc.Client,
+	payer solana.PrivateKey,
+	instructions []solana.Instruction,
+) (solana.Signature, error) {
+	recentBlockHash, err := client.GetLatestBlockhash(ctx, rpc.CommitmentFinalized)
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("failed to get recent blockhash: %w", err)
+	}
+
+	tx, err := solana.NewTransaction(
+		instructions,
+		recentBlockHash.Value.Blockhash,
+		solana.TransactionPayer(payer.PublicKey()),
+	)
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	_, err = tx.Sign(func(key solana.PublicKey) *solana.PrivateKey {
+		if key == payer.PublicKey() {
+			return &payer
+		}
+
+		return nil
+	})
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	sig, err := client.SendTransaction(ctx, tx)
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("failed to send transaction: %w", err)
+	}
+
+	return sig, nil
+}
+
+// transactionSize returns the serialized size of the transaction once it carries all required signatures.
+func transactionSize(tx *solana.Transaction) (int, error) {
+	message, err := tx.Message.MarshalBinary()
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode transaction message: %w", err)
+	}
+
+	numSignatures := int(tx.Message.Header.NumRequiredSignatures)
+
+	var signatureCount []byte
+
+	err = bin.EncodeCompactU16Length(&signatureCount, numSignatures)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode signature count: %w", err)
+	}
+
+	return len(signatureCount) + numSignatures*solana.SignatureLength + len(message), nil
+}
@@ -0,0 +1,86 @@
+package solana
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupTableInstructions(t *testing.T) {
+	t.Parallel()
+
+	authority := solana.NewWallet().PublicKey()
+
+	table, bump, err := deriveLookupTableAddress(authority, 12345)
+	require.NoError(t, err)
+
+	create := newCreateLookupTableInstruction(table, bump, authority, 12345)
+	assert.Equal(t, addressLookupTableProgramID, create.ProgramID())
+
+	data, err := create.Data()
+	require.NoError(t, err)
+	assert.Equal(t, lookupTableInstructionCreate, binary.LittleEndian.Uint32(data))
+	assert.Equal(t, uint64(12345), binary.LittleEndian.Uint64(data[4:]))
+	assert.Equal(t, bump, data[12])
+	assert.Equal(t, table, create.Accounts()[0].PublicKey)
+
+	addresses := solana.PublicKeySlice{solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()}
+	extend := newExtendLookupTableInstruction(table, authority, addresses)
+
+	data, err = extend.Data()
+	require.NoError(t, err)
+	assert.Equal(t, lookupTableInstructionExtend, binary.LittleEndian.Uint32(data))
+	assert.Equal(t, uint64(2), binary.LittleEndian.Uint64(data[4:]))
+	assert.Equal(t, addresses[1][:], data[12+solana.PublicKeyLength:])
+
+	deactivate := newDeactivateLookupTableInstruction(table, authority)
+
+	data, err = deactivate.Data()
+	require.NoError(t, err)
+	assert.Equal(t, lookupTableInstructionDeactivate, binary.LittleEndian.Uint32(data))
+	assert.True(t, deactivate.Accounts()[1].IsSigner)
+}
+
+func TestMissingAddresses(t *testing.T) {
+	t.Parallel()
+
+	a, b, c := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+
+	tables := map[solana.PublicKey]solana.PublicKeySlice{
+		solana.NewWallet().PublicKey(): {a},
+		solana.NewWallet().PublicKey(): {c},
+	}
+
+	assert.Equal(t, solana.PublicKeySlice{b}, missingAddresses(tables, solana.PublicKeySlice{a, b, c}))
+	assert.Empty(t, missingAddresses(tables, solana.PublicKeySlice{c}))
+	assert.Equal(t, solana.PublicKeySlice{a, b}, missingAddresses(nil, solana.PublicKeySlice{a, b}))
+}
+
+func TestTransactionSize(t *testing.T) {
+	t.Parallel()
+
+	sci, priceUpdates := newBatchingInteractor(t, 3)
+	sci.lookupTables = map[solana.PublicKey]solana.PublicKeySlice{
+		solana.NewWallet().PublicKey(): sci.lookupTableAddresses(),
+	}
+
+	instructions, _, err := sci.buildUpdateInstructions(priceUpdates, 0)
+	require.NoError(t, err)
+
+	tx, err := sci.newTransaction(instructions, solana.Hash{})
+	require.NoError(t, err)
+	assert.True(t, tx.Message.IsVersioned())
+
+	size, err := transactionSize(tx)
+	require.NoError(t, err)
+
+	_, err = tx.Sign(func(solana.PublicKey) *solana.PrivateKey { return &sci.payer })
+	require.NoError(t, err)
+
+	raw, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	assert.Len(t, raw, size)
+}
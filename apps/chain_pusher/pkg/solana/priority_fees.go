@@ -0,0 +1,263 @@
+package solana
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/gagliardetto/solana-go"
+	computebudget "github.com/gagliardetto/solana-go/programs/compute-budget"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+var (
+	ErrInvalidPriorityFeeMode       = errors.New("invalid priority fee mode, expected none, static, percentile or max")
+	ErrInvalidPriorityFeePercentile = errors.New("priority fee percentile must be between 0 and 100")
+	ErrInvalidPriorityFeeEscalation = errors.New("priority fee escalation factor must be at least 1")
+	ErrInvalidComputeUnitLimit      = errors.New("compute unit limit exceeds the maximum compute unit limit")
+	ErrMissingMaxPriorityFee        = errors.New("priority fee mode max requires a max priority fee in lamports")
+)
+
+// PriorityFeeMode selects how the compute unit price of update transactions is chosen.
+type PriorityFeeMode string
+
+const (
+	// PriorityFeeModeNone sends transactions without a compute unit price, except when escalating retries.
+	PriorityFeeModeNone PriorityFeeMode = "none"
+	// PriorityFeeModeStatic always uses the configured compute unit price.
+	PriorityFeeModeStatic PriorityFeeMode = "static"
+	// PriorityFeeModePercentile uses a percentile of the recent prioritization fees paid for the written accounts.
+	PriorityFeeModePercentile PriorityFeeMode = "percentile"
+	// PriorityFeeModeMax spends the whole max priority fee on every transaction.
+	PriorityFeeModeMax PriorityFeeMode = "max"
+)
+
+const (
+	// DefaultPriorityFeePercentile is the percentile of recent prioritization fees used in percentile mode.
+	DefaultPriorityFeePercentile = 75
+	// DefaultPriorityFeeRetries is the number of times an unconfirmed batch is resent with a higher priority fee.
+	DefaultPriorityFeeRetries = 3
+	// DefaultPriorityFeeEscalation is the factor the compute unit price is multiplied by on each retry.
+	DefaultPriorityFeeEscalation = 2.0
+)
+
+const (
+	// defaultComputeUnitsPerInstruction is the compute units the runtime allots to each instruction
+	// when the transaction does not set a compute unit limit.
+	defaultComputeUnitsPerInstruction = 200_000
+	microLamportsPerLamport           = 1_000_000
+	// minEscalatedComputeUnitPrice is the compute unit price (micro-lamports) retries escalate from
+	// when the first attempt paid no priority fee.
+	minEscalatedComputeUnitPrice = 1_000
+	percentileMax                = 100
+)
+
+// ParsePriorityFeeMode parses a priority fee mode flag value.
+func ParsePriorityFeeMode(mode string) (PriorityFeeMode, error) {
+	switch PriorityFeeMode(mode) {
+	case PriorityFeeModeNone, PriorityFeeModeStatic, PriorityFeeModePercentile, PriorityFeeModeMax:
+		return PriorityFeeMode(mode), nil
+	default:
+		return "", fmt.Errorf("%w: got %q", ErrInvalidPriorityFeeMode, mode)
+	}
+}
+
+// PriorityFeeConfig controls the ComputeBudget instructions added to update transactions.
+type PriorityFeeConfig struct {
+	// ComputeUnitLimit is the compute unit limit requested per transaction, 0 to use the runtime default.
+	ComputeUnitLimit uint32
+	Mode             PriorityFeeMode
+	// StaticMicroLamports is the compute unit price used in static mode, in micro-lamports.
+	StaticMicroLamports uint64
+	Percentile          float64
+	// MaxLamports caps the total priority fee of a transaction, 0 for no cap.
+	MaxLamports uint64
+	// MaxRetries is the number of times a batch that fails confirmation is resent with an escalated fee.
+	MaxRetries       int
+	EscalationFactor float64
+}
+
+func (c PriorityFeeConfig) Validate() error {
+	if _, err := ParsePriorityFeeMode(string(c.Mode)); err != nil {
+		return err
+	}
+
+	if c.Percentile < 0 || c.Percentile > percentileMax {
+		return ErrInvalidPriorityFeePercentile
+	}
+
+	if c.EscalationFactor < 1 {
+		return ErrInvalidPriorityFeeEscalation
+	}
+
+	if c.ComputeUnitLimit > computebudget.MAX_COMPUTE_UNIT_LIMIT {
+		return ErrInvalidComputeUnitLimit
+	}
+
+	if c.Mode == PriorityFeeModeMax && c.MaxLamports == 0 {
+		return ErrMissingMaxPriorityFee
+	}
+
+	return nil
+}
+
+// computeUnits returns the compute units a transaction with the given number of update instructions may consume.
+func (c PriorityFeeConfig) computeUnits(numInstructions int) uint64 {
+	if c.ComputeUnitLimit > 0 {
+		return uint64(c.ComputeUnitLimit)
+	}
+
+	//nolint:gosec // numInstructions is bounded by the transaction size
+	return min(uint64(numInstructions)*defaultComputeUnitsPerInstruction, computebudget.MAX_COMPUTE_UNIT_LIMIT)
+}
+
+// maxComputeUnitPrice returns the highest compute unit price that keeps the total priority fee within MaxLamports.
+func (c PriorityFeeConfig) maxComputeUnitPrice(computeUnits uint64) (uint64, bool) {
+	if c.MaxLamports == 0 || computeUnits == 0 {
+		return 0, false
+	}
+
+	if c.MaxLamports > math.MaxUint64/microLamportsPerLamport {
+		return math.MaxUint64 / computeUnits, true
+	}
+
+	return c.MaxLamports * microLamportsPerLamport / computeUnits, true
+}
+
+// percentileFee returns the given percentile of the recent prioritization fees, in micro-lamports per compute unit.
+func percentileFee(fees []rpc.PriorizationFeeResult, percentile float64) uint64 {
+	if len(fees) == 0 {
+		return 0
+	}
+
+	values := make([]uint64, len(fees))
+	for i, fee := range fees {
+		values[i] = fee.PrioritizationFee
+	}
+
+	slices.Sort(values)
+
+	index := int(math.Ceil(percentile/percentileMax*float64(len(values)))) - 1
+	index = max(0, min(index, len(values)-1))
+
+	return values[index]
+}
+
+// escalateComputeUnitPrice raises the compute unit price for the given retry attempt.
+func escalateComputeUnitPrice(price uint64, factor float64, attempt int) uint64 {
+	if attempt <= 0 {
+		return price
+	}
+
+	price = max(price, minEscalatedComputeUnitPrice)
+
+	escalated := float64(price) * math.Pow(factor, float64(attempt))
+	if escalated >= math.MaxUint64 {
+		return math.MaxUint64
+	}
+
+	return uint64(escalated)
+}
+
+// computeUnitPrice picks the compute unit price in micro-lamports for a transaction writing to the given accounts.
+func (sci *ContractInteractor) computeUnitPrice(
+	ctx context.Context,
+	accounts solana.PublicKeySlice,
+	computeUnits uint64,
+	attempt int,
+) uint64 {
+	var price uint64
+
+	maxPrice, capped := sci.priorityFees.maxComputeUnitPrice(computeUnits)
+
+	switch sci.priorityFees.Mode {
+	case PriorityFeeModeStatic:
+		price = sci.priorityFees.StaticMicroLamports
+	case PriorityFeeModePercentile:
+		fees, err := sci.client.GetRecentPrioritizationFees(ctx, accounts)
+		if err != nil {
+			sci.logger.Warn().Err(err).Msg("Failed to get recent prioritization fees, using static priority fee")
+
+			price = sci.priorityFees.StaticMicroLamports
+
+			break
+		}
+
+		price = percentileFee(fees, sci.priorityFees.Percentile)
+	case PriorityFeeModeMax:
+		price = maxPrice
+	case PriorityFeeModeNone:
+	}
+
+	price = escalateComputeUnitPrice(price, sci.priorityFees.EscalationFactor, attempt)
+	if capped && price > maxPrice {
+		price = maxPrice
+	}
+
+	return price
+}
+
+// computeBudgetInstructions builds the ComputeBudget instructions for a transaction with the given number of
+// update instructions, returning them along with the chosen compute unit price.
+func (sci *ContractInteractor) computeBudgetInstructions(
+	ctx context.Context,
+	accounts solana.PublicKeySlice,
+	numInstructions int,
+	attempt int,
+) ([]solana.Instruction, uint64, error) {
+	instructions := []solana.Instruction{}
+
+	if sci.priorityFees.ComputeUnitLimit > 0 {
+		instruction, err := computebudget.NewSetComputeUnitLimitInstruction(
+			sci.priorityFees.ComputeUnitLimit,
+		).ValidateAndBuild()
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to build compute unit limit instruction: %w", err)
+		}
+
+		instructions = append(instructions, instruction)
+	}
+
+	price := sci.computeUnitPrice(ctx, accounts, sci.priorityFees.computeUnits(numInstructions), attempt)
+	if price > 0 {
+		instruction, err := computebudget.NewSetComputeUnitPriceInstruction(price).ValidateAndBuild()
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to build compute unit price instruction: %w", err)
+		}
+
+		instructions = append(instructions, instruction)
+	}
+
+	return instructions, price, nil
+}
+
+// placeholderComputeBudgetInstructions returns compute budget instructions of the same size as the ones update
+// transactions may carry, so that batches can be sized without querying prioritization fees.
+func (sci *ContractInteractor) placeholderComputeBudgetInstructions() ([]solana.Instruction, error) {
+	instructions := []solana.Instruction{}
+
+	if sci.priorityFees.ComputeUnitLimit > 0 {
+		instruction, err := computebudget.NewSetComputeUnitLimitInstruction(
+			sci.priorityFees.ComputeUnitLimit,
+		).ValidateAndBuild()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build compute unit limit instruction: %w", err)
+		}
+
+		instructions = append(instructions, instruction)
+	}
+
+	// a compute unit price is set whenever a fee mode is configured, and on every retry
+	if sci.priorityFees.Mode != PriorityFeeModeNone || sci.priorityFees.MaxRetries > 0 {
+		instruction, err := computebudget.NewSetComputeUnitPriceInstruction(math.MaxUint64).ValidateAndBuild()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build compute unit price instruction: %w", err)
+		}
+
+		instructions = append(instructions, instruction)
+	}
+
+	return instructions, nil
+}
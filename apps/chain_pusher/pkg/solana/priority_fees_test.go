@@ -0,0 +1,172 @@
+package solana
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePriorityFeeMode(t *testing.T) {
+	t.Parallel()
+
+	for _, mode := range []string{"none", "static", "percentile", "max"} {
+		parsed, err := ParsePriorityFeeMode(mode)
+		require.NoError(t, err)
+		assert.Equal(t, PriorityFeeMode(mode), parsed)
+	}
+
+	_, err := ParsePriorityFeeMode("dynamic")
+	require.ErrorIs(t, err, ErrInvalidPriorityFeeMode)
+}
+
+func TestPriorityFeeConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	valid := PriorityFeeConfig{
+		Mode:             PriorityFeeModePercentile,
+		Percentile:       DefaultPriorityFeePercentile,
+		EscalationFactor: DefaultPriorityFeeEscalation,
+	}
+	require.NoError(t, valid.Validate())
+
+	tests := []struct {
+		name   string
+		modify func(c *PriorityFeeConfig)
+		err    error
+	}{
+		{"unknown mode", func(c *PriorityFeeConfig) { c.Mode = "dynamic" }, ErrInvalidPriorityFeeMode},
+		{"percentile too high", func(c *PriorityFeeConfig) { c.Percentile = 101 }, ErrInvalidPriorityFeePercentile},
+		{"escalation below one", func(c *PriorityFeeConfig) { c.EscalationFactor = 0.5 }, ErrInvalidPriorityFeeEscalation},
+		{"compute unit limit too high", func(c *PriorityFeeConfig) { c.ComputeUnitLimit = 2_000_000 }, ErrInvalidComputeUnitLimit},
+		{"max mode without cap", func(c *PriorityFeeConfig) { c.Mode = PriorityFeeModeMax }, ErrMissingMaxPriorityFee},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := valid
+			tt.modify(&config)
+			require.ErrorIs(t, config.Validate(), tt.err)
+		})
+	}
+}
+
+func TestPercentileFee(t *testing.T) {
+	t.Parallel()
+
+	fees := []rpc.PriorizationFeeResult{
+		{PrioritizationFee: 500},
+		{PrioritizationFee: 0},
+		{PrioritizationFee: 100},
+		{PrioritizationFee: 10_000},
+	}
+
+	assert.Equal(t, uint64(0), percentileFee(nil, 75))
+	assert.Equal(t, uint64(0), percentileFee(fees, 0))
+	assert.Equal(t, uint64(100), percentileFee(fees, 50))
+	assert.Equal(t, uint64(500), percentileFee(fees, 75))
+	assert.Equal(t, uint64(10_000), percentileFee(fees, 100))
+}
+
+func TestEscalateComputeUnitPrice(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, uint64(0), escalateComputeUnitPrice(0, 2, 0))
+	assert.Equal(t, uint64(5_000), escalateComputeUnitPrice(5_000, 2, 0))
+	assert.Equal(t, uint64(20_000), escalateComputeUnitPrice(5_000, 2, 2))
+	assert.Equal(t, uint64(minEscalatedComputeUnitPrice*3), escalateComputeUnitPrice(0, 3, 1))
+	assert.Equal(t, uint64(math.MaxUint64), escalateComputeUnitPrice(math.MaxUint64/2, 4, 3))
+}
+
+func TestComputeUnitPrice(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		config  PriorityFeeConfig
+		attempt int
+		want    uint64
+	}{
+		{
+			name:   "none",
+			config: PriorityFeeConfig{Mode: PriorityFeeModeNone, EscalationFactor: 2},
+			want:   0,
+		},
+		{
+			name:    "none escalated on retry",
+			config:  PriorityFeeConfig{Mode: PriorityFeeModeNone, EscalationFactor: 2},
+			attempt: 1,
+			want:    minEscalatedComputeUnitPrice * 2,
+		},
+		{
+			name:   "static",
+			config: PriorityFeeConfig{Mode: PriorityFeeModeStatic, StaticMicroLamports: 7_000, EscalationFactor: 2},
+			want:   7_000,
+		},
+		{
+			// 400k compute units with a 2 lamport cap allows at most 5 micro-lamports per compute unit
+			name: "static capped",
+			config: PriorityFeeConfig{
+				Mode: PriorityFeeModeStatic, StaticMicroLamports: 7_000, MaxLamports: 2, EscalationFactor: 2,
+			},
+			want: 5,
+		},
+		{
+			name:   "max",
+			config: PriorityFeeConfig{Mode: PriorityFeeModeMax, MaxLamports: 4_000, EscalationFactor: 2},
+			want:   10_000,
+		},
+		{
+			name: "escalation stops at cap",
+			config: PriorityFeeConfig{
+				Mode: PriorityFeeModeStatic, StaticMicroLamports: 7_000, MaxLamports: 4_000, EscalationFactor: 2,
+			},
+			attempt: 3,
+			want:    10_000,
+		},
+		{
+			name: "explicit compute unit limit",
+			config: PriorityFeeConfig{
+				ComputeUnitLimit: 100_000, Mode: PriorityFeeModeMax, MaxLamports: 4_000, EscalationFactor: 2,
+			},
+			want: 40_000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sci := &ContractInteractor{priorityFees: tt.config}
+			computeUnits := tt.config.computeUnits(2)
+			assert.Equal(t, tt.want, sci.computeUnitPrice(t.Context(), nil, computeUnits, tt.attempt))
+		})
+	}
+}
+
+func TestComputeBudgetInstructions(t *testing.T) {
+	t.Parallel()
+
+	sci := &ContractInteractor{priorityFees: PriorityFeeConfig{Mode: PriorityFeeModeNone, EscalationFactor: 2}}
+
+	instructions, price, err := sci.computeBudgetInstructions(t.Context(), nil, 4, 0)
+	require.NoError(t, err)
+	assert.Empty(t, instructions)
+	assert.Equal(t, uint64(0), price)
+
+	sci.priorityFees = PriorityFeeConfig{
+		ComputeUnitLimit:    300_000,
+		Mode:                PriorityFeeModeStatic,
+		StaticMicroLamports: 1_500,
+		EscalationFactor:    2,
+	}
+
+	instructions, price, err = sci.computeBudgetInstructions(t.Context(), nil, 4, 0)
+	require.NoError(t, err)
+	assert.Len(t, instructions, 2)
+	assert.Equal(t, uint64(1_500), price)
+}
@@ -5,13 +5,14 @@ import (
 
 	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/pusher"
 	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
 	"github.com/spf13/cobra"
 )
 
 const (
 	DefaultLimitPerSecond = 40
 	DefaultBurstLimit     = 10
-	DefaultBatchSize      = 4
+	DefaultBatchSize      = 0
 )
 
 func NewPushCmd() *cobra.Command {
@@ -31,9 +32,34 @@ func NewPushCmd() *cobra.Command {
 	pushCmd.Flags().IntP(pusher.BatchingWindowFlag, "b", pusher.DefaultBatchingWindow, pusher.BatchingWindowDesc)
 	pushCmd.Flags().String(pusher.BatchingWindowStrFlag, "", pusher.BatchingWindowStrDesc)
 	pushCmd.Flags().IntP(pusher.PollingPeriodFlag, "p", pusher.DefaultPollingPeriod, pusher.PollingPeriodDesc)
+	pushCmd.Flags().Float64(pusher.MaxSpendPerHourFlag, 0, pusher.MaxSpendPerHourDesc)
+	pushCmd.Flags().Float64(pusher.MaxSpendPerDayFlag, 0, pusher.MaxSpendPerDayDesc)
+	pushCmd.Flags().String(pusher.SpendStateFileFlag, "", pusher.SpendStateFileDesc)
+	pushCmd.Flags().String(pusher.MetricsAddressFlag, "", pusher.MetricsAddressDesc)
+	pushCmd.Flags().Int(pusher.MaxAssetsPerWindowFlag, 0, pusher.MaxAssetsPerWindowDesc)
+	pushCmd.Flags().BoolP(pusher.VerifyPublishersFlag, "v", false, pusher.VerifyPublishersDesc)
 	pushCmd.Flags().IntP(pusher.LimitPerSecondFlag, "l", DefaultLimitPerSecond, pusher.LimitPerSecondDesc)
 	pushCmd.Flags().IntP(pusher.BurstLimitFlag, "r", DefaultBurstLimit, pusher.BurstLimitDesc)
 	pushCmd.Flags().IntP(pusher.BatchSizeFlag, "s", DefaultBatchSize, pusher.BatchSizeDesc)
+	pushCmd.Flags().Uint32(pusher.ComputeUnitLimitFlag, 0, pusher.ComputeUnitLimitDesc)
+	pushCmd.Flags().String(pusher.PriorityFeeModeFlag, string(PriorityFeeModeNone), pusher.PriorityFeeModeDesc)
+	pushCmd.Flags().Uint64(pusher.PriorityFeeFlag, 0, pusher.PriorityFeeDesc)
+	pushCmd.Flags().Float64(
+		pusher.PriorityFeePercentileFlag,
+		DefaultPriorityFeePercentile,
+		pusher.PriorityFeePercentileDesc,
+	)
+	pushCmd.Flags().Uint64(pusher.MaxPriorityFeeLamportsFlag, 0, pusher.MaxPriorityFeeLamportsDesc)
+	pushCmd.Flags().Int(pusher.PriorityFeeRetriesFlag, DefaultPriorityFeeRetries, pusher.PriorityFeeRetriesDesc)
+	pushCmd.Flags().Float64(
+		pusher.PriorityFeeEscalationFlag,
+		DefaultPriorityFeeEscalation,
+		pusher.PriorityFeeEscalationDesc,
+	)
+	pushCmd.Flags().Bool(pusher.UseLookupTablesFlag, false, pusher.UseLookupTablesDesc)
+	pushCmd.Flags().StringSlice(pusher.LookupTablesFlag, nil, pusher.LookupTablesDesc)
+	pushCmd.Flags().String(pusher.DerivationPathFlag, DefaultDerivationPath, pusher.DerivationPathDesc)
+	pushCmd.Flags().Bool(pusher.IncludeTreasuryBalanceFlag, false, pusher.IncludeTreasuryBalanceDesc)
 
 	pushCmd.MarkFlagsMutuallyExclusive(pusher.BatchingWindowFlag, pusher.BatchingWindowStrFlag)
 
@@ -44,6 +70,8 @@ func NewPushCmd() *cobra.Command {
 	_ = pushCmd.MarkFlagRequired(pusher.AssetConfigFileFlag)
 	_ = pushCmd.MarkFlagRequired(pusher.MnemonicFileFlag)
 
+	pushCmd.AddCommand(newDeactivateLookupTablesCmd())
+
 	return pushCmd
 }
 
@@ -58,13 +86,35 @@ func runSolanaPush(cmd *cobra.Command, args []string) {
 	batchingWindow, _ := cmd.Flags().GetInt(pusher.BatchingWindowFlag)
 	batchingWindowStr, _ := cmd.Flags().GetString(pusher.BatchingWindowStrFlag)
 	pollingPeriod, _ := cmd.Flags().GetInt(pusher.PollingPeriodFlag)
+	maxSpendPerHour, _ := cmd.Flags().GetFloat64(pusher.MaxSpendPerHourFlag)
+	maxSpendPerDay, _ := cmd.Flags().GetFloat64(pusher.MaxSpendPerDayFlag)
+	spendStateFile, _ := cmd.Flags().GetString(pusher.SpendStateFileFlag)
+	metricsAddress, _ := cmd.Flags().GetString(pusher.MetricsAddressFlag)
+	maxAssetsPerWindow, _ := cmd.Flags().GetInt(pusher.MaxAssetsPerWindowFlag)
+	verifyPublishers, _ := cmd.Flags().GetBool(pusher.VerifyPublishersFlag)
 	limitPerSecond, _ := cmd.Flags().GetInt(pusher.LimitPerSecondFlag)
 	burstLimit, _ := cmd.Flags().GetInt(pusher.BurstLimitFlag)
 	batchSize, _ := cmd.Flags().GetInt(pusher.BatchSizeFlag)
+	computeUnitLimit, _ := cmd.Flags().GetUint32(pusher.ComputeUnitLimitFlag)
+	priorityFeeModeStr, _ := cmd.Flags().GetString(pusher.PriorityFeeModeFlag)
+	priorityFee, _ := cmd.Flags().GetUint64(pusher.PriorityFeeFlag)
+	priorityFeePercentile, _ := cmd.Flags().GetFloat64(pusher.PriorityFeePercentileFlag)
+	maxPriorityFeeLamports, _ := cmd.Flags().GetUint64(pusher.MaxPriorityFeeLamportsFlag)
+	priorityFeeRetries, _ := cmd.Flags().GetInt(pusher.PriorityFeeRetriesFlag)
+	priorityFeeEscalation, _ := cmd.Flags().GetFloat64(pusher.PriorityFeeEscalationFlag)
+	useLookupTables, _ := cmd.Flags().GetBool(pusher.UseLookupTablesFlag)
+	lookupTables, _ := cmd.Flags().GetStringSlice(pusher.LookupTablesFlag)
+	derivationPath, _ := cmd.Flags().GetString(pusher.DerivationPathFlag)
+	includeTreasuryBalance, _ := cmd.Flags().GetBool(pusher.IncludeTreasuryBalanceFlag)
 
 	logger := PusherLogger(chainRpcUrl, contractAddress)
 
-	payer, err := solana.PrivateKeyFromSolanaKeygenFile(privateKeyFile)
+	priorityFeeMode, err := ParsePriorityFeeMode(priorityFeeModeStr)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Invalid priority fee mode")
+	}
+
+	payer, err := LoadPrivateKeyFile(privateKeyFile, derivationPath)
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Failed to parse private key")
 	}
@@ -79,11 +129,30 @@ func runSolanaPush(cmd *cobra.Command, args []string) {
 		limitPerSecond,
 		burstLimit,
 		batchSize,
+		PriorityFeeConfig{
+			ComputeUnitLimit:    computeUnitLimit,
+			Mode:                priorityFeeMode,
+			StaticMicroLamports: priorityFee,
+			Percentile:          priorityFeePercentile,
+			MaxLamports:         maxPriorityFeeLamports,
+			MaxRetries:          priorityFeeRetries,
+			EscalationFactor:    priorityFeeEscalation,
+		},
+		useLookupTables,
+		lookupTables,
+		includeTreasuryBalance,
 	)
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Failed to initialize contract interactor")
 	}
 
+	pusher.StartMetricsServer(metricsAddress, logger)
+
+	budget, err := pusher.NewSpendBudget(maxSpendPerHour, maxSpendPerDay, spendStateFile, logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to initialize spend budget")
+	}
+
 	pusher := pusher.NewPusher(
 		storkWsEndpoint,
 		storkAuth,
@@ -94,8 +163,62 @@ func runSolanaPush(cmd *cobra.Command, args []string) {
 		batchingWindowStr,
 		batchingWindow,
 		pollingPeriod,
+		verifyPublishers,
+		maxAssetsPerWindow,
 		interactor,
+		budget,
 		&logger,
 	)
 	pusher.Run(context.Background())
 }
+
+func newDeactivateLookupTablesCmd() *cobra.Command {
+	deactivateCmd := &cobra.Command{
+		Use:   "deactivate-lookup-tables",
+		Short: "Deactivate address lookup tables created by the Solana pusher",
+		Run:   runDeactivateLookupTables,
+	}
+
+	deactivateCmd.Flags().StringP(pusher.ChainRpcUrlFlag, "c", "", pusher.ChainRpcUrlDesc)
+	deactivateCmd.Flags().StringP(pusher.PrivateKeyFileFlag, "k", "", pusher.PrivateKeyFileDesc)
+	deactivateCmd.Flags().StringSlice(pusher.LookupTablesFlag, nil, pusher.LookupTablesDesc)
+	deactivateCmd.Flags().String(pusher.DerivationPathFlag, DefaultDerivationPath, pusher.DerivationPathDesc)
+
+	_ = deactivateCmd.MarkFlagRequired(pusher.ChainRpcUrlFlag)
+	_ = deactivateCmd.MarkFlagRequired(pusher.PrivateKeyFileFlag)
+	_ = deactivateCmd.MarkFlagRequired(pusher.LookupTablesFlag)
+
+	return deactivateCmd
+}
+
+func runDeactivateLookupTables(cmd *cobra.Command, args []string) {
+	chainRpcUrl, _ := cmd.Flags().GetString(pusher.ChainRpcUrlFlag)
+	privateKeyFile, _ := cmd.Flags().GetString(pusher.PrivateKeyFileFlag)
+	lookupTables, _ := cmd.Flags().GetStringSlice(pusher.LookupTablesFlag)
+	derivationPath, _ := cmd.Flags().GetString(pusher.DerivationPathFlag)
+
+	logger := pusher.AppLogger("solana").With().Str("chainRpcUrl", chainRpcUrl).Logger()
+
+	authority, err := LoadPrivateKeyFile(privateKeyFile, derivationPath)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to parse private key")
+	}
+
+	tables := make(solana.PublicKeySlice, 0, len(lookupTables))
+
+	for _, address := range lookupTables {
+		table, err := solana.PublicKeyFromBase58(address)
+		if err != nil {
+			logger.Fatal().Err(err).Str("lookupTable", address).Msg("Invalid lookup table address")
+		}
+
+		tables = append(tables, table)
+	}
+
+	sig, err := DeactivateLookupTables(cmd.Context(), rpc.New(chainRpcUrl), authority, tables)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to deactivate lookup tables")
+	}
+
+	logger.Info().Str("signature", sig.String()).Msg("Sent lookup table deactivation")
+}
@@ -0,0 +1,209 @@
+package bindings
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/NethermindEth/juno/core/crypto"
+	"github.com/NethermindEth/juno/core/felt"
+	starkcurve "github.com/consensys/gnark-crypto/ecc/stark-curve"
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/ecdsa"
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fr"
+)
+
+var (
+	ErrInvalidPrivateKey = errors.New("invalid starknet private key")
+	ErrInvalidAddress    = errors.New("invalid starknet address")
+)
+
+const (
+	// transactionVersion3 is the invoke version paying fees in STRK with per resource bounds.
+	transactionVersion3 = 3
+	// dataAvailabilityModeL1 is the only data availability mode accepted for now.
+	dataAvailabilityModeL1 = "L1"
+	// resourceNameShift and maxAmountShift position the fields of a resource bound within its felt.
+	resourceNameShift = 192
+	maxAmountShift    = 128
+)
+
+var (
+	invokePrefix = new(felt.Felt).SetBytes([]byte("invoke"))
+	// queryVersionOffset is added to the version of transactions that are only simulated, so that their signature
+	// can never be replayed on chain.
+	queryVersionOffset = new(big.Int).Lsh(big.NewInt(1), 128)
+
+	l1GasName     = new(big.Int).SetBytes([]byte("L1_GAS"))
+	l2GasName     = new(big.Int).SetBytes([]byte("L2_GAS"))
+	l1DataGasName = new(big.Int).SetBytes([]byte("L1_DATA"))
+)
+
+// Call is a single contract call executed by the account.
+type Call struct {
+	To       *felt.Felt
+	Selector *felt.Felt
+	Calldata []*felt.Felt
+}
+
+// ResourceBound is the maximum amount of a resource a transaction may use and the maximum price paid per unit.
+type ResourceBound struct {
+	MaxAmount       uint64
+	MaxPricePerUnit *big.Int
+}
+
+// ResourceBounds are the bounds for every resource a v3 transaction pays for.
+type ResourceBounds struct {
+	L1Gas     ResourceBound
+	L1DataGas ResourceBound
+	L2Gas     ResourceBound
+}
+
+// Account is a deployed Starknet account contract whose signer is a single Stark key, as used by the
+// OpenZeppelin and Argent accounts.
+type Account struct {
+	Address    *felt.Felt
+	privateKey *ecdsa.PrivateKey
+}
+
+// NewAccount creates an account from its address and the hex encoded Stark private key of its signer.
+func NewAccount(address string, privateKeyHex string) (*Account, error) {
+	addressFelt, err := new(felt.Felt).SetString(strings.TrimSpace(address))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidAddress, err)
+	}
+
+	privateKey, err := parsePrivateKey(privateKeyHex)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Account{Address: addressFelt, privateKey: privateKey}, nil
+}
+
+// PublicKey returns the x coordinate of the signer's public key, which is what account contracts store.
+func (a *Account) PublicKey() *felt.Felt {
+	return felt.NewFelt(&a.privateKey.PublicKey.A.X)
+}
+
+// sign signs a transaction hash, returning the [r, s] signature expected by account contracts.
+func (a *Account) sign(hash *felt.Felt) ([]*felt.Felt, error) {
+	hashBytes := hash.Bytes()
+
+	sigBytes, err := a.privateKey.Sign(hashBytes[:], nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction hash: %w", err)
+	}
+
+	var sig ecdsa.Signature
+
+	_, err = sig.SetBytes(sigBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	return []*felt.Felt{new(felt.Felt).SetBytes(sig.R[:]), new(felt.Felt).SetBytes(sig.S[:])}, nil
+}
+
+// executeCalldata encodes calls for the account's __execute__ entrypoint, in the Cairo 1 account format.
+func executeCalldata(calls []Call) []*felt.Felt {
+	calldata := []*felt.Felt{new(felt.Felt).SetUint64(uint64(len(calls)))}
+
+	for _, call := range calls {
+		calldata = append(calldata, call.To, call.Selector, new(felt.Felt).SetUint64(uint64(len(call.Calldata))))
+		calldata = append(calldata, call.Calldata...)
+	}
+
+	return calldata
+}
+
+// invokeV3Hash computes the hash of a v3 invoke transaction without paymaster or account deployment data.
+func invokeV3Hash(
+	version *felt.Felt,
+	senderAddress *felt.Felt,
+	calldata []*felt.Felt,
+	nonce *felt.Felt,
+	bounds ResourceBounds,
+	chainID *felt.Felt,
+) *felt.Felt {
+	return invokeV3HashWithResources(version, senderAddress, calldata, nonce, bounds.tipAndResourcesHash(), chainID)
+}
+
+// invokeV3HashWithResources computes the hash of a v3 invoke transaction from the hash of its tip and resource
+// bounds, which covers L1 data gas only since Starknet 0.13.4.
+func invokeV3HashWithResources(
+	version *felt.Felt,
+	senderAddress *felt.Felt,
+	calldata []*felt.Felt,
+	nonce *felt.Felt,
+	tipAndResourcesHash *felt.Felt,
+	chainID *felt.Felt,
+) *felt.Felt {
+	return crypto.PoseidonArray(
+		invokePrefix,
+		version,
+		senderAddress,
+		tipAndResourcesHash,
+		crypto.PoseidonArray(),
+		chainID,
+		nonce,
+		// nonce and fee data availability modes packed as nonce << 32 | fee, both L1 which is 0
+		new(felt.Felt),
+		crypto.PoseidonArray(),
+		crypto.PoseidonArray(calldata...),
+	)
+}
+
+// tipAndResourcesHash hashes a zero tip followed by the L1 gas, L2 gas and L1 data gas bounds.
+func (b ResourceBounds) tipAndResourcesHash() *felt.Felt {
+	return crypto.PoseidonArray(
+		new(felt.Felt),
+		encodeResourceBound(l1GasName, b.L1Gas),
+		encodeResourceBound(l2GasName, b.L2Gas),
+		encodeResourceBound(l1DataGasName, b.L1DataGas),
+	)
+}
+
+// encodeResourceBound packs a resource bound as name (60 bits) | max amount (64 bits) | max price (128 bits).
+func encodeResourceBound(name *big.Int, bound ResourceBound) *felt.Felt {
+	encoded := new(big.Int).Lsh(name, resourceNameShift)
+	encoded.Or(encoded, new(big.Int).Lsh(new(big.Int).SetUint64(bound.MaxAmount), maxAmountShift))
+	encoded.Or(encoded, bound.MaxPricePerUnit)
+
+	return new(felt.Felt).SetBigInt(encoded)
+}
+
+func transactionVersion(query bool) *felt.Felt {
+	version := big.NewInt(transactionVersion3)
+	if query {
+		version.Add(version, queryVersionOffset)
+	}
+
+	return new(felt.Felt).SetBigInt(version)
+}
+
+func parsePrivateKey(privateKeyHex string) (*ecdsa.PrivateKey, error) {
+	scalar, ok := new(big.Int).SetString(strings.TrimPrefix(strings.TrimSpace(privateKeyHex), "0x"), 16)
+	if !ok || scalar.Sign() <= 0 || scalar.Cmp(fr.Modulus()) >= 0 {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	var publicKey starkcurve.G1Affine
+	publicKey.ScalarMultiplicationBase(scalar)
+
+	publicKeyBytes := publicKey.Bytes()
+
+	// gnark only exposes the scalar through its public key || scalar encoding
+	buf := make([]byte, 0, len(publicKeyBytes)+fr.Bytes)
+	buf = append(buf, publicKeyBytes[:]...)
+	buf = append(buf, scalar.FillBytes(make([]byte, fr.Bytes))...)
+
+	var privateKey ecdsa.PrivateKey
+
+	_, err := privateKey.SetBytes(buf)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPrivateKey, err)
+	}
+
+	return &privateKey, nil
+}
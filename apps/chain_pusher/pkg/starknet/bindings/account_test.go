@@ -0,0 +1,183 @@
+package bindings
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/NethermindEth/juno/core/crypto"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testAccountAddress = "0x0123"
+	// the generator's x coordinate, which is the public key of private key 1
+	starkGeneratorX = "0x1ef15c18599971b7beced415a40f0c7deacfd9b0d1819e03d723d8bc943cfca"
+)
+
+func TestNewAccount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		privateKey string
+		wantError  bool
+	}{
+		{name: "hex with prefix", privateKey: "0x1"},
+		{name: "hex without prefix and whitespace", privateKey: " 1\n"},
+		{name: "zero", privateKey: "0x0", wantError: true},
+		{name: "not hex", privateKey: "0xzz", wantError: true},
+		{
+			name:       "larger than the curve order",
+			privateKey: "0x0800000000000011000000000000000000000000000000000000000000000001",
+			wantError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			account, err := NewAccount(testAccountAddress, tt.privateKey)
+			if tt.wantError {
+				require.ErrorIs(t, err, ErrInvalidPrivateKey)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, starkGeneratorX, account.PublicKey().String())
+			assert.Equal(t, "0x123", account.Address.String())
+		})
+	}
+}
+
+func TestAccountSign(t *testing.T) {
+	t.Parallel()
+
+	account, err := NewAccount(testAccountAddress, "0x2dccce1da22003777062ee0870e9881b460a8b7eca276870f57c601f182136c")
+	require.NoError(t, err)
+
+	hash := crypto.PoseidonArray(new(felt.Felt).SetUint64(1), new(felt.Felt).SetUint64(2))
+
+	signature, err := account.sign(hash)
+	require.NoError(t, err)
+	require.Len(t, signature, 2)
+
+	publicKey := crypto.NewPublicKey(account.PublicKey())
+
+	verified, err := publicKey.Verify(&crypto.Signature{R: *signature[0], S: *signature[1]}, hash)
+	require.NoError(t, err)
+	assert.True(t, verified)
+
+	otherHash := new(felt.Felt).Add(hash, new(felt.Felt).SetUint64(1))
+
+	verified, err = publicKey.Verify(&crypto.Signature{R: *signature[0], S: *signature[1]}, otherHash)
+	require.NoError(t, err)
+	assert.False(t, verified)
+}
+
+func TestExecuteCalldata(t *testing.T) {
+	t.Parallel()
+
+	to := new(felt.Felt).SetUint64(10)
+	sel := selector("transfer")
+
+	calldata := executeCalldata([]Call{
+		{To: to, Selector: sel, Calldata: []*felt.Felt{new(felt.Felt).SetUint64(7), new(felt.Felt).SetUint64(8)}},
+		{To: to, Selector: sel},
+	})
+
+	expected := []string{"0x2", "0xa", sel.String(), "0x2", "0x7", "0x8", "0xa", sel.String(), "0x0"}
+
+	actual := make([]string, len(calldata))
+	for i, f := range calldata {
+		actual[i] = f.String()
+	}
+
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, "0x83afd3f4caedc6eebf44246fe54e38c95e3179a5ec9ea81740eca5b482d12e", sel.String())
+}
+
+func TestEncodeResourceBound(t *testing.T) {
+	t.Parallel()
+
+	encoded := encodeResourceBound(l2GasName, ResourceBound{MaxAmount: 0x10, MaxPricePerUnit: big.NewInt(0x20)})
+
+	// "L2_GAS" in the top bits, then the amount and the price in the low 192 bits
+	assert.Equal(t, "0x4c325f4741530000000000000010"+"00000000000000000000000000000020", encoded.String())
+
+	encoded = encodeResourceBound(l1DataGasName, ResourceBound{MaxAmount: 0x10, MaxPricePerUnit: big.NewInt(0x20)})
+	assert.Equal(t, "0x4c315f444154410000000000000010"+"00000000000000000000000000000020", encoded.String())
+}
+
+func TestInvokeV3Hash(t *testing.T) {
+	t.Parallel()
+
+	// a v3 invoke accepted on the integration network, from before L1 data gas was added to the resource bounds:
+	// https://external.integration.starknet.io/feeder_gateway/get_transaction?transactionHash=0x49728601e0bb2f48ce506b0cbd9c0e2a9e50d95858aa41463f46386dca489fd
+	sender := feltFromHex(t, "0x3f6f3bc663aedc5285d6013cc3ffcbc4341d86ab488b8b68d297f8258793c41")
+	nonce := feltFromHex(t, "0xe97")
+	chainID := new(felt.Felt).SetBytes([]byte("SN_GOERLI"))
+
+	calldataHex := []string{
+		"0x2",
+		"0x450703c32370cf7ffff540b9352e7ee4ad583af143a361155f2b485c0c39684",
+		"0x27c3334165536f239cfd400ed956eabff55fc60de4fb56728b6a4f6b87db01c",
+		"0x0",
+		"0x4",
+		"0x4c312760dfd17a954cdd09e76aa9f149f806d88ec3e402ffaf5c4926f568a42",
+		"0x5df99ae77df976b4f0e5cf28c7dcfe09bd6e81aab787b19ac0c08e03d928cf",
+		"0x4",
+		"0x1",
+		"0x5",
+		"0x450703c32370cf7ffff540b9352e7ee4ad583af143a361155f2b485c0c39684",
+		"0x5df99ae77df976b4f0e5cf28c7dcfe09bd6e81aab787b19ac0c08e03d928cf",
+		"0x1",
+		"0x7fe4fd616c7fece1244b3616bb516562e230be8c9f29668b46ce0369d5ca829",
+		"0x287acddb27a2f9ba7f2612d72788dc96a5b30e401fc1e8072250940e024a587",
+	}
+
+	calldata := make([]*felt.Felt, len(calldataHex))
+	for i, hex := range calldataHex {
+		calldata[i] = feltFromHex(t, hex)
+	}
+
+	l1Gas := ResourceBound{MaxAmount: 0x186a0, MaxPricePerUnit: big.NewInt(0x5af3107a4000)}
+	l2Gas := ResourceBound{MaxAmount: 0, MaxPricePerUnit: big.NewInt(0)}
+	tipAndResourcesHash := crypto.PoseidonArray(
+		new(felt.Felt), encodeResourceBound(l1GasName, l1Gas), encodeResourceBound(l2GasName, l2Gas),
+	)
+
+	hash := invokeV3HashWithResources(transactionVersion(false), sender, calldata, nonce, tipAndResourcesHash, chainID)
+	assert.Equal(t, "0x49728601e0bb2f48ce506b0cbd9c0e2a9e50d95858aa41463f46386dca489fd", hash.String())
+
+	// since Starknet 0.13.4 the L1 data gas bound is hashed after the L2 gas bound
+	bounds := ResourceBounds{
+		L1Gas:     l1Gas,
+		L1DataGas: ResourceBound{MaxAmount: 3, MaxPricePerUnit: big.NewInt(4)},
+		L2Gas:     l2Gas,
+	}
+	assert.Equal(t, crypto.PoseidonArray(
+		new(felt.Felt),
+		encodeResourceBound(l1GasName, bounds.L1Gas),
+		encodeResourceBound(l2GasName, bounds.L2Gas),
+		encodeResourceBound(l1DataGasName, bounds.L1DataGas),
+	), bounds.tipAndResourcesHash())
+
+	queryHash := invokeV3Hash(transactionVersion(true), sender, calldata, nonce, bounds, chainID)
+	assert.NotEqual(t, invokeV3Hash(transactionVersion(false), sender, calldata, nonce, bounds, chainID), queryHash)
+	assert.Equal(t, "0x100000000000000000000000000000003", transactionVersion(true).String())
+	// 0x186a0 * 0x5af3107a4000 + 3 * 4
+	assert.Equal(t, "10000000000000000012", bounds.maxFee().String())
+}
+
+func feltFromHex(t *testing.T, hex string) *felt.Felt {
+	t.Helper()
+
+	value, err := new(felt.Felt).SetString(hex)
+	require.NoError(t, err)
+
+	return value
+}
@@ -0,0 +1,110 @@
+package bindings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/NethermindEth/juno/core/felt"
+)
+
+var ErrInvalidEventData = errors.New("invalid event data")
+
+const (
+	temporalNumericValueUpdateEvent = "TemporalNumericValueUpdateEvent"
+	// the event is keyed by its selector and the u256 asset id, and carries the new value as data
+	updateEventKeyLen  = 1 + feltsPerU256
+	updateEventDataLen = temporalNumericValueFelts
+)
+
+// EventCursor is the next block to read update events from.
+type EventCursor struct {
+	BlockNumber uint64 `json:"blockNumber"`
+}
+
+type TemporalNumericValueUpdateEvent struct {
+	AssetID EncodedAssetID
+	Value   TemporalNumericValue
+}
+
+// LatestBlockNumber returns the number of the latest accepted block.
+func (s *StorkContract) LatestBlockNumber(ctx context.Context) (uint64, error) {
+	blockNumber, err := s.rpc.blockNumber(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get block number: %w", err)
+	}
+
+	return blockNumber, nil
+}
+
+// QueryTemporalNumericValueUpdateEvents returns the contract's update events from the cursor's block up to and
+// including toBlock, following continuation tokens in pages of chunkSize events. Malformed events are skipped and
+// reported with ErrInvalidEventData after the rest are returned.
+func (s *StorkContract) QueryTemporalNumericValueUpdateEvents(
+	ctx context.Context,
+	cursor EventCursor,
+	toBlock uint64,
+	chunkSize int,
+) ([]TemporalNumericValueUpdateEvent, error) {
+	filter := eventFilterJSON{
+		FromBlock: blockNumberID{BlockNumber: cursor.BlockNumber},
+		ToBlock:   blockNumberID{BlockNumber: toBlock},
+		Address:   s.ContractAddress,
+		Keys:      [][]*felt.Felt{{selector(temporalNumericValueUpdateEvent)}},
+		ChunkSize: chunkSize,
+	}
+
+	var (
+		events   []TemporalNumericValueUpdateEvent
+		parseErr error
+	)
+
+	for {
+		chunk, err := s.rpc.getEvents(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get events: %w", err)
+		}
+
+		for _, emitted := range chunk.Events {
+			event, err := parseUpdateEvent(emitted)
+			if err != nil {
+				parseErr = err
+
+				continue
+			}
+
+			events = append(events, event)
+		}
+
+		if chunk.ContinuationToken == "" {
+			return events, parseErr
+		}
+
+		filter.ContinuationToken = chunk.ContinuationToken
+	}
+}
+
+func parseUpdateEvent(emitted emittedEventJSON) (TemporalNumericValueUpdateEvent, error) {
+	if len(emitted.Keys) != updateEventKeyLen || len(emitted.Data) != updateEventDataLen {
+		return TemporalNumericValueUpdateEvent{}, fmt.Errorf(
+			"%w: expected %d keys and %d data felts, got %d and %d",
+			ErrInvalidEventData, updateEventKeyLen, updateEventDataLen, len(emitted.Keys), len(emitted.Data),
+		)
+	}
+
+	id, err := decodeU256(emitted.Keys[1:])
+	if err != nil || id.BitLen() > len(EncodedAssetID{})*8 {
+		return TemporalNumericValueUpdateEvent{}, fmt.Errorf("%w: invalid asset id", ErrInvalidEventData)
+	}
+
+	value, err := decodeTemporalNumericValue(emitted.Data)
+	if err != nil {
+		return TemporalNumericValueUpdateEvent{}, fmt.Errorf("%w: %w", ErrInvalidEventData, err)
+	}
+
+	var assetID EncodedAssetID
+
+	id.FillBytes(assetID[:])
+
+	return TemporalNumericValueUpdateEvent{AssetID: assetID, Value: value}, nil
+}
@@ -0,0 +1,278 @@
+package bindings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/NethermindEth/juno/core/felt"
+)
+
+var (
+	ErrRpcRequestFailed = errors.New("starknet rpc request failed")
+	ErrRpcError         = errors.New("starknet rpc error")
+)
+
+const (
+	jsonRpcVersion = "2.0"
+	// rpcTimeout bounds calls made without a deadline on the context.
+	rpcTimeout = 30 * time.Second
+	// blockLatest is the block tag for the latest accepted block.
+	blockLatest = "latest"
+)
+
+type rpcRequest struct {
+	JsonRpc string `json:"jsonrpc"`
+	ID      uint64 `json:"id"`
+	Method  string `json:"method"`
+	Params  any    `json:"params"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *rpcErrorBody   `json:"error"`
+}
+
+type rpcErrorBody struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// rpcClient is a minimal Starknet JSON-RPC client covering the methods the pusher needs.
+type rpcClient struct {
+	url        string
+	httpClient *http.Client
+	nextID     atomic.Uint64
+}
+
+func newRpcClient(url string) *rpcClient {
+	return &rpcClient{
+		url:        url,
+		httpClient: &http.Client{Timeout: rpcTimeout},
+	}
+}
+
+type functionCall struct {
+	ContractAddress    *felt.Felt   `json:"contract_address"`
+	EntryPointSelector *felt.Felt   `json:"entry_point_selector"`
+	Calldata           []*felt.Felt `json:"calldata"`
+}
+
+type resourceBoundsJSON struct {
+	MaxAmount       string `json:"max_amount"`
+	MaxPricePerUnit string `json:"max_price_per_unit"`
+}
+
+type resourceBoundsMappingJSON struct {
+	L1Gas     resourceBoundsJSON `json:"l1_gas"`
+	L1DataGas resourceBoundsJSON `json:"l1_data_gas"`
+	L2Gas     resourceBoundsJSON `json:"l2_gas"`
+}
+
+// invokeTransactionV3JSON is the RPC representation of a v3 invoke transaction.
+type invokeTransactionV3JSON struct {
+	Type                      string                    `json:"type"`
+	SenderAddress             *felt.Felt                `json:"sender_address"`
+	Calldata                  []*felt.Felt              `json:"calldata"`
+	Version                   *felt.Felt                `json:"version"`
+	Signature                 []*felt.Felt              `json:"signature"`
+	Nonce                     *felt.Felt                `json:"nonce"`
+	ResourceBounds            resourceBoundsMappingJSON `json:"resource_bounds"`
+	Tip                       string                    `json:"tip"`
+	PaymasterData             []*felt.Felt              `json:"paymaster_data"`
+	AccountDeploymentData     []*felt.Felt              `json:"account_deployment_data"`
+	NonceDataAvailabilityMode string                    `json:"nonce_data_availability_mode"`
+	FeeDataAvailabilityMode   string                    `json:"fee_data_availability_mode"`
+}
+
+type feeEstimateJSON struct {
+	L1GasConsumed     *felt.Felt `json:"l1_gas_consumed"`
+	L1GasPrice        *felt.Felt `json:"l1_gas_price"`
+	L1DataGasConsumed *felt.Felt `json:"l1_data_gas_consumed"`
+	L1DataGasPrice    *felt.Felt `json:"l1_data_gas_price"`
+	L2GasConsumed     *felt.Felt `json:"l2_gas_consumed"`
+	L2GasPrice        *felt.Felt `json:"l2_gas_price"`
+	OverallFee        *felt.Felt `json:"overall_fee"`
+}
+
+type addInvokeTransactionResultJSON struct {
+	TransactionHash *felt.Felt `json:"transaction_hash"`
+}
+
+type eventFilterJSON struct {
+	FromBlock         any            `json:"from_block"`
+	ToBlock           any            `json:"to_block"`
+	Address           *felt.Felt     `json:"address"`
+	Keys              [][]*felt.Felt `json:"keys"`
+	ContinuationToken string         `json:"continuation_token,omitempty"`
+	ChunkSize         int            `json:"chunk_size"`
+}
+
+type blockNumberID struct {
+	BlockNumber uint64 `json:"block_number"`
+}
+
+type emittedEventJSON struct {
+	FromAddress     *felt.Felt   `json:"from_address"`
+	Keys            []*felt.Felt `json:"keys"`
+	Data            []*felt.Felt `json:"data"`
+	BlockNumber     uint64       `json:"block_number"`
+	TransactionHash *felt.Felt   `json:"transaction_hash"`
+}
+
+type eventsChunkJSON struct {
+	Events            []emittedEventJSON `json:"events"`
+	ContinuationToken string             `json:"continuation_token"`
+}
+
+func (c *rpcClient) call(ctx context.Context, method string, params any, result any) error {
+	body, err := json.Marshal(rpcRequest{
+		JsonRpc: jsonRpcVersion,
+		ID:      c.nextID.Add(1),
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s request: %w", method, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %w", method, err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrRpcRequestFailed, method, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read %s response: %w", method, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s: status %d: %s", ErrRpcRequestFailed, method, resp.StatusCode, respBody)
+	}
+
+	var rpcResp rpcResponse
+
+	err = json.Unmarshal(respBody, &rpcResp)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal %s response: %w", method, err)
+	}
+
+	if rpcResp.Error != nil {
+		return fmt.Errorf(
+			"%w: %s: code=%d message=%s data=%s",
+			ErrRpcError, method, rpcResp.Error.Code, rpcResp.Error.Message, rpcResp.Error.Data,
+		)
+	}
+
+	err = json.Unmarshal(rpcResp.Result, result)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal %s result: %w", method, err)
+	}
+
+	return nil
+}
+
+func (c *rpcClient) chainID(ctx context.Context) (*felt.Felt, error) {
+	var chainID felt.Felt
+
+	err := c.call(ctx, "starknet_chainId", []any{}, &chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &chainID, nil
+}
+
+func (c *rpcClient) blockNumber(ctx context.Context) (uint64, error) {
+	var blockNumber uint64
+
+	err := c.call(ctx, "starknet_blockNumber", []any{}, &blockNumber)
+	if err != nil {
+		return 0, err
+	}
+
+	return blockNumber, nil
+}
+
+func (c *rpcClient) callContract(ctx context.Context, call functionCall) ([]*felt.Felt, error) {
+	if call.Calldata == nil {
+		call.Calldata = []*felt.Felt{}
+	}
+
+	var result []*felt.Felt
+
+	err := c.call(ctx, "starknet_call", []any{call, blockLatest}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (c *rpcClient) getNonce(ctx context.Context, address *felt.Felt) (*felt.Felt, error) {
+	var nonce felt.Felt
+
+	err := c.call(ctx, "starknet_getNonce", []any{blockLatest, address}, &nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return &nonce, nil
+}
+
+func (c *rpcClient) estimateFee(ctx context.Context, tx invokeTransactionV3JSON) (feeEstimateJSON, error) {
+	var estimates []feeEstimateJSON
+
+	err := c.call(
+		ctx,
+		"starknet_estimateFee",
+		[]any{[]invokeTransactionV3JSON{tx}, []string{"SKIP_VALIDATE"}, blockLatest},
+		&estimates,
+	)
+	if err != nil {
+		return feeEstimateJSON{}, err
+	}
+
+	if len(estimates) != 1 {
+		return feeEstimateJSON{}, fmt.Errorf("%w: expected 1 fee estimate, got %d", ErrRpcError, len(estimates))
+	}
+
+	return estimates[0], nil
+}
+
+func (c *rpcClient) addInvokeTransaction(ctx context.Context, tx invokeTransactionV3JSON) (*felt.Felt, error) {
+	var result addInvokeTransactionResultJSON
+
+	err := c.call(ctx, "starknet_addInvokeTransaction", []any{tx}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.TransactionHash, nil
+}
+
+func (c *rpcClient) getEvents(ctx context.Context, filter eventFilterJSON) (eventsChunkJSON, error) {
+	var chunk eventsChunkJSON
+
+	err := c.call(ctx, "starknet_getEvents", []any{filter}, &chunk)
+	if err != nil {
+		return eventsChunkJSON{}, err
+	}
+
+	return chunk, nil
+}
@@ -0,0 +1,451 @@
+// Package bindings provides a minimal Starknet JSON-RPC client and hand written bindings for the Stork Starknet
+// contract, as there is no generator for Go bindings of Cairo contracts.
+package bindings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/NethermindEth/juno/core/crypto"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+)
+
+var (
+	ErrFeedNotFound        = errors.New("feed not found")
+	ErrUnexpectedResult    = errors.New("unexpected call result")
+	ErrInvalidContractData = errors.New("invalid contract data")
+	ErrInvalidNonce        = errors.New("invalid transaction nonce")
+)
+
+const (
+	getTemporalNumericValueFunction = "get_temporal_numeric_value_unchecked_v1"
+	updateTemporalNumericValuesFunc = "update_temporal_numeric_values_v1"
+	singleUpdateFeeFunction         = "single_update_fee"
+	approveFunction                 = "approve"
+	balanceOfFunction               = "balance_of"
+	feltsPerU256                    = 2
+	temporalNumericValueFelts       = 2
+	u128Bits                        = 128
+	i128Bits                        = 127
+	// invalidNonceMessage is part of the error returned when a transaction is submitted with a stale nonce.
+	invalidNonceMessage = "invalid transaction nonce"
+)
+
+// Token contracts on mainnet and Sepolia. Update fees may be charged in either, while v3 transaction fees are
+// always paid in STRK.
+const (
+	STRKTokenAddress = "0x04718f5a0fc34cc1af16a1cdee98ffb20c31f5cd61d6ab07201858f4287c938d"
+	ETHTokenAddress  = "0x049d36570d4e46f48e99674bd3fcc84644ddd6b96f7c741b1562b82f9e004dc7"
+)
+
+// EncodedAssetID is the 32 byte encoded asset id, passed to the contract as a u256.
+type EncodedAssetID [32]byte
+
+// TemporalNumericValue mirrors the contract's TemporalNumericValue { timestamp_ns: u64, quantized_value: i128 }.
+type TemporalNumericValue struct {
+	TimestampNs    uint64
+	QuantizedValue *big.Int
+}
+
+// TemporalNumericValueInput mirrors the contract's update input struct. The u256 fields are big endian byte
+// arrays, as in the EVM contract.
+type TemporalNumericValueInput struct {
+	TemporalNumericValue TemporalNumericValue
+	ID                   [32]byte
+	PublisherMerkleRoot  [32]byte
+	ValueComputeAlgHash  [32]byte
+	R                    [32]byte
+	S                    [32]byte
+	V                    uint8
+}
+
+// UpdateResult reports the submitted transaction and the maximum fee it may charge in fri.
+type UpdateResult struct {
+	TransactionHash string
+	MaxFee          *big.Int
+}
+
+// StorkContract is a client for the Stork Starknet contract, sending updates from a single account.
+type StorkContract struct {
+	ContractAddress *felt.Felt
+	FeeTokenAddress *felt.Felt
+	Account         *Account
+	// FeeMultiplier scales the estimated resource amounts and prices into the transaction's bounds.
+	FeeMultiplier float64
+
+	rpc     *rpcClient
+	chainID *felt.Felt
+
+	// txMu serializes transactions, so that the locally tracked nonce stays consistent.
+	txMu  sync.Mutex
+	nonce *felt.Felt
+}
+
+// NewStorkContract connects to the rpc and returns a client for the contract, sending transactions from account.
+func NewStorkContract(
+	ctx context.Context,
+	rpcUrl string,
+	contractAddress string,
+	feeTokenAddress string,
+	account *Account,
+	feeMultiplier float64,
+) (*StorkContract, error) {
+	contractAddressFelt, err := new(felt.Felt).SetString(contractAddress)
+	if err != nil {
+		return nil, fmt.Errorf("%w: contract address: %w", ErrInvalidAddress, err)
+	}
+
+	if feeTokenAddress == "" {
+		feeTokenAddress = STRKTokenAddress
+	}
+
+	feeTokenAddressFelt, err := new(felt.Felt).SetString(feeTokenAddress)
+	if err != nil {
+		return nil, fmt.Errorf("%w: fee token address: %w", ErrInvalidAddress, err)
+	}
+
+	rpc := newRpcClient(rpcUrl)
+
+	chainID, err := rpc.chainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain id: %w", err)
+	}
+
+	return &StorkContract{
+		ContractAddress: contractAddressFelt,
+		FeeTokenAddress: feeTokenAddressFelt,
+		Account:         account,
+		FeeMultiplier:   max(feeMultiplier, 1),
+		rpc:             rpc,
+		chainID:         chainID,
+	}, nil
+}
+
+// GetTemporalNumericValueUncheckedV1 reads the latest value of a feed without checking its staleness.
+func (s *StorkContract) GetTemporalNumericValueUncheckedV1(
+	ctx context.Context,
+	id EncodedAssetID,
+) (TemporalNumericValue, error) {
+	result, err := s.rpc.callContract(ctx, functionCall{
+		ContractAddress:    s.ContractAddress,
+		EntryPointSelector: selector(getTemporalNumericValueFunction),
+		Calldata:           encodeU256(id[:]),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), ErrFeedNotFound.Error()) {
+			return TemporalNumericValue{}, fmt.Errorf("%w: %w", ErrFeedNotFound, err)
+		}
+
+		return TemporalNumericValue{}, fmt.Errorf("failed to call %s: %w", getTemporalNumericValueFunction, err)
+	}
+
+	if len(result) != temporalNumericValueFelts {
+		return TemporalNumericValue{}, fmt.Errorf(
+			"%w: expected %d felts, got %d", ErrUnexpectedResult, temporalNumericValueFelts, len(result),
+		)
+	}
+
+	return decodeTemporalNumericValue(result)
+}
+
+// SingleUpdateFee returns the fee charged per feed update, in the fee token.
+func (s *StorkContract) SingleUpdateFee(ctx context.Context) (*big.Int, error) {
+	result, err := s.rpc.callContract(ctx, functionCall{
+		ContractAddress:    s.ContractAddress,
+		EntryPointSelector: selector(singleUpdateFeeFunction),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", singleUpdateFeeFunction, err)
+	}
+
+	return decodeU256(result)
+}
+
+// GetBalance returns the account's fee token balance.
+func (s *StorkContract) GetBalance(ctx context.Context) (*big.Int, error) {
+	result, err := s.rpc.callContract(ctx, functionCall{
+		ContractAddress:    s.FeeTokenAddress,
+		EntryPointSelector: selector(balanceOfFunction),
+		Calldata:           []*felt.Felt{s.Account.Address},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", balanceOfFunction, err)
+	}
+
+	return decodeU256(result)
+}
+
+// UpdateTemporalNumericValuesV1 submits the updates in a single invoke transaction, preceded by an approval of
+// the update fee when the contract charges one.
+func (s *StorkContract) UpdateTemporalNumericValuesV1(
+	ctx context.Context,
+	inputs []TemporalNumericValueInput,
+	fee *big.Int,
+) (UpdateResult, error) {
+	calls := make([]Call, 0, 2) //nolint:mnd // approval and update.
+
+	if fee != nil && fee.Sign() > 0 {
+		calls = append(calls, Call{
+			To:       s.FeeTokenAddress,
+			Selector: selector(approveFunction),
+			Calldata: append([]*felt.Felt{s.ContractAddress}, encodeU256(fee.FillBytes(make([]byte, 32)))...),
+		})
+	}
+
+	calls = append(calls, Call{
+		To:       s.ContractAddress,
+		Selector: selector(updateTemporalNumericValuesFunc),
+		Calldata: encodeTemporalNumericValueInputs(inputs),
+	})
+
+	return s.execute(ctx, calls)
+}
+
+// execute signs and submits an invoke transaction, resyncing the nonce if the transaction is rejected.
+func (s *StorkContract) execute(ctx context.Context, calls []Call) (UpdateResult, error) {
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	if s.nonce == nil {
+		nonce, err := s.rpc.getNonce(ctx, s.Account.Address)
+		if err != nil {
+			return UpdateResult{}, fmt.Errorf("failed to get nonce: %w", err)
+		}
+
+		s.nonce = nonce
+	}
+
+	result, err := s.submit(ctx, calls, s.nonce)
+	if err != nil {
+		// a rejected transaction does not consume the nonce, but resync in case a stale nonce was the cause
+		s.nonce = nil
+
+		if strings.Contains(strings.ToLower(err.Error()), invalidNonceMessage) {
+			return UpdateResult{}, fmt.Errorf("%w: %w", ErrInvalidNonce, err)
+		}
+
+		return UpdateResult{}, err
+	}
+
+	s.nonce = new(felt.Felt).Add(s.nonce, new(felt.Felt).SetUint64(1))
+
+	return result, nil
+}
+
+func (s *StorkContract) submit(ctx context.Context, calls []Call, nonce *felt.Felt) (UpdateResult, error) {
+	calldata := executeCalldata(calls)
+
+	// fee estimation skips validation, so the simulated transaction is left unsigned
+	estimate, err := s.rpc.estimateFee(ctx, s.invokeTransaction(
+		calldata, nonce, ResourceBounds{
+			L1Gas:     ResourceBound{MaxPricePerUnit: new(big.Int)},
+			L1DataGas: ResourceBound{MaxPricePerUnit: new(big.Int)},
+			L2Gas:     ResourceBound{MaxPricePerUnit: new(big.Int)},
+		}, true, []*felt.Felt{},
+	))
+	if err != nil {
+		return UpdateResult{}, fmt.Errorf("failed to estimate fee: %w", err)
+	}
+
+	bounds, err := s.resourceBounds(estimate)
+	if err != nil {
+		return UpdateResult{}, err
+	}
+
+	version := transactionVersion(false)
+	hash := invokeV3Hash(version, s.Account.Address, calldata, nonce, bounds, s.chainID)
+
+	signature, err := s.Account.sign(hash)
+	if err != nil {
+		return UpdateResult{}, err
+	}
+
+	txHash, err := s.rpc.addInvokeTransaction(ctx, s.invokeTransaction(calldata, nonce, bounds, false, signature))
+	if err != nil {
+		return UpdateResult{}, fmt.Errorf("failed to add invoke transaction: %w", err)
+	}
+
+	return UpdateResult{TransactionHash: txHash.String(), MaxFee: bounds.maxFee()}, nil
+}
+
+func (s *StorkContract) invokeTransaction(
+	calldata []*felt.Felt,
+	nonce *felt.Felt,
+	bounds ResourceBounds,
+	query bool,
+	signature []*felt.Felt,
+) invokeTransactionV3JSON {
+	return invokeTransactionV3JSON{
+		Type:          "INVOKE",
+		SenderAddress: s.Account.Address,
+		Calldata:      calldata,
+		Version:       transactionVersion(query),
+		Signature:     signature,
+		Nonce:         nonce,
+		ResourceBounds: resourceBoundsMappingJSON{
+			L1Gas:     bounds.L1Gas.toJSON(),
+			L1DataGas: bounds.L1DataGas.toJSON(),
+			L2Gas:     bounds.L2Gas.toJSON(),
+		},
+		Tip:                       "0x0",
+		PaymasterData:             []*felt.Felt{},
+		AccountDeploymentData:     []*felt.Felt{},
+		NonceDataAvailabilityMode: dataAvailabilityModeL1,
+		FeeDataAvailabilityMode:   dataAvailabilityModeL1,
+	}
+}
+
+// resourceBounds scales the estimate by the fee multiplier so that the transaction survives price movements
+// between estimation and inclusion.
+func (s *StorkContract) resourceBounds(estimate feeEstimateJSON) (ResourceBounds, error) {
+	var (
+		bounds ResourceBounds
+		err    error
+	)
+
+	bounds.L1Gas, err = scaleResourceBound(estimate.L1GasConsumed, estimate.L1GasPrice, s.FeeMultiplier)
+	if err != nil {
+		return ResourceBounds{}, fmt.Errorf("l1 gas: %w", err)
+	}
+
+	bounds.L1DataGas, err = scaleResourceBound(estimate.L1DataGasConsumed, estimate.L1DataGasPrice, s.FeeMultiplier)
+	if err != nil {
+		return ResourceBounds{}, fmt.Errorf("l1 data gas: %w", err)
+	}
+
+	bounds.L2Gas, err = scaleResourceBound(estimate.L2GasConsumed, estimate.L2GasPrice, s.FeeMultiplier)
+	if err != nil {
+		return ResourceBounds{}, fmt.Errorf("l2 gas: %w", err)
+	}
+
+	return bounds, nil
+}
+
+func scaleResourceBound(consumed *felt.Felt, price *felt.Felt, multiplier float64) (ResourceBound, error) {
+	if consumed == nil || price == nil {
+		return ResourceBound{}, fmt.Errorf("%w: missing fee estimate field", ErrUnexpectedResult)
+	}
+
+	amount := scale(consumed.BigInt(new(big.Int)), multiplier)
+	if !amount.IsUint64() {
+		return ResourceBound{}, fmt.Errorf("%w: resource amount %s overflows u64", ErrUnexpectedResult, amount)
+	}
+
+	maxPrice := scale(price.BigInt(new(big.Int)), multiplier)
+	if maxPrice.BitLen() > u128Bits {
+		return ResourceBound{}, fmt.Errorf("%w: resource price %s overflows u128", ErrUnexpectedResult, maxPrice)
+	}
+
+	return ResourceBound{MaxAmount: amount.Uint64(), MaxPricePerUnit: maxPrice}, nil
+}
+
+func scale(value *big.Int, multiplier float64) *big.Int {
+	scaled, _ := new(big.Float).Mul(new(big.Float).SetInt(value), big.NewFloat(multiplier)).Int(nil)
+
+	return scaled
+}
+
+func (b ResourceBound) toJSON() resourceBoundsJSON {
+	return resourceBoundsJSON{
+		MaxAmount:       "0x" + new(big.Int).SetUint64(b.MaxAmount).Text(16),
+		MaxPricePerUnit: "0x" + b.MaxPricePerUnit.Text(16),
+	}
+}
+
+// maxFee is the most the transaction can be charged under its bounds.
+func (b ResourceBounds) maxFee() *big.Int {
+	fee := new(big.Int)
+
+	for _, bound := range []ResourceBound{b.L1Gas, b.L1DataGas, b.L2Gas} {
+		fee.Add(fee, new(big.Int).Mul(new(big.Int).SetUint64(bound.MaxAmount), bound.MaxPricePerUnit))
+	}
+
+	return fee
+}
+
+// selector returns the entrypoint selector of a function or the key of an event, its starknet keccak.
+func selector(name string) *felt.Felt {
+	// StarknetKeccak only fails if writing to the hash does, which it never does
+	hash, _ := crypto.StarknetKeccak([]byte(name))
+
+	return hash
+}
+
+// encodeU256 encodes 32 big endian bytes as a Cairo u256, which is serialized as its low then high 128 bits.
+func encodeU256(b []byte) []*felt.Felt {
+	return []*felt.Felt{new(felt.Felt).SetBytes(b[16:32]), new(felt.Felt).SetBytes(b[:16])}
+}
+
+func decodeU256(result []*felt.Felt) (*big.Int, error) {
+	if len(result) != feltsPerU256 {
+		return nil, fmt.Errorf("%w: expected %d felts for u256, got %d", ErrUnexpectedResult, feltsPerU256, len(result))
+	}
+
+	value := result[1].BigInt(new(big.Int))
+	value.Lsh(value, u128Bits)
+
+	return value.Or(value, result[0].BigInt(new(big.Int))), nil
+}
+
+// minI128 is the smallest value of a Cairo i128.
+var minI128 = new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), i128Bits))
+
+// encodeI128 encodes a signed value as a felt, where negative values wrap around the field prime.
+func encodeI128(value *big.Int) *felt.Felt {
+	return new(felt.Felt).SetBigInt(value)
+}
+
+func decodeI128(value *felt.Felt) (*big.Int, error) {
+	result := value.BigInt(new(big.Int))
+	if result.BitLen() <= i128Bits {
+		return result, nil
+	}
+
+	// negative values are stored as prime - |value|
+	result.Sub(result, fp.Modulus())
+	if result.Cmp(minI128) < 0 {
+		return nil, fmt.Errorf("%w: %s is not an i128", ErrInvalidContractData, value)
+	}
+
+	return result, nil
+}
+
+func decodeTemporalNumericValue(result []*felt.Felt) (TemporalNumericValue, error) {
+	timestamp := result[0].BigInt(new(big.Int))
+	if !timestamp.IsUint64() {
+		return TemporalNumericValue{}, fmt.Errorf("%w: timestamp %s overflows u64", ErrInvalidContractData, timestamp)
+	}
+
+	quantizedValue, err := decodeI128(result[1])
+	if err != nil {
+		return TemporalNumericValue{}, err
+	}
+
+	return TemporalNumericValue{TimestampNs: timestamp.Uint64(), QuantizedValue: quantizedValue}, nil
+}
+
+// encodeTemporalNumericValueInputs serializes an Array<TemporalNumericValueInput> as its length followed by the
+// fields of each input in declaration order.
+func encodeTemporalNumericValueInputs(inputs []TemporalNumericValueInput) []*felt.Felt {
+	calldata := []*felt.Felt{new(felt.Felt).SetUint64(uint64(len(inputs)))}
+
+	for _, input := range inputs {
+		calldata = append(calldata,
+			new(felt.Felt).SetUint64(input.TemporalNumericValue.TimestampNs),
+			encodeI128(input.TemporalNumericValue.QuantizedValue),
+		)
+		calldata = append(calldata, encodeU256(input.ID[:])...)
+		calldata = append(calldata, encodeU256(input.PublisherMerkleRoot[:])...)
+		calldata = append(calldata, encodeU256(input.ValueComputeAlgHash[:])...)
+		calldata = append(calldata, encodeU256(input.R[:])...)
+		calldata = append(calldata, encodeU256(input.S[:])...)
+		calldata = append(calldata, new(felt.Felt).SetUint64(uint64(input.V)))
+	}
+
+	return calldata
+}
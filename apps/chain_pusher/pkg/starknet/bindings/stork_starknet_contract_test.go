@@ -0,0 +1,336 @@
+package bindings
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/NethermindEth/juno/core/crypto"
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testContractAddress = "0x0456"
+
+// fakeRpc answers Starknet JSON-RPC requests from per method handlers and records the requests it receives.
+type fakeRpc struct {
+	mu       sync.Mutex
+	handlers map[string]func(params json.RawMessage) (any, *rpcErrorBody)
+	requests map[string][]json.RawMessage
+}
+
+func newFakeRpc(t *testing.T, handlers map[string]func(params json.RawMessage) (any, *rpcErrorBody)) string {
+	t.Helper()
+
+	fake := &fakeRpc{handlers: handlers, requests: make(map[string][]json.RawMessage)}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     uint64          `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		fake.mu.Lock()
+		fake.requests[req.Method] = append(fake.requests[req.Method], req.Params)
+		handler, ok := fake.handlers[req.Method]
+		fake.mu.Unlock()
+
+		resp := map[string]any{"jsonrpc": "2.0", "id": req.ID}
+
+		if !ok {
+			resp["error"] = rpcErrorBody{Code: -32601, Message: "method not found"}
+		} else if result, rpcErr := handler(req.Params); rpcErr != nil {
+			resp["error"] = rpcErr
+		} else {
+			resp["result"] = result
+		}
+
+		assert.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL
+}
+
+func chainIDHandler(json.RawMessage) (any, *rpcErrorBody) {
+	return new(felt.Felt).SetBytes([]byte("SN_SEPOLIA")), nil
+}
+
+func newTestContract(
+	t *testing.T,
+	handlers map[string]func(params json.RawMessage) (any, *rpcErrorBody),
+) *StorkContract {
+	t.Helper()
+
+	handlers["starknet_chainId"] = chainIDHandler
+	url := newFakeRpc(t, handlers)
+
+	account, err := NewAccount("0x0123", "0x1")
+	require.NoError(t, err)
+
+	contract, err := NewStorkContract(context.Background(), url, testContractAddress, "", account, 2)
+	require.NoError(t, err)
+
+	return contract
+}
+
+func TestGetTemporalNumericValueUncheckedV1(t *testing.T) {
+	t.Parallel()
+
+	negative := new(felt.Felt).SetBigInt(big.NewInt(-1500))
+
+	contract := newTestContract(t, map[string]func(json.RawMessage) (any, *rpcErrorBody){
+		"starknet_call": func(params json.RawMessage) (any, *rpcErrorBody) {
+			var call []json.RawMessage
+			if err := json.Unmarshal(params, &call); err != nil || len(call) != 2 {
+				return nil, &rpcErrorBody{Code: -32602, Message: "invalid params"}
+			}
+
+			var fc functionCall
+			if err := json.Unmarshal(call[0], &fc); err != nil {
+				return nil, &rpcErrorBody{Code: -32602, Message: "invalid params"}
+			}
+
+			if fc.Calldata[0].IsZero() {
+				return nil, &rpcErrorBody{Code: 40, Message: "Contract error", Data: json.RawMessage(`"feed not found"`)}
+			}
+
+			return []*felt.Felt{new(felt.Felt).SetUint64(42), negative}, nil
+		},
+	})
+
+	var id EncodedAssetID
+	id[31] = 1
+
+	value, err := contract.GetTemporalNumericValueUncheckedV1(context.Background(), id)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), value.TimestampNs)
+	assert.Equal(t, big.NewInt(-1500), value.QuantizedValue)
+
+	_, err = contract.GetTemporalNumericValueUncheckedV1(context.Background(), EncodedAssetID{})
+	require.ErrorIs(t, err, ErrFeedNotFound)
+}
+
+func TestUpdateTemporalNumericValuesV1(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu          sync.Mutex
+		submitted   []invokeTransactionV3JSON
+		nonceReads  int
+		rejectNonce = true
+	)
+
+	feltHex := func(v uint64) string { return new(felt.Felt).SetUint64(v).String() }
+
+	contract := newTestContract(t, map[string]func(json.RawMessage) (any, *rpcErrorBody){
+		"starknet_getNonce": func(json.RawMessage) (any, *rpcErrorBody) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			nonceReads++
+
+			return new(felt.Felt).SetUint64(uint64(7 + nonceReads)), nil
+		},
+		"starknet_estimateFee": func(json.RawMessage) (any, *rpcErrorBody) {
+			return []map[string]string{{
+				"l1_gas_consumed":      feltHex(0),
+				"l1_gas_price":         feltHex(100),
+				"l1_data_gas_consumed": feltHex(128),
+				"l1_data_gas_price":    feltHex(10),
+				"l2_gas_consumed":      feltHex(1000),
+				"l2_gas_price":         feltHex(3),
+				"overall_fee":          feltHex(4280),
+			}}, nil
+		},
+		"starknet_addInvokeTransaction": func(params json.RawMessage) (any, *rpcErrorBody) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			var tx []invokeTransactionV3JSON
+			if err := json.Unmarshal(params, &tx); err != nil || len(tx) != 1 {
+				return nil, &rpcErrorBody{Code: -32602, Message: "invalid params"}
+			}
+
+			if rejectNonce {
+				rejectNonce = false
+
+				return nil, &rpcErrorBody{Code: 52, Message: "Invalid transaction nonce"}
+			}
+
+			submitted = append(submitted, tx[0])
+
+			return map[string]string{"transaction_hash": "0xabc"}, nil
+		},
+	})
+
+	inputs := []TemporalNumericValueInput{{
+		TemporalNumericValue: TemporalNumericValue{TimestampNs: 1, QuantizedValue: big.NewInt(-2)},
+		V:                    27,
+	}}
+
+	_, err := contract.UpdateTemporalNumericValuesV1(context.Background(), inputs, big.NewInt(10))
+	require.ErrorIs(t, err, ErrInvalidNonce)
+
+	result, err := contract.UpdateTemporalNumericValuesV1(context.Background(), inputs, big.NewInt(10))
+	require.NoError(t, err)
+	assert.Equal(t, "0xabc", result.TransactionHash)
+
+	_, err = contract.UpdateTemporalNumericValuesV1(context.Background(), inputs, nil)
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	// the nonce is read again after the rejection, then tracked locally
+	assert.Equal(t, 2, nonceReads)
+	require.Len(t, submitted, 2)
+	assert.Equal(t, "0x9", submitted[0].Nonce.String())
+	assert.Equal(t, "0xa", submitted[1].Nonce.String())
+
+	tx := submitted[0]
+	assert.Equal(t, "0x3", tx.Version.String())
+	assert.Equal(t, "0x0", tx.ResourceBounds.L1Gas.MaxAmount)
+	assert.Equal(t, "0x100", tx.ResourceBounds.L1DataGas.MaxAmount)
+	assert.Equal(t, "0x14", tx.ResourceBounds.L1DataGas.MaxPricePerUnit)
+	assert.Equal(t, "0x7d0", tx.ResourceBounds.L2Gas.MaxAmount)
+	assert.Equal(t, "0x6", tx.ResourceBounds.L2Gas.MaxPricePerUnit)
+
+	// an approval of the update fee precedes the update
+	assert.Equal(t, "0x2", tx.Calldata[0].String())
+	assert.Equal(t, selector(approveFunction), tx.Calldata[2])
+	assert.Equal(t, "0x1", submitted[1].Calldata[0].String())
+
+	bounds := ResourceBounds{
+		L1Gas:     ResourceBound{MaxAmount: 0, MaxPricePerUnit: big.NewInt(200)},
+		L1DataGas: ResourceBound{MaxAmount: 256, MaxPricePerUnit: big.NewInt(20)},
+		L2Gas:     ResourceBound{MaxAmount: 2000, MaxPricePerUnit: big.NewInt(6)},
+	}
+	hash := invokeV3Hash(tx.Version, tx.SenderAddress, tx.Calldata, tx.Nonce, bounds, contract.chainID)
+
+	require.Len(t, tx.Signature, 2)
+
+	publicKey := crypto.NewPublicKey(contract.Account.PublicKey())
+
+	verified, err := publicKey.Verify(&crypto.Signature{R: *tx.Signature[0], S: *tx.Signature[1]}, hash)
+	require.NoError(t, err)
+	assert.True(t, verified)
+}
+
+func TestEncodeTemporalNumericValueInputs(t *testing.T) {
+	t.Parallel()
+
+	var input TemporalNumericValueInput
+
+	input.TemporalNumericValue = TemporalNumericValue{TimestampNs: 9, QuantizedValue: big.NewInt(-1)}
+	input.ID[0] = 0xff
+	input.ID[31] = 0x01
+	input.V = 28
+
+	calldata := encodeTemporalNumericValueInputs([]TemporalNumericValueInput{input, input})
+
+	// length, then timestamp, value, five u256s and v per input
+	require.Len(t, calldata, 1+2*(2+5*feltsPerU256+1))
+	assert.Equal(t, "0x2", calldata[0].String())
+	assert.Equal(t, "0x9", calldata[1].String())
+
+	quantizedValue, err := decodeI128(calldata[2])
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(-1), quantizedValue)
+
+	assert.Equal(t, "0x1", calldata[3].String())
+	assert.Equal(t, "0xff000000000000000000000000000000", calldata[4].String())
+	assert.Equal(t, "0x1c", calldata[13].String())
+}
+
+func TestDecodeI128(t *testing.T) {
+	t.Parallel()
+
+	maxI128 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 127), big.NewInt(1))
+	minI128 := new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 127))
+
+	for _, value := range []*big.Int{big.NewInt(0), big.NewInt(5), big.NewInt(-5), maxI128, minI128} {
+		decoded, err := decodeI128(encodeI128(value))
+		require.NoError(t, err)
+		assert.Zero(t, value.Cmp(decoded), "expected %s, got %s", value, decoded)
+	}
+
+	_, err := decodeI128(new(felt.Felt).SetBigInt(new(big.Int).Lsh(big.NewInt(1), 200)))
+	require.ErrorIs(t, err, ErrInvalidContractData)
+}
+
+func TestParseUpdateEvent(t *testing.T) {
+	t.Parallel()
+
+	key := selector(temporalNumericValueUpdateEvent)
+	low := new(felt.Felt).SetUint64(2)
+	high := new(felt.Felt).SetBytes([]byte{0x01})
+
+	event, err := parseUpdateEvent(emittedEventJSON{
+		Keys: []*felt.Felt{key, low, high},
+		Data: []*felt.Felt{new(felt.Felt).SetUint64(100), new(felt.Felt).SetBigInt(big.NewInt(-3))},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, byte(0x01), event.AssetID[15])
+	assert.Equal(t, byte(0x02), event.AssetID[31])
+	assert.Equal(t, uint64(100), event.Value.TimestampNs)
+	assert.Equal(t, big.NewInt(-3), event.Value.QuantizedValue)
+
+	_, err = parseUpdateEvent(emittedEventJSON{Keys: []*felt.Felt{key}, Data: []*felt.Felt{}})
+	require.ErrorIs(t, err, ErrInvalidEventData)
+}
+
+func TestQueryTemporalNumericValueUpdateEvents(t *testing.T) {
+	t.Parallel()
+
+	key := selector(temporalNumericValueUpdateEvent)
+	event := func(timestamp uint64) emittedEventJSON {
+		return emittedEventJSON{
+			Keys: []*felt.Felt{key, new(felt.Felt).SetUint64(1), new(felt.Felt)},
+			Data: []*felt.Felt{new(felt.Felt).SetUint64(timestamp), new(felt.Felt).SetUint64(7)},
+		}
+	}
+
+	var filters []eventFilterJSON
+
+	contract := newTestContract(t, map[string]func(json.RawMessage) (any, *rpcErrorBody){
+		"starknet_getEvents": func(params json.RawMessage) (any, *rpcErrorBody) {
+			var filter []struct {
+				FromBlock         blockNumberID `json:"from_block"`
+				ToBlock           blockNumberID `json:"to_block"`
+				ContinuationToken string        `json:"continuation_token"`
+			}
+			if err := json.Unmarshal(params, &filter); err != nil || len(filter) != 1 {
+				return nil, &rpcErrorBody{Code: -32602, Message: "invalid params"}
+			}
+
+			filters = append(filters, eventFilterJSON{
+				FromBlock: filter[0].FromBlock, ToBlock: filter[0].ToBlock, ContinuationToken: filter[0].ContinuationToken,
+			})
+
+			if filter[0].ContinuationToken == "" {
+				return eventsChunkJSON{Events: []emittedEventJSON{event(1), {Keys: []*felt.Felt{key}}}, ContinuationToken: "next"}, nil
+			}
+
+			return eventsChunkJSON{Events: []emittedEventJSON{event(2)}}, nil
+		},
+	})
+
+	events, err := contract.QueryTemporalNumericValueUpdateEvents(context.Background(), EventCursor{BlockNumber: 10}, 20, 2)
+	require.ErrorIs(t, err, ErrInvalidEventData)
+	require.Len(t, events, 2)
+	assert.Equal(t, uint64(2), events[1].Value.TimestampNs)
+
+	require.Len(t, filters, 2)
+	assert.Equal(t, blockNumberID{BlockNumber: 10}, filters[0].FromBlock)
+	assert.Equal(t, blockNumberID{BlockNumber: 20}, filters[0].ToBlock)
+	assert.Equal(t, "next", filters[1].ContinuationToken)
+}
@@ -0,0 +1,156 @@
+package starknet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/pusher"
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/starknet/bindings"
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/types"
+)
+
+// DefaultEventPollInterval is how often starknet_getEvents is polled for new feed update events.
+const DefaultEventPollInterval = 5 * time.Second
+
+// eventChunkSize is the number of events requested per starknet_getEvents page.
+const eventChunkSize = 100
+
+// ListenContractEvents polls the contract's feed update events, resuming from the persisted cursor.
+// Starknet RPC websocket subscriptions are not widely served, so events are followed with block range polling.
+func (sci *ContractInteractor) ListenContractEvents(
+	ctx context.Context,
+	ch chan map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue,
+) {
+	if sci.eventPollInterval <= 0 {
+		sci.logger.Warn().Msg("Event polling disabled, relying on contract polling only")
+
+		return
+	}
+
+	cursor, err := sci.initialEventCursor(ctx)
+	if err != nil {
+		sci.logger.Error().Err(err).Msg("Failed to determine starting event cursor, relying on contract polling only")
+
+		return
+	}
+
+	sci.logger.Info().Dur("interval", sci.eventPollInterval).Msg("Polling contract feed update events")
+
+	ticker := time.NewTicker(sci.eventPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			cursor = sci.pollEvents(ctx, cursor, ch)
+		}
+	}
+}
+
+// initialEventCursor returns the persisted cursor if there is one, and otherwise the next block so that history
+// is not replayed on first start.
+func (sci *ContractInteractor) initialEventCursor(ctx context.Context) (bindings.EventCursor, error) {
+	cursor, found, err := loadEventCursor(sci.eventCursorFile)
+	if err != nil {
+		return bindings.EventCursor{}, err
+	}
+
+	if found {
+		sci.logger.Info().Str("cursorFile", sci.eventCursorFile).Msg("Resuming events from persisted cursor")
+
+		return cursor, nil
+	}
+
+	for {
+		blockNumber, err := sci.contract.LatestBlockNumber(ctx)
+		if err == nil {
+			return bindings.EventCursor{BlockNumber: blockNumber + 1}, nil
+		}
+
+		sci.logger.Warn().Err(err).Msg("Failed to get latest block number, retrying")
+
+		select {
+		case <-ctx.Done():
+			return bindings.EventCursor{}, fmt.Errorf("failed to get latest block number: %w", ctx.Err())
+		case <-time.After(sci.eventPollInterval):
+		}
+	}
+}
+
+// pollEvents forwards the events from the cursor up to the latest block to the channel and returns the cursor to
+// continue from.
+func (sci *ContractInteractor) pollEvents(
+	ctx context.Context,
+	cursor bindings.EventCursor,
+	ch chan map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue,
+) bindings.EventCursor {
+	latestBlock, err := sci.contract.LatestBlockNumber(ctx)
+	if err != nil {
+		sci.logger.Warn().Err(err).Msg("Failed to get latest block number")
+
+		return cursor
+	}
+
+	if latestBlock < cursor.BlockNumber {
+		return cursor
+	}
+
+	events, err := sci.contract.QueryTemporalNumericValueUpdateEvents(ctx, cursor, latestBlock, eventChunkSize)
+	if errors.Is(err, bindings.ErrInvalidEventData) {
+		sci.logger.Warn().Err(err).Msg("Skipped malformed feed update events")
+	} else if err != nil {
+		sci.logger.Warn().Err(err).Msg("Failed to query feed update events")
+
+		return cursor
+	}
+
+	updates := latestEventValues(events)
+	if len(updates) > 0 {
+		select {
+		case ch <- updates:
+		case <-ctx.Done():
+			return cursor
+		}
+	}
+
+	cursor = bindings.EventCursor{BlockNumber: latestBlock + 1}
+
+	err = pusher.WriteStateFile(sci.eventCursorFile, cursor)
+	if err != nil {
+		sci.logger.Warn().Err(err).Msg("Failed to persist event cursor")
+	}
+
+	return cursor
+}
+
+// latestEventValues keeps the newest value per asset from a range of events.
+func latestEventValues(
+	events []bindings.TemporalNumericValueUpdateEvent,
+) map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue {
+	updates := make(map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue)
+
+	for _, event := range events {
+		encodedAssetID := types.InternalEncodedAssetID(event.AssetID)
+
+		existing, ok := updates[encodedAssetID]
+		if ok && existing.TimestampNs >= event.Value.TimestampNs {
+			continue
+		}
+
+		updates[encodedAssetID] = temporalNumericValueToInternal(event.Value)
+	}
+
+	return updates
+}
+
+func loadEventCursor(path string) (bindings.EventCursor, bool, error) {
+	var cursor bindings.EventCursor
+
+	found, err := pusher.ReadStateFile(path, &cursor)
+
+	return cursor, found, err
+}
@@ -0,0 +1,276 @@
+package starknet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/pusher"
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/starknet/bindings"
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/types"
+	"github.com/Stork-Oracle/stork-external/shared"
+	"github.com/rs/zerolog"
+)
+
+var (
+	ErrPrivateKeyEmpty     = errors.New("private key cannot be empty")
+	ErrNilStorkSignedPrice = errors.New("stork signed price is nil")
+	ErrInvalidSignatureV   = errors.New("invalid signature V")
+)
+
+type ContractInteractor struct {
+	logger zerolog.Logger
+
+	account           *bindings.Account
+	contractAddress   string
+	feeTokenAddress   string
+	feeMultiplier     float64
+	eventPollInterval time.Duration
+	eventCursorFile   string
+
+	contract        *bindings.StorkContract
+	puller          *pusher.ConcurrentPuller
+	singleUpdateFee *big.Int
+}
+
+func NewContractInteractor(
+	contractAddress string,
+	accountAddress string,
+	keyFileContent []byte,
+	logger zerolog.Logger,
+	feeTokenAddress string,
+	feeMultiplier float64,
+	eventPollInterval time.Duration,
+	eventCursorFile string,
+	pullConcurrency int,
+	limitPerSecond int,
+	burstLimit int,
+) (*ContractInteractor, error) {
+	logger = logger.With().Str("component", "starknet-contract-interactor").Logger()
+
+	privateKey, err := loadPrivateKey(keyFileContent)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := bindings.NewAccount(accountAddress, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load account: %w", err)
+	}
+
+	return &ContractInteractor{
+		logger:            logger,
+		account:           account,
+		contractAddress:   contractAddress,
+		feeTokenAddress:   feeTokenAddress,
+		feeMultiplier:     feeMultiplier,
+		eventPollInterval: eventPollInterval,
+		eventCursorFile:   eventCursorFile,
+		contract:          nil,
+		puller:            pusher.NewConcurrentPuller(pullConcurrency, limitPerSecond, burstLimit),
+		singleUpdateFee:   nil,
+	}, nil
+}
+
+func (sci *ContractInteractor) ConnectHTTP(ctx context.Context, url string) error {
+	contract, err := bindings.NewStorkContract(
+		ctx, url, sci.contractAddress, sci.feeTokenAddress, sci.account, sci.feeMultiplier,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create stork contract: %w", err)
+	}
+
+	sci.contract = contract
+
+	return nil
+}
+
+func (sci *ContractInteractor) ConnectWs(ctx context.Context, url string) error {
+	// not implemented
+	return nil
+}
+
+func (sci *ContractInteractor) PullValues(
+	ctx context.Context,
+	encodedAssetIDs []types.InternalEncodedAssetID,
+) (map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue, error) {
+	polledVals, err := sci.puller.Pull(ctx, encodedAssetIDs, sci.pullValue)
+
+	sci.logger.Debug().Msgf("Pulled %d values from contract", len(polledVals))
+
+	if err != nil {
+		return polledVals, fmt.Errorf("failed to pull values: %w", err)
+	}
+
+	return polledVals, nil
+}
+
+// pullValue reads a single feed, as the contract has no function for reading multiple feeds.
+func (sci *ContractInteractor) pullValue(
+	ctx context.Context,
+	encodedAssetID types.InternalEncodedAssetID,
+) (types.InternalTemporalNumericValue, error) {
+	value, err := sci.contract.GetTemporalNumericValueUncheckedV1(ctx, bindings.EncodedAssetID(encodedAssetID))
+	if err != nil {
+		if errors.Is(err, bindings.ErrFeedNotFound) {
+			return types.InternalTemporalNumericValue{}, fmt.Errorf("%w: %w", pusher.ErrFeedNotFound, err)
+		}
+
+		sci.logger.Warn().Err(err).Msg("Failed to get temporal numeric value")
+
+		return types.InternalTemporalNumericValue{}, fmt.Errorf("failed to get temporal numeric value: %w", err)
+	}
+
+	return temporalNumericValueToInternal(value), nil
+}
+
+func (sci *ContractInteractor) BatchPushToContract(
+	ctx context.Context,
+	priceUpdates map[types.InternalEncodedAssetID]types.AggregatedSignedPrice,
+) error {
+	if len(priceUpdates) == 0 {
+		return nil
+	}
+
+	inputs := make([]bindings.TemporalNumericValueInput, 0, len(priceUpdates))
+
+	for _, update := range priceUpdates {
+		input, err := aggregatedSignedPriceToTemporalNumericValueInput(update)
+		if err != nil {
+			return err
+		}
+
+		inputs = append(inputs, input)
+	}
+
+	fee, err := sci.getUpdateFee(ctx, len(inputs))
+	if err != nil {
+		return err
+	}
+
+	result, err := sci.contract.UpdateTemporalNumericValuesV1(ctx, inputs, fee)
+	if err != nil {
+		sci.logger.Error().Err(err).Msg("Failed to update temporal numeric values")
+
+		// the owner may have raised the fee, in which case the approved amount is too small until it is re-read
+		sci.singleUpdateFee = nil
+
+		return fmt.Errorf("failed to update temporal numeric values: %w", err)
+	}
+
+	sci.logger.Debug().
+		Int("numUpdates", len(inputs)).
+		Str("txnHash", result.TransactionHash).
+		Str("maxFeeFri", result.MaxFee.String()).
+		Str("updateFee", fee.String()).
+		Msg("Successfully pushed batch update to contract")
+
+	return nil
+}
+
+// getUpdateFee returns the fee charged for numUpdates updates, reading the single update fee on first use and
+// after a failed push.
+func (sci *ContractInteractor) getUpdateFee(ctx context.Context, numUpdates int) (*big.Int, error) {
+	if sci.singleUpdateFee == nil {
+		singleUpdateFee, err := sci.contract.SingleUpdateFee(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get single update fee: %w", err)
+		}
+
+		sci.singleUpdateFee = singleUpdateFee
+	}
+
+	return new(big.Int).Mul(sci.singleUpdateFee, big.NewInt(int64(numUpdates))), nil
+}
+
+// GetWalletBalance returns the account's balance of the fee token, in its smallest unit (fri for STRK, wei for
+// ETH).
+func (sci *ContractInteractor) GetWalletBalance(ctx context.Context) (float64, error) {
+	balance, err := sci.contract.GetBalance(ctx)
+	if err != nil {
+		return -1, fmt.Errorf("failed to get wallet balance: %w", err)
+	}
+
+	balanceFloat, _ := new(big.Float).SetInt(balance).Float64()
+
+	return balanceFloat, nil
+}
+
+func temporalNumericValueToInternal(value bindings.TemporalNumericValue) types.InternalTemporalNumericValue {
+	return types.InternalTemporalNumericValue{
+		TimestampNs:    value.TimestampNs,
+		QuantizedValue: value.QuantizedValue,
+	}
+}
+
+func aggregatedSignedPriceToTemporalNumericValueInput(
+	price types.AggregatedSignedPrice,
+) (bindings.TemporalNumericValueInput, error) {
+	signedPrice := price.StorkSignedPrice
+	if signedPrice == nil {
+		return bindings.TemporalNumericValueInput{}, fmt.Errorf("%w: %s", ErrNilStorkSignedPrice, price.AssetID)
+	}
+
+	//nolint:mnd // base number.
+	quantizedValue, ok := new(big.Int).SetString(string(signedPrice.QuantizedPrice), 10)
+	if !ok {
+		return bindings.TemporalNumericValueInput{}, shared.ErrFailedToConvertQuantizedPriceToBigInt
+	}
+
+	id, err := pusher.HexStringToByte32(string(signedPrice.EncodedAssetID))
+	if err != nil {
+		return bindings.TemporalNumericValueInput{}, fmt.Errorf("failed to parse encoded asset id: %w", err)
+	}
+
+	publisherMerkleRoot, err := pusher.HexStringToByte32(signedPrice.PublisherMerkleRoot)
+	if err != nil {
+		return bindings.TemporalNumericValueInput{}, fmt.Errorf("failed to parse publisher merkle root: %w", err)
+	}
+
+	valueComputeAlgHash, err := pusher.HexStringToByte32(signedPrice.StorkCalculationAlg.Checksum)
+	if err != nil {
+		return bindings.TemporalNumericValueInput{}, fmt.Errorf("failed to parse value compute alg hash: %w", err)
+	}
+
+	r, err := pusher.HexStringToByte32(signedPrice.TimestampedSignature.Signature.R)
+	if err != nil {
+		return bindings.TemporalNumericValueInput{}, fmt.Errorf("failed to parse signature R: %w", err)
+	}
+
+	s, err := pusher.HexStringToByte32(signedPrice.TimestampedSignature.Signature.S)
+	if err != nil {
+		return bindings.TemporalNumericValueInput{}, fmt.Errorf("failed to parse signature S: %w", err)
+	}
+
+	v, err := pusher.HexStringToByteArray(signedPrice.TimestampedSignature.Signature.V)
+	if err != nil || len(v) != 1 {
+		return bindings.TemporalNumericValueInput{}, fmt.Errorf(
+			"%w: %q", ErrInvalidSignatureV, signedPrice.TimestampedSignature.Signature.V,
+		)
+	}
+
+	return bindings.TemporalNumericValueInput{
+		TemporalNumericValue: bindings.TemporalNumericValue{
+			TimestampNs:    signedPrice.TimestampedSignature.TimestampNano,
+			QuantizedValue: quantizedValue,
+		},
+		ID:                  id,
+		PublisherMerkleRoot: publisherMerkleRoot,
+		ValueComputeAlgHash: valueComputeAlgHash,
+		R:                   r,
+		S:                   s,
+		V:                   v[0],
+	}, nil
+}
+
+func loadPrivateKey(keyFileContent []byte) (string, error) {
+	privateKey := strings.TrimSpace(strings.Split(string(keyFileContent), "\n")[0])
+	if privateKey == "" {
+		return "", ErrPrivateKeyEmpty
+	}
+
+	return privateKey, nil
+}
@@ -0,0 +1,116 @@
+package starknet
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/internal/testutil"
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/starknet/bindings"
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadPrivateKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		keyFileContent []byte
+		expected       string
+		wantError      bool
+	}{
+		{
+			name:           "simple private key",
+			keyFileContent: []byte("0x1234567890abcdef"),
+			expected:       "0x1234567890abcdef",
+		},
+		{
+			name:           "private key with trailing newline and whitespace",
+			keyFileContent: []byte(" 0x1234567890abcdef \n"),
+			expected:       "0x1234567890abcdef",
+		},
+		{
+			name:           "only the first line is used",
+			keyFileContent: []byte("0x1234\n0x5678\n"),
+			expected:       "0x1234",
+		},
+		{
+			name:           "empty content",
+			keyFileContent: []byte(""),
+			wantError:      true,
+		},
+		{
+			name:           "only newline",
+			keyFileContent: []byte("\n"),
+			wantError:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := loadPrivateKey(tt.keyFileContent)
+			if tt.wantError {
+				require.ErrorIs(t, err, ErrPrivateKeyEmpty)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestAggregatedSignedPriceToTemporalNumericValueInput(t *testing.T) {
+	t.Parallel()
+
+	tests := testutil.StandardPriceCase()
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := aggregatedSignedPriceToTemporalNumericValueInput(tt.Price)
+			if tt.WantError {
+				assert.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			expected := tt.PriceBytes.StorkSignedPrice
+			assert.Equal(t, expected.EncodedAssetID, result.ID)
+			assert.Equal(t, expected.TimestampedSignature.TimestampNano, result.TemporalNumericValue.TimestampNs)
+			assert.Equal(t, expected.QuantizedPrice, result.TemporalNumericValue.QuantizedValue)
+			assert.Equal(t, expected.PublisherMerkleRoot, result.PublisherMerkleRoot)
+			assert.Equal(t, expected.StorkCalculationAlg, result.ValueComputeAlgHash)
+			assert.Equal(t, expected.TimestampedSignature.Signature.R, result.R)
+			assert.Equal(t, expected.TimestampedSignature.Signature.S, result.S)
+			assert.Equal(t, expected.TimestampedSignature.Signature.V, result.V)
+		})
+	}
+}
+
+func TestLatestEventValues(t *testing.T) {
+	t.Parallel()
+
+	var first, second bindings.EncodedAssetID
+	first[0] = 1
+	second[0] = 2
+
+	updates := latestEventValues([]bindings.TemporalNumericValueUpdateEvent{
+		{AssetID: first, Value: bindings.TemporalNumericValue{TimestampNs: 10, QuantizedValue: big.NewInt(100)}},
+		{AssetID: second, Value: bindings.TemporalNumericValue{TimestampNs: 5, QuantizedValue: big.NewInt(-50)}},
+		{AssetID: first, Value: bindings.TemporalNumericValue{TimestampNs: 20, QuantizedValue: big.NewInt(200)}},
+		{AssetID: first, Value: bindings.TemporalNumericValue{TimestampNs: 15, QuantizedValue: big.NewInt(150)}},
+	})
+
+	assert.Equal(t, map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue{
+		types.InternalEncodedAssetID(first):  {TimestampNs: 20, QuantizedValue: big.NewInt(200)},
+		types.InternalEncodedAssetID(second): {TimestampNs: 5, QuantizedValue: big.NewInt(-50)},
+	}, updates)
+}
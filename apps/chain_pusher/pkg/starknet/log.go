@@ -0,0 +1,16 @@
+package starknet
+
+import (
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/pusher"
+	"github.com/rs/zerolog"
+)
+
+func PusherLogger(
+	rpcUrl string,
+	contractAddress string,
+) zerolog.Logger {
+	return pusher.AppLogger("starknet").With().
+		Str("chainRpcUrl", rpcUrl).
+		Str("contractAddress", contractAddress).
+		Logger()
+}
@@ -0,0 +1,131 @@
+package starknet
+
+import (
+	"context"
+	"os"
+
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/pusher"
+	"github.com/spf13/cobra"
+)
+
+// DefaultFeeMultiplier leaves headroom over the estimated fee for gas price movements before inclusion.
+const DefaultFeeMultiplier = 1.5
+
+func NewPushCmd() *cobra.Command {
+	pushCmd := &cobra.Command{
+		Use:   "starknet",
+		Short: "Push WebSocket prices to Starknet contract",
+		Run:   runPush,
+	}
+
+	pushCmd.Flags().StringP(pusher.StorkWebsocketEndpointFlag, "w", "", pusher.StorkWebsocketEndpointDesc)
+	pushCmd.Flags().StringP(pusher.StorkAuthCredentialsFlag, "a", "", pusher.StorkAuthCredentialsDesc)
+	pushCmd.Flags().StringP(pusher.ChainRpcUrlFlag, "c", "", pusher.ChainRpcUrlDesc)
+	pushCmd.Flags().StringP(pusher.ContractAddressFlag, "x", "", pusher.ContractAddressDesc)
+	pushCmd.Flags().StringP(pusher.AssetConfigFileFlag, "f", "", pusher.AssetConfigFileDesc)
+	pushCmd.Flags().StringP(pusher.PrivateKeyFileFlag, "k", "", pusher.PrivateKeyFileDesc)
+	pushCmd.Flags().StringP(pusher.AccountAddressFlag, "o", "", pusher.AccountAddressDesc)
+	pushCmd.Flags().IntP(pusher.BatchingWindowFlag, "b", pusher.DefaultBatchingWindow, pusher.BatchingWindowDesc)
+	pushCmd.Flags().String(pusher.BatchingWindowStrFlag, "", pusher.BatchingWindowStrDesc)
+	pushCmd.Flags().IntP(pusher.PollingPeriodFlag, "p", pusher.DefaultPollingPeriod, pusher.PollingPeriodDesc)
+	pushCmd.Flags().Float64(pusher.MaxSpendPerHourFlag, 0, pusher.MaxSpendPerHourDesc)
+	pushCmd.Flags().Float64(pusher.MaxSpendPerDayFlag, 0, pusher.MaxSpendPerDayDesc)
+	pushCmd.Flags().String(pusher.SpendStateFileFlag, "", pusher.SpendStateFileDesc)
+	pushCmd.Flags().String(pusher.MetricsAddressFlag, "", pusher.MetricsAddressDesc)
+	pushCmd.Flags().Int(pusher.MaxAssetsPerWindowFlag, 0, pusher.MaxAssetsPerWindowDesc)
+	pushCmd.Flags().BoolP(pusher.VerifyPublishersFlag, "v", false, pusher.VerifyPublishersDesc)
+	pushCmd.Flags().String(pusher.FeeTokenAddressFlag, "", pusher.FeeTokenAddressDesc)
+	pushCmd.Flags().Float64(pusher.FeeMultiplierFlag, DefaultFeeMultiplier, pusher.FeeMultiplierDesc)
+	pushCmd.Flags().Duration(pusher.EventPollIntervalFlag, DefaultEventPollInterval, pusher.EventPollIntervalDesc)
+	pushCmd.Flags().String(pusher.EventCursorFileFlag, "", pusher.EventCursorFileDesc)
+	pushCmd.Flags().Int(pusher.PullConcurrencyFlag, pusher.DefaultPullConcurrency, pusher.PullConcurrencyDesc)
+	pushCmd.Flags().IntP(pusher.LimitPerSecondFlag, "l", pusher.DefaultPullLimitPerSecond, pusher.LimitPerSecondDesc)
+	pushCmd.Flags().Int(pusher.BurstLimitFlag, pusher.DefaultPullBurstLimit, pusher.BurstLimitDesc)
+
+	pushCmd.MarkFlagsMutuallyExclusive(pusher.BatchingWindowFlag, pusher.BatchingWindowStrFlag)
+
+	_ = pushCmd.MarkFlagRequired(pusher.StorkWebsocketEndpointFlag)
+	_ = pushCmd.MarkFlagRequired(pusher.StorkAuthCredentialsFlag)
+	_ = pushCmd.MarkFlagRequired(pusher.ChainRpcUrlFlag)
+	_ = pushCmd.MarkFlagRequired(pusher.ContractAddressFlag)
+	_ = pushCmd.MarkFlagRequired(pusher.AssetConfigFileFlag)
+	_ = pushCmd.MarkFlagRequired(pusher.PrivateKeyFileFlag)
+	_ = pushCmd.MarkFlagRequired(pusher.AccountAddressFlag)
+
+	return pushCmd
+}
+
+func runPush(cmd *cobra.Command, args []string) {
+	storkWsEndpoint, _ := cmd.Flags().GetString(pusher.StorkWebsocketEndpointFlag)
+	storkAuth, _ := cmd.Flags().GetString(pusher.StorkAuthCredentialsFlag)
+	chainRpcUrl, _ := cmd.Flags().GetString(pusher.ChainRpcUrlFlag)
+	contractAddress, _ := cmd.Flags().GetString(pusher.ContractAddressFlag)
+	assetConfigFile, _ := cmd.Flags().GetString(pusher.AssetConfigFileFlag)
+	privateKeyFile, _ := cmd.Flags().GetString(pusher.PrivateKeyFileFlag)
+	accountAddress, _ := cmd.Flags().GetString(pusher.AccountAddressFlag)
+	batchingWindow, _ := cmd.Flags().GetInt(pusher.BatchingWindowFlag)
+	batchingWindowStr, _ := cmd.Flags().GetString(pusher.BatchingWindowStrFlag)
+	pollingPeriod, _ := cmd.Flags().GetInt(pusher.PollingPeriodFlag)
+	maxSpendPerHour, _ := cmd.Flags().GetFloat64(pusher.MaxSpendPerHourFlag)
+	maxSpendPerDay, _ := cmd.Flags().GetFloat64(pusher.MaxSpendPerDayFlag)
+	spendStateFile, _ := cmd.Flags().GetString(pusher.SpendStateFileFlag)
+	metricsAddress, _ := cmd.Flags().GetString(pusher.MetricsAddressFlag)
+	maxAssetsPerWindow, _ := cmd.Flags().GetInt(pusher.MaxAssetsPerWindowFlag)
+	verifyPublishers, _ := cmd.Flags().GetBool(pusher.VerifyPublishersFlag)
+	feeTokenAddress, _ := cmd.Flags().GetString(pusher.FeeTokenAddressFlag)
+	feeMultiplier, _ := cmd.Flags().GetFloat64(pusher.FeeMultiplierFlag)
+	eventPollInterval, _ := cmd.Flags().GetDuration(pusher.EventPollIntervalFlag)
+	eventCursorFile, _ := cmd.Flags().GetString(pusher.EventCursorFileFlag)
+	pullConcurrency, _ := cmd.Flags().GetInt(pusher.PullConcurrencyFlag)
+	limitPerSecond, _ := cmd.Flags().GetInt(pusher.LimitPerSecondFlag)
+	burstLimit, _ := cmd.Flags().GetInt(pusher.BurstLimitFlag)
+
+	logger := PusherLogger(chainRpcUrl, contractAddress)
+
+	keyFileContent, err := os.ReadFile(privateKeyFile)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to read private key file")
+	}
+
+	interactor, err := NewContractInteractor(
+		contractAddress,
+		accountAddress,
+		keyFileContent,
+		logger,
+		feeTokenAddress,
+		feeMultiplier,
+		eventPollInterval,
+		eventCursorFile,
+		pullConcurrency,
+		limitPerSecond,
+		burstLimit,
+	)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to initialize contract interactor")
+	}
+
+	pusher.StartMetricsServer(metricsAddress, logger)
+
+	budget, err := pusher.NewSpendBudget(maxSpendPerHour, maxSpendPerDay, spendStateFile, logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to initialize spend budget")
+	}
+
+	pusher := pusher.NewPusher(
+		storkWsEndpoint,
+		storkAuth,
+		chainRpcUrl,
+		"",
+		contractAddress,
+		assetConfigFile,
+		batchingWindowStr,
+		batchingWindow,
+		pollingPeriod,
+		verifyPublishers,
+		maxAssetsPerWindow,
+		interactor,
+		budget,
+		&logger,
+	)
+	pusher.Run(context.Background())
+}
@@ -0,0 +1,163 @@
+package bindings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/Stork-Oracle/go-sui-sdk/v2/sui_types"
+	"github.com/Stork-Oracle/go-sui-sdk/v2/types"
+)
+
+var (
+	ErrNoGasCoinAvailable     = errors.New("no unleased gas coin with sufficient balance available")
+	ErrInsufficientGasBalance = errors.New("insufficient balance to refill the gas coin pool")
+)
+
+const (
+	// MaxGasPaymentObjects is the maximum number of coins a transaction can use as gas payment.
+	MaxGasPaymentObjects = 256
+	// fragmentationFactor is how many coins per pool slot the owner may hold before the coins are merged.
+	fragmentationFactor = 4
+)
+
+// GasCoinPool leases distinct gas coins to concurrent transactions, so that no two in-flight transactions use the
+// same coin object and fail on a version conflict. Coins are identified by object ID and their latest reference
+// is looked up before every lease, as the reference changes with every transaction that uses the coin.
+type GasCoinPool struct {
+	mu            sync.Mutex
+	leased        map[sui_types.ObjectID]struct{}
+	slots         chan struct{}
+	size          int
+	targetBalance uint64
+}
+
+// NewGasCoinPool creates a pool that allows size concurrent transactions and keeps coins of targetBalance MIST.
+func NewGasCoinPool(size int, targetBalance uint64) *GasCoinPool {
+	size = max(size, 1)
+
+	return &GasCoinPool{
+		mu:            sync.Mutex{},
+		leased:        make(map[sui_types.ObjectID]struct{}),
+		slots:         make(chan struct{}, size),
+		size:          size,
+		targetBalance: targetBalance,
+	}
+}
+
+// Size returns the number of concurrent transactions the pool supports.
+func (p *GasCoinPool) Size() int {
+	return p.size
+}
+
+// Wait blocks until one of the pool's slots is free. Every successful Wait must be followed by Done.
+func (p *GasCoinPool) Wait(ctx context.Context) error {
+	select {
+	case p.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to wait for a gas coin: %w", ctx.Err())
+	}
+}
+
+// Done frees a slot taken by Wait.
+func (p *GasCoinPool) Done() {
+	<-p.slots
+}
+
+// Lease picks the unleased coin with the smallest balance covering minBalance, so that large coins are kept for
+// large transactions, and marks it as leased until Release.
+func (p *GasCoinPool) Lease(coins []types.Coin, minBalance uint64) (*types.Coin, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var picked *types.Coin
+
+	for i := range coins {
+		coin := &coins[i]
+		if _, ok := p.leased[coin.CoinObjectId]; ok {
+			continue
+		}
+
+		if coin.Balance.Uint64() < minBalance {
+			continue
+		}
+
+		if picked == nil || coin.Balance.Uint64() < picked.Balance.Uint64() {
+			picked = coin
+		}
+	}
+
+	if picked == nil {
+		return nil, ErrNoGasCoinAvailable
+	}
+
+	p.leased[picked.CoinObjectId] = struct{}{}
+
+	return picked, nil
+}
+
+// LeaseAll leases every unleased coin, up to the gas payment limit, and returns them in descending balance order.
+func (p *GasCoinPool) LeaseAll(coins []types.Coin) []types.Coin {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	unleased := make([]types.Coin, 0, len(coins))
+
+	for _, coin := range coins {
+		if _, ok := p.leased[coin.CoinObjectId]; !ok {
+			unleased = append(unleased, coin)
+		}
+	}
+
+	sort.Slice(unleased, func(i, j int) bool {
+		return unleased[i].Balance.Uint64() > unleased[j].Balance.Uint64()
+	})
+
+	if len(unleased) > MaxGasPaymentObjects {
+		unleased = unleased[:MaxGasPaymentObjects]
+	}
+
+	for _, coin := range unleased {
+		p.leased[coin.CoinObjectId] = struct{}{}
+	}
+
+	return unleased
+}
+
+// Release returns leased coins to the pool.
+func (p *GasCoinPool) Release(coins ...types.Coin) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, coin := range coins {
+		delete(p.leased, coin.CoinObjectId)
+	}
+}
+
+// MissingCoins returns how many coins of the target balance must be split off so that every slot has a coin
+// holding at least half of the target balance.
+func (p *GasCoinPool) MissingCoins(coins []types.Coin) int {
+	usable := 0
+
+	for _, coin := range coins {
+		if coin.Balance.Uint64() >= p.targetBalance/2 {
+			usable++
+		}
+	}
+
+	return max(p.size-usable, 0)
+}
+
+// NeedsRebalance reports whether the coins should be merged and split, either because there are not enough
+// usable coins for every slot or because the balance is fragmented across many small coins.
+func (p *GasCoinPool) NeedsRebalance(coins []types.Coin) bool {
+	return p.MissingCoins(coins) > 0 || len(coins) > p.size*fragmentationFactor
+}
+
+// TargetBalance returns the balance in MIST coins are split to.
+func (p *GasCoinPool) TargetBalance() uint64 {
+	return p.targetBalance
+}
@@ -6,11 +6,13 @@ package bindings
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/big"
 	"strconv"
+	"sync"
 
 	"github.com/Stork-Oracle/go-sui-sdk/v2/account"
 	sui_client "github.com/Stork-Oracle/go-sui-sdk/v2/client"
@@ -19,19 +21,41 @@ import (
 	"github.com/Stork-Oracle/go-sui-sdk/v2/types"
 	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/pusher"
 	"github.com/fardream/go-bcs/bcs"
+	"github.com/mr-tron/base58"
 )
 
 var (
 	ErrFeedRegistryNotFound = errors.New("feed registry not found")
 	ErrFieldNotFound        = errors.New("field not found")
 	ErrWrongType            = errors.New("wrong type")
+	ErrInvalidEventBcs      = errors.New("invalid event bcs")
+)
+
+// feedUpdateEventModule and feedUpdateEventName identify the event emitted for every feed update.
+const (
+	feedUpdateEventModule = "event"
+	feedUpdateEventName   = "TemporalNumericValueFeedUpdateEvent"
+	// feedUpdateEventBcsSize is the size of a BCS encoded feed update event: a length prefixed 32 byte asset ID,
+	// a u64 timestamp, a bool sign and a u128 magnitude.
+	feedUpdateEventBcsSize = 1 + 32 + 8 + 16 + 1
 )
 
 type StorkContract struct {
 	Client          *sui_client.Client
 	Account         *account.Account
 	ContractAddress sui_types.SuiAddress
-	State           StorkState
+	// OriginalContractAddress is the address the package was first published at, which event types are
+	// namespaced under across upgrades.
+	OriginalContractAddress sui_types.SuiAddress
+	State                   StorkState
+	// Sponsor pays the gas of update transactions when set, while Account still pays the update fee.
+	Sponsor *account.Account
+	// GasPool leases the gas coins of the gas owner, which is the sponsor if set and the account otherwise.
+	GasPool *GasCoinPool
+	// FeePool leases the account coins the update fee is split from in sponsored transactions.
+	FeePool *GasCoinPool
+
+	rebalanceMu sync.Mutex
 }
 
 type MultipleUpdateData struct {
@@ -89,11 +113,32 @@ type U128 struct {
 	Value []byte
 }
 
+type TemporalNumericValueFeedUpdateEvent struct {
+	AssetID EncodedAssetID
+	Value   TemporalNumericValue
+}
+
+// bcsTemporalNumericValueFeedUpdateEvent mirrors the BCS layout of the Move TemporalNumericValueFeedUpdateEvent.
+type bcsTemporalNumericValueFeedUpdateEvent struct {
+	AssetID []byte
+	Value   struct {
+		TimestampNs    uint64
+		QuantizedValue struct {
+			// BCS decodes fields in declaration order, which for the Move I128 is negative then magnitude.
+			Negative bool
+			// Magnitude must be allocated before decoding, as only *bcs.Uint128 implements bcs.Unmarshaler.
+			Magnitude *bcs.Uint128
+		}
+	}
+}
+
 func NewStorkContract(
 	ctx context.Context,
 	rpcUrl string,
 	contractAddress string,
 	account *account.Account,
+	sponsor *account.Account,
+	gasPool *GasCoinPool,
 ) (*StorkContract, error) {
 	client, err := sui_client.Dial(rpcUrl)
 	if err != nil {
@@ -105,12 +150,137 @@ func NewStorkContract(
 		return nil, fmt.Errorf("failed to convert contract address to Sui address: %w", err)
 	}
 
-	state, err := getStorkState(ctx, *contractAddr, client)
+	originalContractAddress, err := getOriginalContractAddress(ctx, *contractAddr, client)
 	if err != nil {
 		return nil, err
 	}
 
-	return &StorkContract{Client: client, Account: account, ContractAddress: *contractAddr, State: state}, nil
+	state, err := getStorkState(ctx, originalContractAddress, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StorkContract{
+		Client:                  client,
+		Account:                 account,
+		ContractAddress:         *contractAddr,
+		OriginalContractAddress: originalContractAddress,
+		State:                   state,
+		Sponsor:                 sponsor,
+		GasPool:                 gasPool,
+		FeePool:                 NewGasCoinPool(gasPool.Size(), 0),
+		rebalanceMu:             sync.Mutex{},
+	}, nil
+}
+
+// QueryTemporalNumericValueFeedUpdateEvents returns the feed update events emitted after the cursor in
+// ascending order, along with the cursor to resume from and whether more events are available.
+// A nil cursor starts from the first event.
+func (sc *StorkContract) QueryTemporalNumericValueFeedUpdateEvents(
+	ctx context.Context,
+	cursor *types.EventId,
+	limit uint,
+) ([]TemporalNumericValueFeedUpdateEvent, *types.EventId, bool, error) {
+	page, err := sc.Client.QueryEvents(ctx, sc.feedUpdateEventFilter(), cursor, &limit, false)
+	if err != nil {
+		return nil, cursor, false, fmt.Errorf("failed to query feed update events: %w", err)
+	}
+
+	events := make([]TemporalNumericValueFeedUpdateEvent, 0, len(page.Data))
+
+	for _, rawEvent := range page.Data {
+		event, err := DecodeTemporalNumericValueFeedUpdateEvent(rawEvent.Bcs)
+		if err != nil {
+			return nil, cursor, false, err
+		}
+
+		events = append(events, event)
+	}
+
+	nextCursor := page.NextCursor
+	if nextCursor == nil {
+		nextCursor = cursor
+	}
+
+	return events, nextCursor, page.HasNextPage, nil
+}
+
+// LatestFeedUpdateEventCursor returns the cursor of the most recent feed update event, or nil if none were emitted.
+func (sc *StorkContract) LatestFeedUpdateEventCursor(ctx context.Context) (*types.EventId, error) {
+	limit := uint(1)
+
+	page, err := sc.Client.QueryEvents(ctx, sc.feedUpdateEventFilter(), nil, &limit, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query latest feed update event: %w", err)
+	}
+
+	if len(page.Data) == 0 {
+		//nolint:nilnil // A contract without events has no cursor to resume from.
+		return nil, nil
+	}
+
+	cursor := page.Data[0].Id
+
+	return &cursor, nil
+}
+
+func (sc *StorkContract) feedUpdateEventFilter() types.EventFilter {
+	eventType := fmt.Sprintf(
+		"%s::%s::%s", sc.OriginalContractAddress.String(), feedUpdateEventModule, feedUpdateEventName,
+	)
+
+	return types.EventFilter{MoveEventType: &eventType}
+}
+
+// DecodeTemporalNumericValueFeedUpdateEvent decodes the BCS contents of a feed update event. Nodes return event
+// BCS as base58, or as base64 on newer versions, so both are accepted.
+func DecodeTemporalNumericValueFeedUpdateEvent(encoded string) (TemporalNumericValueFeedUpdateEvent, error) {
+	data, err := decodeEventBcs(encoded)
+	if err != nil {
+		return TemporalNumericValueFeedUpdateEvent{}, err
+	}
+
+	var rawEvent bcsTemporalNumericValueFeedUpdateEvent
+	rawEvent.Value.QuantizedValue.Magnitude = new(bcs.Uint128)
+
+	_, err = bcs.Unmarshal(data, &rawEvent)
+	if err != nil {
+		return TemporalNumericValueFeedUpdateEvent{}, fmt.Errorf("%w: %w", ErrInvalidEventBcs, err)
+	}
+
+	var assetID EncodedAssetID
+	if len(rawEvent.AssetID) != len(assetID) {
+		return TemporalNumericValueFeedUpdateEvent{}, fmt.Errorf(
+			"%w: asset id is %d bytes", ErrInvalidEventBcs, len(rawEvent.AssetID),
+		)
+	}
+
+	copy(assetID[:], rawEvent.AssetID)
+
+	return TemporalNumericValueFeedUpdateEvent{
+		AssetID: assetID,
+		Value: TemporalNumericValue{
+			TimestampNs: rawEvent.Value.TimestampNs,
+			QuantizedValue: I128{
+				Magnitude: rawEvent.Value.QuantizedValue.Magnitude.Big(),
+				Negative:  rawEvent.Value.QuantizedValue.Negative,
+			},
+		},
+	}, nil
+}
+
+func decodeEventBcs(encoded string) ([]byte, error) {
+	data, err := base58.Decode(encoded)
+	if err == nil && len(data) == feedUpdateEventBcsSize {
+		return data, nil
+	}
+
+	data, err = base64.StdEncoding.DecodeString(encoded)
+	if err == nil && len(data) == feedUpdateEventBcsSize {
+		return data, nil
+	}
+
+	return nil, fmt.Errorf("%w: unexpected encoding or size", ErrInvalidEventBcs)
 }
 
 // GetMultipleTemporalNumericValuesUnchecked gets multiple temporal numeric values at a time for efficiency.
@@ -336,13 +506,34 @@ func (sc *StorkContract) UpdateMultipleTemporalNumericValuesEvm(
 		return "", fmt.Errorf("failed to create pure field for total fee amount: %w", err)
 	}
 
+	// the fee is split from the gas coin unless the transaction is sponsored, in which case the gas coin belongs
+	// to the sponsor and the fee is split from a coin of the account instead
+	feeSource := sui_types.Argument{GasCoin: &lib.EmptyEnum{}}
+
+	if sc.Sponsor != nil {
+		var feeCoin *types.Coin
+
+		feeCoin, err = sc.leaseCoin(ctx, sc.FeePool, *address, totalFeeAmount)
+		if err != nil {
+			return "", fmt.Errorf("failed to lease fee coin: %w", err)
+		}
+
+		defer sc.FeePool.Done()
+		defer sc.FeePool.Release(*feeCoin)
+
+		feeSource, err = ptb.Obj(sui_types.ObjectArg{ImmOrOwnedObject: feeCoin.Reference()})
+		if err != nil {
+			return "", fmt.Errorf("failed to create object for fee coin: %w", err)
+		}
+	}
+
 	splitCoinResult := ptb.Command(
 		sui_types.Command{
 			SplitCoins: &struct {
 				Argument  sui_types.Argument
 				Arguments []sui_types.Argument
 			}{
-				Argument:  sui_types.Argument{GasCoin: &lib.EmptyEnum{}},
+				Argument:  feeSource,
 				Arguments: []sui_types.Argument{feeArg},
 			},
 		},
@@ -488,40 +679,45 @@ func (sc *StorkContract) UpdateMultipleTemporalNumericValuesEvm(
 
 	pt := ptb.Finish()
 
-	//nolint:mnd // 100 is being used as a arbitrarily large limit and thus a permissible magic number
-	coins, err := sc.Client.GetCoins(ctx, *address, nil, nil, 100)
+	gasBudget, err := sc.getGasBudgetFromDryRun(ctx, *address, &pt, referenceGasPrice)
 	if err != nil {
-		return "", fmt.Errorf("failed to get coins: %w", err)
+		return "", err
 	}
 
-	gasBudget, err := sc.getGasBudgetFromDryRun(ctx, &pt, referenceGasPrice)
+	gasOwner, err := sc.gasOwnerAddress()
 	if err != nil {
 		return "", err
 	}
 
-	totalFeeAmountInt64, err := pusher.SafeUint64ToInt64(totalFeeAmount)
-	if err != nil {
-		return "", fmt.Errorf("failed to convert total fee amount to int64: %w", err)
+	minGasBalance := gasBudget
+	if sc.Sponsor == nil {
+		minGasBalance += totalFeeAmount
+	}
+
+	gasCoin, err := sc.leaseCoin(ctx, sc.GasPool, gasOwner, minGasBalance)
+	if errors.Is(err, ErrNoGasCoinAvailable) {
+		// refill the pool and retry once
+		err = sc.RebalanceGasCoins(ctx)
+		if err == nil {
+			gasCoin, err = sc.leaseCoin(ctx, sc.GasPool, gasOwner, minGasBalance)
+		}
 	}
 
-	pickedCoins, err := types.PickupCoins(
-		coins,
-		*big.NewInt(totalFeeAmountInt64),
-		gasBudget,
-		0,
-		0,
-	)
 	if err != nil {
-		return "", fmt.Errorf("failed to pick up coins: %w", err)
+		return "", fmt.Errorf("failed to lease gas coin: %w", err)
 	}
 
-	tx := sui_types.NewProgrammable(
-		*address,
-		pickedCoins.CoinRefs(),
-		pt,
-		gasBudget,
-		referenceGasPrice,
-	)
+	defer sc.GasPool.Done()
+	defer sc.GasPool.Release(*gasCoin)
+
+	gasPayment := []*sui_types.ObjectRef{gasCoin.Reference()}
+
+	var tx sui_types.TransactionData
+	if sc.Sponsor != nil {
+		tx = sui_types.NewProgrammableAllowSponsor(*address, gasPayment, pt, gasBudget, referenceGasPrice, gasOwner)
+	} else {
+		tx = sui_types.NewProgrammable(*address, gasPayment, pt, gasBudget, referenceGasPrice)
+	}
 
 	txBytes, err := bcs.Marshal(tx)
 	if err != nil {
@@ -533,10 +729,21 @@ func (sc *StorkContract) UpdateMultipleTemporalNumericValuesEvm(
 		return "", fmt.Errorf("failed to sign transaction: %w", err)
 	}
 
+	allSignatures := []any{signatures}
+
+	if sc.Sponsor != nil {
+		sponsorSignatures, signErr := sc.Sponsor.SignSecureWithoutEncode(txBytes, sui_types.DefaultIntent())
+		if signErr != nil {
+			return "", fmt.Errorf("failed to sign transaction as sponsor: %w", signErr)
+		}
+
+		allSignatures = append(allSignatures, sponsorSignatures)
+	}
+
 	txResponse, err := sc.Client.ExecuteTransactionBlock(
 		ctx,
 		txBytes,
-		[]any{signatures},
+		allSignatures,
 		nil,
 		types.TxnRequestTypeWaitForEffectsCert,
 	)
@@ -549,6 +756,225 @@ func (sc *StorkContract) UpdateMultipleTemporalNumericValuesEvm(
 	return digest, nil
 }
 
+// RebalanceGasCoins merges the unleased coins of the gas owner through gas smashing and splits off coins of the
+// pool's target balance until every slot of the pool has a usable coin.
+func (sc *StorkContract) RebalanceGasCoins(ctx context.Context) error {
+	// concurrent pushes that run out of coins would otherwise race to merge the same coins
+	sc.rebalanceMu.Lock()
+	defer sc.rebalanceMu.Unlock()
+
+	gasOwner, err := sc.gasOwnerAddress()
+	if err != nil {
+		return err
+	}
+
+	coins, err := sc.getAllCoins(ctx, gasOwner)
+	if err != nil {
+		return err
+	}
+
+	if !sc.GasPool.NeedsRebalance(coins) {
+		return nil
+	}
+
+	// always split at least one coin, as the transaction needs a command besides merging the gas payment
+	missing := max(sc.GasPool.MissingCoins(coins), 1)
+
+	paymentCoins := sc.GasPool.LeaseAll(coins)
+	defer sc.GasPool.Release(paymentCoins...)
+
+	var total uint64
+	for _, coin := range paymentCoins {
+		total += coin.Balance.Uint64()
+	}
+
+	referenceGasPrice, err := sc.getReferenceGasPrice(ctx)
+	if err != nil {
+		return err
+	}
+
+	ptb, err := splitCoinsTransaction(gasOwner, missing, sc.GasPool.TargetBalance())
+	if err != nil {
+		return err
+	}
+
+	pt := ptb.Finish()
+
+	gasBudget, err := sc.getGasBudgetFromDryRun(ctx, gasOwner, &pt, referenceGasPrice)
+	if err != nil {
+		return err
+	}
+
+	// split fewer coins if the balance cannot cover all of them
+	for missing > 0 && total < uint64(missing)*sc.GasPool.TargetBalance()+gasBudget {
+		missing--
+	}
+
+	if missing == 0 {
+		return fmt.Errorf("%w: %d MIST across %d coins", ErrInsufficientGasBalance, total, len(paymentCoins))
+	}
+
+	ptb, err = splitCoinsTransaction(gasOwner, missing, sc.GasPool.TargetBalance())
+	if err != nil {
+		return err
+	}
+
+	gasPayment := make([]*sui_types.ObjectRef, 0, len(paymentCoins))
+	for i := range paymentCoins {
+		gasPayment = append(gasPayment, paymentCoins[i].Reference())
+	}
+
+	tx := sui_types.NewProgrammable(gasOwner, gasPayment, ptb.Finish(), gasBudget, referenceGasPrice)
+
+	txBytes, err := bcs.Marshal(tx)
+	if err != nil {
+		return fmt.Errorf("failed to marshal transaction: %w", err)
+	}
+
+	signatures, err := sc.gasOwnerAccount().SignSecureWithoutEncode(txBytes, sui_types.DefaultIntent())
+	if err != nil {
+		return fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	_, err = sc.Client.ExecuteTransactionBlock(
+		ctx,
+		txBytes,
+		[]any{signatures},
+		nil,
+		types.TxnRequestTypeWaitForLocalExecution,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to execute gas coin rebalance: %w", err)
+	}
+
+	return nil
+}
+
+// splitCoinsTransaction splits count coins of amount MIST off the gas coin and sends them back to the owner.
+func splitCoinsTransaction(
+	owner sui_types.SuiAddress,
+	count int,
+	amount uint64,
+) (*sui_types.ProgrammableTransactionBuilder, error) {
+	ptb := sui_types.NewProgrammableTransactionBuilder()
+
+	recipients := make([]sui_types.SuiAddress, count)
+	amounts := make([]uint64, count)
+
+	for i := range count {
+		recipients[i] = owner
+		amounts[i] = amount
+	}
+
+	err := ptb.PaySui(recipients, amounts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build coin split: %w", err)
+	}
+
+	return ptb, nil
+}
+
+// leaseCoin waits for a free slot in the pool and leases a coin of the owner holding at least minBalance.
+// On success the caller must release the coin and call Done on the pool.
+func (sc *StorkContract) leaseCoin(
+	ctx context.Context,
+	pool *GasCoinPool,
+	owner sui_types.SuiAddress,
+	minBalance uint64,
+) (*types.Coin, error) {
+	err := pool.Wait(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// coin references change with every transaction, so fetch them after waiting for the slot
+	coins, err := sc.getAllCoins(ctx, owner)
+	if err != nil {
+		pool.Done()
+
+		return nil, err
+	}
+
+	coin, err := pool.Lease(coins, minBalance)
+	if err != nil {
+		pool.Done()
+
+		return nil, err
+	}
+
+	return coin, nil
+}
+
+// getAllCoins returns every SUI coin owned by the address.
+func (sc *StorkContract) getAllCoins(ctx context.Context, owner sui_types.SuiAddress) ([]types.Coin, error) {
+	var (
+		coins  []types.Coin
+		cursor *sui_types.ObjectID
+	)
+
+	for {
+		//nolint:mnd // 100 is being used as a arbitrarily large limit and thus a permissible magic number
+		page, err := sc.Client.GetCoins(ctx, owner, nil, cursor, 100)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get coins: %w", err)
+		}
+
+		coins = append(coins, page.Data...)
+
+		if !page.HasNextPage || page.NextCursor == nil {
+			return coins, nil
+		}
+
+		cursor = page.NextCursor
+	}
+}
+
+func (sc *StorkContract) gasOwnerAccount() *account.Account {
+	if sc.Sponsor != nil {
+		return sc.Sponsor
+	}
+
+	return sc.Account
+}
+
+func (sc *StorkContract) gasOwnerAddress() (sui_types.SuiAddress, error) {
+	address, err := sui_types.NewAddressFromHex(sc.gasOwnerAccount().Address)
+	if err != nil {
+		return sui_types.SuiAddress{}, fmt.Errorf("failed to get gas owner address from hex: %w", err)
+	}
+
+	return *address, nil
+}
+
+// suiBalance is the part of the suix_getBalance response the contract needs.
+type suiBalance struct {
+	TotalBalance string `json:"totalBalance"`
+}
+
+// GetBalance returns the SUI balance in MIST of the account paying the gas of updates,
+// which is the sponsor if one is configured.
+func (sc *StorkContract) GetBalance(ctx context.Context) (uint64, error) {
+	owner, err := sc.gasOwnerAddress()
+	if err != nil {
+		return 0, err
+	}
+
+	var result suiBalance
+
+	// omitting the coin type queries the balance of SUI
+	err = sc.Client.CallContext(ctx, &result, sui_client.SuiXMethod("getBalance"), owner)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get balance: %w", err)
+	}
+
+	balance, err := strconv.ParseUint(result.TotalBalance, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse balance %q: %w", result.TotalBalance, err)
+	}
+
+	return balance, nil
+}
+
 func getOriginalContractAddress(
 	ctx context.Context,
 	contractAddress sui_types.SuiAddress,
@@ -605,14 +1031,9 @@ func getOriginalContractAddress(
 //nolint:cyclop,funlen // This is a long and complex function due to interface destructuring
 func getStorkState(
 	ctx context.Context,
-	contractAddress sui_types.SuiAddress,
+	originalContractAddress sui_types.SuiAddress,
 	client *sui_client.Client,
 ) (StorkState, error) {
-	originalContractAddress, err := getOriginalContractAddress(ctx, contractAddress, client)
-	if err != nil {
-		return StorkState{}, err
-	}
-
 	eventFilter := types.EventFilter{
 		MoveModule: &struct {
 			Package sui_types.ObjectID `json:"package"`
@@ -767,16 +1188,12 @@ func getStorkState(
 
 func (sc *StorkContract) getGasBudgetFromDryRun(
 	ctx context.Context,
+	sender sui_types.SuiAddress,
 	pt *sui_types.ProgrammableTransaction,
 	referenceGasPrice uint64,
 ) (uint64, error) {
-	address, err := sui_types.NewAddressFromHex(sc.Account.Address)
-	if err != nil {
-		return 0, fmt.Errorf("failed to get address from hex: %w", err)
-	}
-
 	tx := sui_types.NewProgrammable(
-		*address,
+		sender,
 		nil,
 		*pt,
 		//nolint:mnd // 10e9 is an arbitrarily large gas budget and thus a permissible magic number
@@ -0,0 +1,162 @@
+package sui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sdk_types "github.com/Stork-Oracle/go-sui-sdk/v2/types"
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/pusher"
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/sui/bindings"
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/types"
+)
+
+// DefaultEventPollInterval is how often the contract is queried for new feed update events.
+const DefaultEventPollInterval = 2 * time.Second
+
+// eventQueryLimit is the maximum page size suix_queryEvents accepts.
+const eventQueryLimit = 50
+
+// ListenContractEvents polls suix_queryEvents for feed update events, resuming from the persisted cursor.
+// Sui does not support websocket subscriptions, so events are followed with cursor polling instead.
+func (sci *ContractInteractor) ListenContractEvents(
+	ctx context.Context,
+	ch chan map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue,
+) {
+	if sci.eventPollInterval <= 0 {
+		sci.logger.Warn().Msg("Event polling disabled, relying on contract polling only")
+
+		return
+	}
+
+	cursor, err := sci.initialEventCursor(ctx)
+	if err != nil {
+		sci.logger.Error().Err(err).Msg("Failed to determine starting event cursor, relying on contract polling only")
+
+		return
+	}
+
+	sci.logger.Info().Dur("interval", sci.eventPollInterval).Msg("Polling contract feed update events")
+
+	ticker := time.NewTicker(sci.eventPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			cursor = sci.pollEvents(ctx, cursor, ch)
+		}
+	}
+}
+
+// initialEventCursor returns the persisted cursor if there is one, and otherwise the latest event so that
+// history is not replayed on first start.
+func (sci *ContractInteractor) initialEventCursor(ctx context.Context) (*sdk_types.EventId, error) {
+	cursor, err := loadEventCursor(sci.eventCursorFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if cursor != nil {
+		sci.logger.Info().Str("cursorFile", sci.eventCursorFile).Msg("Resuming events from persisted cursor")
+
+		return cursor, nil
+	}
+
+	for {
+		cursor, err = sci.contract.LatestFeedUpdateEventCursor(ctx)
+		if err == nil {
+			return cursor, nil
+		}
+
+		sci.logger.Warn().Err(err).Msg("Failed to get latest event cursor, retrying")
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to get latest event cursor: %w", ctx.Err())
+		case <-time.After(sci.eventPollInterval):
+		}
+	}
+}
+
+// pollEvents forwards all events after the cursor to the channel and returns the cursor to continue from.
+func (sci *ContractInteractor) pollEvents(
+	ctx context.Context,
+	cursor *sdk_types.EventId,
+	ch chan map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue,
+) *sdk_types.EventId {
+	for {
+		events, nextCursor, hasNextPage, err := sci.contract.QueryTemporalNumericValueFeedUpdateEvents(
+			ctx, cursor, eventQueryLimit,
+		)
+		if err != nil {
+			sci.logger.Warn().Err(err).Msg("Failed to query feed update events")
+
+			return cursor
+		}
+
+		updates := latestEventValues(events)
+		if len(updates) > 0 {
+			select {
+			case ch <- updates:
+			case <-ctx.Done():
+				return cursor
+			}
+		}
+
+		cursor = nextCursor
+
+		err = saveEventCursor(sci.eventCursorFile, cursor)
+		if err != nil {
+			sci.logger.Warn().Err(err).Msg("Failed to persist event cursor")
+		}
+
+		if !hasNextPage {
+			return cursor
+		}
+	}
+}
+
+// latestEventValues keeps the newest value per asset from a page of events.
+func latestEventValues(
+	events []bindings.TemporalNumericValueFeedUpdateEvent,
+) map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue {
+	updates := make(map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue)
+
+	for _, event := range events {
+		encodedAssetID := types.InternalEncodedAssetID(event.AssetID)
+
+		existing, ok := updates[encodedAssetID]
+		if ok && existing.TimestampNs >= event.Value.TimestampNs {
+			continue
+		}
+
+		updates[encodedAssetID] = temporalNumericValueToInternal(event.Value)
+	}
+
+	return updates
+}
+
+// loadEventCursor reads a persisted event cursor, returning nil if none has been persisted yet.
+//
+//nolint:nilnil // A missing cursor is not an error.
+func loadEventCursor(path string) (*sdk_types.EventId, error) {
+	var cursor sdk_types.EventId
+
+	found, err := pusher.ReadStateFile(path, &cursor)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	return &cursor, nil
+}
+
+func saveEventCursor(path string, cursor *sdk_types.EventId) error {
+	if cursor == nil {
+		return nil
+	}
+
+	return pusher.WriteStateFile(path, cursor)
+}
@@ -0,0 +1,115 @@
+package sui
+
+import (
+	"encoding/json"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	sdk_types "github.com/Stork-Oracle/go-sui-sdk/v2/types"
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/sui/bindings"
+	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeTemporalNumericValueFeedUpdateEvent(t *testing.T) {
+	t.Parallel()
+
+	magnitude, ok := new(big.Int).SetString("1000000000000000000000000000000", 10)
+	require.True(t, ok)
+
+	var assetID bindings.EncodedAssetID
+	assetID[0] = 7
+
+	expected := bindings.TemporalNumericValueFeedUpdateEvent{
+		AssetID: assetID,
+		Value: bindings.TemporalNumericValue{
+			TimestampNs: 1722632569208762117,
+			QuantizedValue: bindings.I128{
+				Magnitude: magnitude,
+				Negative:  true,
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{"base58", "Hu3Gp3hYAmdDbB6QT3YKK2cWMXPYfGCM8FUAHzmfaFaaVc2y968DvXmvGWYoUW8S6BBBBhGFYa25VP5"},
+		{"base64", "IAcAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAABe803jEF6BcBAAAAQOrtdEbQnCyfDAAAAA=="},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			event, err := bindings.DecodeTemporalNumericValueFeedUpdateEvent(tt.encoded)
+			require.NoError(t, err)
+			assert.Equal(t, expected, event)
+		})
+	}
+
+	_, err := bindings.DecodeTemporalNumericValueFeedUpdateEvent("IAc=")
+	require.ErrorIs(t, err, bindings.ErrInvalidEventBcs)
+}
+
+func TestLatestEventValues(t *testing.T) {
+	t.Parallel()
+
+	var first, second bindings.EncodedAssetID
+	first[0] = 1
+	second[0] = 2
+
+	event := func(assetID bindings.EncodedAssetID, timestampNs uint64, magnitude int64) bindings.TemporalNumericValueFeedUpdateEvent {
+		return bindings.TemporalNumericValueFeedUpdateEvent{
+			AssetID: assetID,
+			Value: bindings.TemporalNumericValue{
+				TimestampNs:    timestampNs,
+				QuantizedValue: bindings.I128{Magnitude: big.NewInt(magnitude)},
+			},
+		}
+	}
+
+	updates := latestEventValues([]bindings.TemporalNumericValueFeedUpdateEvent{
+		event(first, 10, 100),
+		event(second, 5, 50),
+		event(first, 20, 200),
+		event(first, 15, 150),
+	})
+
+	assert.Equal(t, map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue{
+		types.InternalEncodedAssetID(first):  {TimestampNs: 20, QuantizedValue: big.NewInt(200)},
+		types.InternalEncodedAssetID(second): {TimestampNs: 5, QuantizedValue: big.NewInt(50)},
+	}, updates)
+	assert.Empty(t, latestEventValues(nil))
+}
+
+func TestEventCursorPersistence(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cursor.json")
+
+	cursor, err := loadEventCursor(path)
+	require.NoError(t, err)
+	assert.Nil(t, cursor)
+
+	cursor, err = loadEventCursor("")
+	require.NoError(t, err)
+	assert.Nil(t, cursor)
+
+	var expected sdk_types.EventId
+
+	err = json.Unmarshal(
+		[]byte(`{"txDigest":"44PMwfFs4tfN4ujLy5xwpiGxQfa9abP5HFYtub8vgHXn","eventSeq":"3"}`),
+		&expected,
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, saveEventCursor(path, &expected))
+
+	cursor, err = loadEventCursor(path)
+	require.NoError(t, err)
+	assert.Equal(t, &expected, cursor)
+}
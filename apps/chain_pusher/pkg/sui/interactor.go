@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/Stork-Oracle/go-sui-sdk/v2/account"
 	"github.com/Stork-Oracle/stork-external/apps/chain_pusher/pkg/pusher"
@@ -15,45 +17,94 @@ import (
 	"github.com/rs/zerolog"
 )
 
-var ErrPrivateKeyEmpty = errors.New("private key is empty")
+var (
+	ErrPrivateKeyEmpty            = errors.New("private key is empty")
+	ErrBatchSizeNegative          = errors.New("batch size must not be negative")
+	ErrGasCoinPoolSizeNotPositive = errors.New("gas coin pool size must be positive")
+)
 
 type ContractInteractor struct {
 	logger zerolog.Logger
 
 	account      *account.Account
+	sponsor      *account.Account
 	contractAddr string
+	gasPool      *bindings.GasCoinPool
+	batchSize    int
 
 	contract *bindings.StorkContract
+
+	eventPollInterval time.Duration
+	eventCursorFile   string
 }
 
 func NewContractInteractor(
 	contractAddr string,
 	keyFileContent []byte,
 	logger zerolog.Logger,
+	eventPollInterval time.Duration,
+	eventCursorFile string,
+	sponsorKeyFileContent []byte,
+	gasCoins int,
+	gasCoinBalance uint64,
+	batchSize int,
 ) (*ContractInteractor, error) {
 	logger = logger.With().Str("component", "sui-contract-interactor").Logger()
 
+	if batchSize < 0 {
+		return nil, ErrBatchSizeNegative
+	}
+
+	if gasCoins <= 0 {
+		return nil, ErrGasCoinPoolSizeNotPositive
+	}
+
+	// load the sponsor first, as the account variable below shadows the account package
+	var sponsor *account.Account
+
+	if len(sponsorKeyFileContent) > 0 {
+		var err error
+
+		sponsor, err = loadPrivateKey(sponsorKeyFileContent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load sponsor key: %w", err)
+		}
+
+		logger.Info().Str("sponsor", sponsor.Address).Msg("Gas for update transactions is paid by the sponsor")
+	}
+
 	account, err := loadPrivateKey(keyFileContent)
 	if err != nil {
 		return nil, err
 	}
 
 	return &ContractInteractor{
-		logger:       logger,
-		account:      account,
-		contractAddr: contractAddr,
-		contract:     nil,
+		logger:            logger,
+		account:           account,
+		sponsor:           sponsor,
+		contractAddr:      contractAddr,
+		gasPool:           bindings.NewGasCoinPool(gasCoins, gasCoinBalance),
+		batchSize:         batchSize,
+		contract:          nil,
+		eventPollInterval: eventPollInterval,
+		eventCursorFile:   eventCursorFile,
 	}, nil
 }
 
 func (sci *ContractInteractor) ConnectHTTP(ctx context.Context, url string) error {
-	contract, err := bindings.NewStorkContract(ctx, url, sci.contractAddr, sci.account)
+	contract, err := bindings.NewStorkContract(ctx, url, sci.contractAddr, sci.account, sci.sponsor, sci.gasPool)
 	if err != nil {
 		return fmt.Errorf("failed to create stork contract client: %w", err)
 	}
 
 	sci.contract = contract
 
+	// prepare the gas coins up front so the first pushes do not all contend on a single coin
+	err = sci.contract.RebalanceGasCoins(ctx)
+	if err != nil {
+		sci.logger.Warn().Err(err).Msg("Failed to rebalance gas coins")
+	}
+
 	return nil
 }
 
@@ -62,16 +113,6 @@ func (sci *ContractInteractor) ConnectWs(ctx context.Context, url string) error
 	return nil
 }
 
-// ListenContractEvents is a placeholder function for the Sui contract interactor.
-// unfortunately, Sui doesn't currently support websocket RPCs, so we can't listen to events from the contract
-// the contract does emit events, so this can be implemented in the future if Sui re-adds websocket support.
-func (sci *ContractInteractor) ListenContractEvents(
-	ctx context.Context,
-	ch chan map[types.InternalEncodedAssetID]types.InternalTemporalNumericValue,
-) {
-	sci.logger.Warn().Msg("Sui does not currently support listening to events via websocket, falling back to polling")
-}
-
 func (sci *ContractInteractor) PullValues(
 	ctx context.Context,
 	encodedAssetIDs []types.InternalEncodedAssetID,
@@ -101,6 +142,8 @@ func (sci *ContractInteractor) PullValues(
 	return result, nil
 }
 
+// BatchPushToContract pushes the updates in transactions of at most batchSize updates, submitting them
+// concurrently on distinct gas coins.
 func (sci *ContractInteractor) BatchPushToContract(
 	ctx context.Context,
 	priceUpdates map[types.InternalEncodedAssetID]types.AggregatedSignedPrice,
@@ -116,6 +159,27 @@ func (sci *ContractInteractor) BatchPushToContract(
 		updateData = append(updateData, update)
 	}
 
+	batches := splitUpdateData(updateData, sci.batchSize)
+	errs := make([]error, len(batches))
+
+	var wg sync.WaitGroup
+
+	for i, batch := range batches {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			errs[i] = sci.pushBatch(ctx, batch)
+		}()
+	}
+
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+func (sci *ContractInteractor) pushBatch(ctx context.Context, updateData []bindings.UpdateData) error {
 	digest, err := sci.contract.UpdateMultipleTemporalNumericValuesEvm(ctx, updateData)
 	if err != nil {
 		sci.logger.Error().Err(err).Msg("failed to update multiple temporal numeric values")
@@ -124,19 +188,37 @@ func (sci *ContractInteractor) BatchPushToContract(
 	}
 
 	sci.logger.Debug().
-		Int("numUpdates", len(priceUpdates)).
+		Int("numUpdates", len(updateData)).
 		Str("txnDigest", digest).
 		Msg("Successfully pushed batch update to contract")
 
 	return nil
 }
 
-// GetWalletBalance is a placeholder function to get the balance of the wallet being used to push to the contract.
-// todo: implement
-//
-//nolint:godox // This function has unmet criteria to be implemented.
+// splitUpdateData splits the updates into batches of at most batchSize updates, or a single batch if batchSize is 0.
+func splitUpdateData(updateData []bindings.UpdateData, batchSize int) [][]bindings.UpdateData {
+	if batchSize <= 0 || len(updateData) <= batchSize {
+		return [][]bindings.UpdateData{updateData}
+	}
+
+	batches := make([][]bindings.UpdateData, 0, (len(updateData)+batchSize-1)/batchSize)
+
+	for start := 0; start < len(updateData); start += batchSize {
+		batches = append(batches, updateData[start:min(start+batchSize, len(updateData))])
+	}
+
+	return batches
+}
+
+// GetWalletBalance returns the SUI balance in MIST of the account paying the gas of updates, which is the sponsor
+// if one is configured.
 func (sci *ContractInteractor) GetWalletBalance(ctx context.Context) (float64, error) {
-	return -1, nil
+	balance, err := sci.contract.GetBalance(ctx)
+	if err != nil {
+		return -1, fmt.Errorf("failed to get wallet balance: %w", err)
+	}
+
+	return float64(balance), nil
 }
 
 func temporalNumericValueToInternal(value bindings.TemporalNumericValue) types.InternalTemporalNumericValue {
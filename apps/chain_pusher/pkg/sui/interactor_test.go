@@ -170,3 +170,41 @@ func TestAggregatedSignedPriceToUpdateData(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitUpdateData(t *testing.T) {
+	t.Parallel()
+
+	updateData := make([]bindings.UpdateData, 5)
+	for i := range updateData {
+		updateData[i] = bindings.UpdateData{TemporalNumericValueTimestampNs: uint64(i)}
+	}
+
+	tests := []struct {
+		name      string
+		batchSize int
+		sizes     []int
+	}{
+		{"no limit", 0, []int{5}},
+		{"limit above count", 10, []int{5}},
+		{"even split", 5, []int{5}},
+		{"uneven split", 2, []int{2, 2, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			batches := splitUpdateData(updateData, tt.batchSize)
+			require.Len(t, batches, len(tt.sizes))
+
+			var flattened []bindings.UpdateData
+			for i, batch := range batches {
+				assert.Len(t, batch, tt.sizes[i])
+
+				flattened = append(flattened, batch...)
+			}
+
+			assert.Equal(t, updateData, flattened)
+		})
+	}
+}
@@ -8,6 +8,16 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// DefaultPollingPeriod is longer than the pusher default because contract state is followed through feed update
+// events, leaving polling as a consistency check.
+const DefaultPollingPeriod = 30
+
+const (
+	DefaultGasCoins       = 4
+	DefaultGasCoinBalance = 1_000_000_000
+	DefaultBatchSize      = 0
+)
+
 func NewPushCmd() *cobra.Command {
 	pushCmd := &cobra.Command{
 		Use:   "sui",
@@ -23,7 +33,19 @@ func NewPushCmd() *cobra.Command {
 	pushCmd.Flags().StringP(pusher.PrivateKeyFileFlag, "k", "", pusher.PrivateKeyFileDesc)
 	pushCmd.Flags().IntP(pusher.BatchingWindowFlag, "b", pusher.DefaultBatchingWindow, pusher.BatchingWindowDesc)
 	pushCmd.Flags().String(pusher.BatchingWindowStrFlag, "", pusher.BatchingWindowStrDesc)
-	pushCmd.Flags().IntP(pusher.PollingPeriodFlag, "p", pusher.DefaultPollingPeriod, pusher.PollingPeriodDesc)
+	pushCmd.Flags().IntP(pusher.PollingPeriodFlag, "p", DefaultPollingPeriod, pusher.PollingPeriodDesc)
+	pushCmd.Flags().Float64(pusher.MaxSpendPerHourFlag, 0, pusher.MaxSpendPerHourDesc)
+	pushCmd.Flags().Float64(pusher.MaxSpendPerDayFlag, 0, pusher.MaxSpendPerDayDesc)
+	pushCmd.Flags().String(pusher.SpendStateFileFlag, "", pusher.SpendStateFileDesc)
+	pushCmd.Flags().String(pusher.MetricsAddressFlag, "", pusher.MetricsAddressDesc)
+	pushCmd.Flags().Int(pusher.MaxAssetsPerWindowFlag, 0, pusher.MaxAssetsPerWindowDesc)
+	pushCmd.Flags().BoolP(pusher.VerifyPublishersFlag, "v", false, pusher.VerifyPublishersDesc)
+	pushCmd.Flags().Duration(pusher.EventPollIntervalFlag, DefaultEventPollInterval, pusher.EventPollIntervalDesc)
+	pushCmd.Flags().String(pusher.EventCursorFileFlag, "", pusher.EventCursorFileDesc)
+	pushCmd.Flags().String(pusher.SponsorKeyFileFlag, "", pusher.SponsorKeyFileDesc)
+	pushCmd.Flags().Int(pusher.GasCoinsFlag, DefaultGasCoins, pusher.GasCoinsDesc)
+	pushCmd.Flags().Uint64(pusher.GasCoinBalanceFlag, DefaultGasCoinBalance, pusher.GasCoinBalanceDesc)
+	pushCmd.Flags().IntP(pusher.BatchSizeFlag, "s", DefaultBatchSize, pusher.BatchSizeDesc)
 
 	pushCmd.MarkFlagsMutuallyExclusive(pusher.BatchingWindowFlag, pusher.BatchingWindowStrFlag)
 
@@ -47,6 +69,18 @@ func runSuiPush(cmd *cobra.Command, args []string) {
 	batchingWindow, _ := cmd.Flags().GetInt(pusher.BatchingWindowFlag)
 	batchingWindowStr, _ := cmd.Flags().GetString(pusher.BatchingWindowStrFlag)
 	pollingPeriod, _ := cmd.Flags().GetInt(pusher.PollingPeriodFlag)
+	maxSpendPerHour, _ := cmd.Flags().GetFloat64(pusher.MaxSpendPerHourFlag)
+	maxSpendPerDay, _ := cmd.Flags().GetFloat64(pusher.MaxSpendPerDayFlag)
+	spendStateFile, _ := cmd.Flags().GetString(pusher.SpendStateFileFlag)
+	metricsAddress, _ := cmd.Flags().GetString(pusher.MetricsAddressFlag)
+	maxAssetsPerWindow, _ := cmd.Flags().GetInt(pusher.MaxAssetsPerWindowFlag)
+	verifyPublishers, _ := cmd.Flags().GetBool(pusher.VerifyPublishersFlag)
+	eventPollInterval, _ := cmd.Flags().GetDuration(pusher.EventPollIntervalFlag)
+	eventCursorFile, _ := cmd.Flags().GetString(pusher.EventCursorFileFlag)
+	sponsorKeyFile, _ := cmd.Flags().GetString(pusher.SponsorKeyFileFlag)
+	gasCoins, _ := cmd.Flags().GetInt(pusher.GasCoinsFlag)
+	gasCoinBalance, _ := cmd.Flags().GetUint64(pusher.GasCoinBalanceFlag)
+	batchSize, _ := cmd.Flags().GetInt(pusher.BatchSizeFlag)
 
 	logger := PusherLogger(chainRpcUrl, contractAddress)
 
@@ -55,15 +89,37 @@ func runSuiPush(cmd *cobra.Command, args []string) {
 		logger.Fatal().Err(err).Msg("Failed to read private key file")
 	}
 
+	var sponsorKeyFileContent []byte
+
+	if sponsorKeyFile != "" {
+		sponsorKeyFileContent, err = os.ReadFile(sponsorKeyFile)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Failed to read sponsor key file")
+		}
+	}
+
 	interactor, err := NewContractInteractor(
 		contractAddress,
 		keyFileContent,
 		logger,
+		eventPollInterval,
+		eventCursorFile,
+		sponsorKeyFileContent,
+		gasCoins,
+		gasCoinBalance,
+		batchSize,
 	)
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Failed to initialize contract interactor")
 	}
 
+	pusher.StartMetricsServer(metricsAddress, logger)
+
+	budget, err := pusher.NewSpendBudget(maxSpendPerHour, maxSpendPerDay, spendStateFile, logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to initialize spend budget")
+	}
+
 	pusher := pusher.NewPusher(
 		storkWsEndpoint,
 		storkAuth,
@@ -74,7 +130,10 @@ func runSuiPush(cmd *cobra.Command, args []string) {
 		batchingWindowStr,
 		batchingWindow,
 		pollingPeriod,
+		verifyPublishers,
+		maxAssetsPerWindow,
 		interactor,
+		budget,
 		&logger,
 	)
 	pusher.Run(context.Background())
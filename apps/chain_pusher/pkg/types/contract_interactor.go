@@ -15,3 +15,16 @@ type ContractInteractor interface {
 	ConnectHTTP(ctx context.Context, url string) error
 	ConnectWs(ctx context.Context, url string) error
 }
+
+// ConfirmationResult reports whether the transaction carrying a set of asset updates landed on chain.
+type ConfirmationResult struct {
+	EncodedAssetIDs []InternalEncodedAssetID
+	Confirmed       bool
+	Err             error
+}
+
+// ConfirmationReporter is implemented by contract interactors that confirm transactions asynchronously,
+// after BatchPushToContract has returned.
+type ConfirmationReporter interface {
+	ConfirmationResults() <-chan ConfirmationResult
+}
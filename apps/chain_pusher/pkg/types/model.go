@@ -22,6 +22,8 @@ type AssetEntry struct {
 	PercentChangeThreshold float64               `yaml:"percent_change_threshold"`
 	FallbackPeriodSecs     uint64                `yaml:"fallback_period_sec"` //nolint:tagliatelle // Legacy
 	PushEveryBatch         bool                  `yaml:"push_every_batch"`
+	Triggers               []TriggerSpec         `yaml:"triggers"`
+	QuietHours             *QuietHours           `yaml:"quiet_hours"`
 }
 
 // LoadConfig loads the asset config from the given filename.
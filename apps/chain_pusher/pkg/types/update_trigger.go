@@ -0,0 +1,499 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+)
+
+var (
+	ErrUnknownUpdateTrigger = errors.New("unknown update trigger type")
+	ErrInvalidUpdateTrigger = errors.New("invalid update trigger")
+	ErrInvalidQuietHours    = errors.New("invalid quiet hours")
+)
+
+// UpdateReason is the kind of update a trigger fires.
+type UpdateReason string
+
+const (
+	// UpdateReasonHeartbeat updates keep a feed fresh on chain regardless of how its value moved. They are never
+	// silenced by quiet hours or throttled by the spend budget.
+	UpdateReasonHeartbeat UpdateReason = "heartbeat"
+	// UpdateReasonDeviation updates are fired by the value moving away from the value on chain.
+	UpdateReasonDeviation UpdateReason = "deviation"
+)
+
+const (
+	// quantizedDecimals is the number of decimals quantized values carry.
+	quantizedDecimals = 18
+	percent           = 100
+	basisPoints       = 10_000
+	minutesPerHour    = 60
+)
+
+// UpdateTrigger decides whether the latest Stork value of an asset should be pushed over its value on chain.
+type UpdateTrigger interface {
+	// Reason is reported for the updates the trigger fires.
+	Reason() UpdateReason
+	// ShouldUpdate reports whether latest should be pushed at wall clock time now.
+	ShouldUpdate(now time.Time, onChain InternalTemporalNumericValue, latest AggregatedSignedPrice) bool
+}
+
+// UpdateTriggerFactory decodes the YAML parameters of a trigger, given the unmarshal function of its node, which
+// also holds the type key.
+type UpdateTriggerFactory func(unmarshal func(any) error) (UpdateTrigger, error)
+
+var (
+	updateTriggerFactoriesMu sync.RWMutex
+	updateTriggerFactories   = map[string]UpdateTriggerFactory{
+		"percent_change":        decodeTrigger[*PercentChangeTrigger],
+		"fallback_period":       decodeTrigger[*FallbackPeriodTrigger],
+		"absolute_deviation":    decodeTrigger[*AbsoluteDeviationTrigger],
+		"basis_points":          decodeTrigger[*BasisPointsTrigger],
+		"time_scaled_deviation": decodeTrigger[*TimeScaledDeviationTrigger],
+		"aligned_heartbeat":     decodeTrigger[*AlignedHeartbeatTrigger],
+	}
+)
+
+// RegisterUpdateTrigger makes a trigger available to asset configs under the given type name. It must be called
+// before the asset config is loaded, e.g. from an init function.
+func RegisterUpdateTrigger(name string, factory UpdateTriggerFactory) {
+	updateTriggerFactoriesMu.Lock()
+	defer updateTriggerFactoriesMu.Unlock()
+
+	updateTriggerFactories[name] = factory
+}
+
+// TriggerSpec is a single entry of an asset's triggers list.
+type TriggerSpec struct {
+	Type    string
+	Trigger UpdateTrigger
+}
+
+func (s *TriggerSpec) UnmarshalYAML(unmarshal func(any) error) error {
+	var header struct {
+		Type string `yaml:"type"`
+	}
+
+	err := unmarshal(&header)
+	if err != nil {
+		return fmt.Errorf("failed to decode update trigger: %w", err)
+	}
+
+	updateTriggerFactoriesMu.RLock()
+	factory, ok := updateTriggerFactories[header.Type]
+	updateTriggerFactoriesMu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownUpdateTrigger, header.Type)
+	}
+
+	trigger, err := factory(unmarshal)
+	if err != nil {
+		return fmt.Errorf("failed to decode %s update trigger: %w", header.Type, err)
+	}
+
+	s.Type = header.Type
+	s.Trigger = trigger
+
+	return nil
+}
+
+type validatedTrigger interface {
+	UpdateTrigger
+	validate() error
+}
+
+func decodeTrigger[T validatedTrigger](unmarshal func(any) error) (UpdateTrigger, error) {
+	var trigger T
+
+	err := unmarshal(&trigger)
+	if err != nil {
+		return nil, err
+	}
+
+	err = trigger.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return trigger, nil
+}
+
+// PercentChangeTrigger fires when the value moved by more than ThresholdPercent from the value on chain.
+type PercentChangeTrigger struct {
+	ThresholdPercent float64 `yaml:"threshold"`
+}
+
+func (t *PercentChangeTrigger) Reason() UpdateReason {
+	return UpdateReasonDeviation
+}
+
+func (t *PercentChangeTrigger) ShouldUpdate(
+	_ time.Time, onChain InternalTemporalNumericValue, latest AggregatedSignedPrice,
+) bool {
+	quantizedVal, quantizedCurrVal, ok := quantizedValues(onChain, latest)
+	if !ok {
+		return false
+	}
+
+	// Calculate the absolute difference
+	difference := new(big.Float).Sub(quantizedVal, quantizedCurrVal)
+	absDifference := new(big.Float).Abs(difference)
+
+	if quantizedCurrVal.Sign() == 0 {
+		return quantizedVal.Sign() != 0
+	}
+
+	// Calculate the ratio
+	ratio := new(big.Float).Quo(absDifference, quantizedCurrVal)
+	absRatio := new(big.Float).Abs(ratio)
+
+	percentChange := new(big.Float).Mul(absRatio, big.NewFloat(percent))
+
+	return percentChange.Cmp(big.NewFloat(t.ThresholdPercent)) > 0
+}
+
+func (t *PercentChangeTrigger) validate() error {
+	if t.ThresholdPercent < 0 {
+		return fmt.Errorf("%w: threshold must not be negative", ErrInvalidUpdateTrigger)
+	}
+
+	return nil
+}
+
+// FallbackPeriodTrigger fires when the latest value is more than Period newer than the value on chain.
+type FallbackPeriodTrigger struct {
+	Period time.Duration `yaml:"period"`
+}
+
+func (t *FallbackPeriodTrigger) Reason() UpdateReason {
+	return UpdateReasonHeartbeat
+}
+
+func (t *FallbackPeriodTrigger) ShouldUpdate(
+	_ time.Time, onChain InternalTemporalNumericValue, latest AggregatedSignedPrice,
+) bool {
+	return latest.TimestampNano-onChain.TimestampNs > uint64(t.Period)
+}
+
+func (t *FallbackPeriodTrigger) validate() error {
+	if t.Period < 0 {
+		return fmt.Errorf("%w: period must not be negative", ErrInvalidUpdateTrigger)
+	}
+
+	return nil
+}
+
+// AbsoluteDeviationTrigger fires when the value moved by more than a fixed amount, which unlike a relative
+// threshold stays meaningful for values close to zero such as rates. The threshold is configured in value units
+// and held quantized.
+type AbsoluteDeviationTrigger struct {
+	Threshold *big.Int
+}
+
+func (t *AbsoluteDeviationTrigger) UnmarshalYAML(unmarshal func(any) error) error {
+	var params struct {
+		Threshold string `yaml:"threshold"`
+	}
+
+	err := unmarshal(&params)
+	if err != nil {
+		return err
+	}
+
+	threshold, ok := new(big.Float).SetString(params.Threshold)
+	if !ok {
+		return fmt.Errorf("%w: threshold %q is not a number", ErrInvalidUpdateTrigger, params.Threshold)
+	}
+
+	//nolint:mnd // Base number
+	scale := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(quantizedDecimals), nil))
+	t.Threshold, _ = new(big.Float).Mul(threshold, scale).Int(nil)
+
+	return nil
+}
+
+func (t *AbsoluteDeviationTrigger) Reason() UpdateReason {
+	return UpdateReasonDeviation
+}
+
+func (t *AbsoluteDeviationTrigger) ShouldUpdate(
+	_ time.Time, onChain InternalTemporalNumericValue, latest AggregatedSignedPrice,
+) bool {
+	latestValue, ok := latestQuantizedValue(latest)
+	if !ok || onChain.QuantizedValue == nil {
+		return false
+	}
+
+	difference := new(big.Int).Sub(latestValue, onChain.QuantizedValue)
+
+	return difference.Abs(difference).Cmp(t.Threshold) > 0
+}
+
+func (t *AbsoluteDeviationTrigger) validate() error {
+	if t.Threshold == nil || t.Threshold.Sign() < 0 {
+		return fmt.Errorf("%w: threshold must not be negative", ErrInvalidUpdateTrigger)
+	}
+
+	return nil
+}
+
+// BasisPointsTrigger fires when the value leaves the band of BandBps basis points around the value on chain. The
+// comparison is done on the quantized integers, so it is exact even for very tight bands.
+type BasisPointsTrigger struct {
+	BandBps uint64 `yaml:"band_bps"`
+}
+
+func (t *BasisPointsTrigger) Reason() UpdateReason {
+	return UpdateReasonDeviation
+}
+
+func (t *BasisPointsTrigger) ShouldUpdate(
+	_ time.Time, onChain InternalTemporalNumericValue, latest AggregatedSignedPrice,
+) bool {
+	latestValue, ok := latestQuantizedValue(latest)
+	if !ok || onChain.QuantizedValue == nil {
+		return false
+	}
+
+	// |latest - onChain| * 10000 > band * |onChain|
+	difference := new(big.Int).Sub(latestValue, onChain.QuantizedValue)
+	difference.Abs(difference)
+	difference.Mul(difference, big.NewInt(basisPoints))
+
+	band := new(big.Int).Abs(onChain.QuantizedValue)
+	band.Mul(band, new(big.Int).SetUint64(t.BandBps))
+
+	return difference.Cmp(band) > 0
+}
+
+func (t *BasisPointsTrigger) validate() error {
+	return nil
+}
+
+// TimeScaledDeviationTrigger fires when the value moved by more than a percent threshold that shrinks linearly
+// from InitialPercent to FinalPercent as the value on chain ages over DecayPeriod, so small moves are eventually
+// pushed without pushing every small move straight away.
+type TimeScaledDeviationTrigger struct {
+	InitialPercent float64       `yaml:"initial_percent"`
+	FinalPercent   float64       `yaml:"final_percent"`
+	DecayPeriod    time.Duration `yaml:"decay_period"`
+}
+
+func (t *TimeScaledDeviationTrigger) Reason() UpdateReason {
+	return UpdateReasonDeviation
+}
+
+func (t *TimeScaledDeviationTrigger) ShouldUpdate(
+	now time.Time, onChain InternalTemporalNumericValue, latest AggregatedSignedPrice,
+) bool {
+	threshold := PercentChangeTrigger{ThresholdPercent: t.threshold(onChain, latest)}
+
+	return threshold.ShouldUpdate(now, onChain, latest)
+}
+
+// threshold returns the percent threshold for the time elapsed between the value on chain and the latest value.
+func (t *TimeScaledDeviationTrigger) threshold(
+	onChain InternalTemporalNumericValue, latest AggregatedSignedPrice,
+) float64 {
+	if latest.TimestampNano <= onChain.TimestampNs {
+		return t.InitialPercent
+	}
+
+	elapsed := time.Duration(latest.TimestampNano - onChain.TimestampNs)
+	if t.DecayPeriod == 0 || elapsed >= t.DecayPeriod {
+		return t.FinalPercent
+	}
+
+	progress := float64(elapsed) / float64(t.DecayPeriod)
+
+	return t.InitialPercent - (t.InitialPercent-t.FinalPercent)*progress
+}
+
+func (t *TimeScaledDeviationTrigger) validate() error {
+	if t.FinalPercent < 0 || t.InitialPercent < t.FinalPercent || t.DecayPeriod < 0 {
+		return fmt.Errorf(
+			"%w: thresholds must satisfy initial_percent >= final_percent >= 0 and decay_period must not be negative",
+			ErrInvalidUpdateTrigger,
+		)
+	}
+
+	return nil
+}
+
+// AlignedHeartbeatTrigger fires once per wall clock aligned Interval, e.g. every minute on the minute, when the
+// value on chain predates the latest boundary and a value from after the boundary is available.
+type AlignedHeartbeatTrigger struct {
+	Interval time.Duration `yaml:"interval"`
+}
+
+func (t *AlignedHeartbeatTrigger) Reason() UpdateReason {
+	return UpdateReasonHeartbeat
+}
+
+func (t *AlignedHeartbeatTrigger) ShouldUpdate(
+	now time.Time, onChain InternalTemporalNumericValue, latest AggregatedSignedPrice,
+) bool {
+	boundary := uint64(now.Truncate(t.Interval).UnixNano()) //nolint:gosec // Wall clock times are positive.
+
+	return onChain.TimestampNs < boundary && latest.TimestampNano >= boundary
+}
+
+func (t *AlignedHeartbeatTrigger) validate() error {
+	if t.Interval <= 0 {
+		return fmt.Errorf("%w: interval must be positive", ErrInvalidUpdateTrigger)
+	}
+
+	return nil
+}
+
+// QuietHours is a daily window in which deviation triggered updates of an asset are silenced, e.g. while its
+// market is closed. Heartbeat updates are still pushed. The window may wrap around midnight.
+type QuietHours struct {
+	StartMinute int
+	EndMinute   int
+	Location    *time.Location
+}
+
+func (q *QuietHours) UnmarshalYAML(unmarshal func(any) error) error {
+	var params struct {
+		Start    string `yaml:"start"`
+		End      string `yaml:"end"`
+		Timezone string `yaml:"timezone"`
+	}
+
+	err := unmarshal(&params)
+	if err != nil {
+		return err
+	}
+
+	q.StartMinute, err = parseMinuteOfDay(params.Start)
+	if err != nil {
+		return err
+	}
+
+	q.EndMinute, err = parseMinuteOfDay(params.End)
+	if err != nil {
+		return err
+	}
+
+	q.Location, err = time.LoadLocation(params.Timezone)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidQuietHours, err)
+	}
+
+	return nil
+}
+
+// Contains reports whether now falls within the quiet hours.
+func (q *QuietHours) Contains(now time.Time) bool {
+	local := now.In(q.Location)
+	minute := local.Hour()*minutesPerHour + local.Minute()
+
+	if q.StartMinute <= q.EndMinute {
+		return minute >= q.StartMinute && minute < q.EndMinute
+	}
+
+	return minute >= q.StartMinute || minute < q.EndMinute
+}
+
+func parseMinuteOfDay(value string) (int, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q is not a HH:MM time", ErrInvalidQuietHours, value)
+	}
+
+	return parsed.Hour()*minutesPerHour + parsed.Minute(), nil
+}
+
+// UpdateTriggers returns the asset's triggers. The legacy fallback_period_sec and percent_change_threshold fields
+// always apply when no triggers are configured, and otherwise only when set.
+func (e AssetEntry) UpdateTriggers() []UpdateTrigger {
+	triggers := make([]UpdateTrigger, 0, len(e.Triggers)+2) //nolint:mnd // The two legacy triggers.
+
+	if len(e.Triggers) == 0 || e.FallbackPeriodSecs > 0 {
+		triggers = append(triggers, &FallbackPeriodTrigger{Period: time.Duration(e.FallbackPeriodSecs) * time.Second})
+	}
+
+	if len(e.Triggers) == 0 || e.PercentChangeThreshold > 0 {
+		triggers = append(triggers, &PercentChangeTrigger{ThresholdPercent: e.PercentChangeThreshold})
+	}
+
+	for _, spec := range e.Triggers {
+		triggers = append(triggers, spec.Trigger)
+	}
+
+	return triggers
+}
+
+// FallbackPeriod returns the shortest period of the asset's fallback period triggers, which is the longest the
+// value on chain may go without an update. It returns false if the asset has no fallback period.
+func (e AssetEntry) FallbackPeriod() (time.Duration, bool) {
+	var (
+		period time.Duration
+		found  bool
+	)
+
+	for _, trigger := range e.UpdateTriggers() {
+		fallback, ok := trigger.(*FallbackPeriodTrigger)
+		if ok && (!found || fallback.Period < period) {
+			period, found = fallback.Period, true
+		}
+	}
+
+	return period, found
+}
+
+// EvaluateTriggers returns the reason the asset should be pushed, if any. Heartbeats take precedence so that an
+// update both triggers would fire is never silenced, and deviations are silenced within quiet hours.
+func (e AssetEntry) EvaluateTriggers(
+	now time.Time, onChain InternalTemporalNumericValue, latest AggregatedSignedPrice,
+) (UpdateReason, bool) {
+	deviated := false
+
+	for _, trigger := range e.UpdateTriggers() {
+		if !trigger.ShouldUpdate(now, onChain, latest) {
+			continue
+		}
+
+		if trigger.Reason() == UpdateReasonHeartbeat {
+			return UpdateReasonHeartbeat, true
+		}
+
+		deviated = true
+	}
+
+	if deviated && (e.QuietHours == nil || !e.QuietHours.Contains(now)) {
+		return UpdateReasonDeviation, true
+	}
+
+	return "", false
+}
+
+func latestQuantizedValue(latest AggregatedSignedPrice) (*big.Int, bool) {
+	if latest.StorkSignedPrice == nil {
+		return nil, false
+	}
+
+	//nolint:mnd // Base number
+	return new(big.Int).SetString(string(latest.StorkSignedPrice.QuantizedPrice), 10)
+}
+
+func quantizedValues(
+	onChain InternalTemporalNumericValue, latest AggregatedSignedPrice,
+) (*big.Float, *big.Float, bool) {
+	if latest.StorkSignedPrice == nil || onChain.QuantizedValue == nil {
+		return nil, nil, false
+	}
+
+	quantizedVal := new(big.Float)
+	quantizedVal.SetString(string(latest.StorkSignedPrice.QuantizedPrice))
+
+	quantizedCurrVal := new(big.Float)
+	quantizedCurrVal.SetInt(onChain.QuantizedValue)
+
+	return quantizedVal, quantizedCurrVal, true
+}
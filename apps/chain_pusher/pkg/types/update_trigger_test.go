@@ -0,0 +1,339 @@
+package types
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Stork-Oracle/stork-external/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// quantized returns value with the 18 decimals quantized values carry.
+func quantized(t *testing.T, value string) *big.Int {
+	t.Helper()
+
+	parsed, ok := new(big.Float).SetString(value)
+	require.True(t, ok)
+
+	result, _ := new(big.Float).Mul(parsed, big.NewFloat(1e18)).Int(nil)
+
+	return result
+}
+
+func TestUpdateTriggers(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2026, 3, 2, 10, 0, 30, 0, time.UTC)
+	baseNs := uint64(base.UnixNano())
+
+	tests := []struct {
+		name       string
+		trigger    UpdateTrigger
+		now        time.Time
+		onChainTs  uint64
+		onChainVal string
+		latestTs   uint64
+		latestVal  string
+		expected   bool
+	}{
+		{
+			name:       "percent change above threshold",
+			trigger:    &PercentChangeTrigger{ThresholdPercent: 1},
+			onChainVal: "100",
+			latestVal:  "101.5",
+			expected:   true,
+		},
+		{
+			name:       "percent change within threshold",
+			trigger:    &PercentChangeTrigger{ThresholdPercent: 1},
+			onChainVal: "100",
+			latestVal:  "100.5",
+			expected:   false,
+		},
+		{
+			name:       "fallback period elapsed",
+			trigger:    &FallbackPeriodTrigger{Period: time.Minute},
+			onChainTs:  baseNs,
+			onChainVal: "100",
+			latestTs:   baseNs + uint64(61*time.Second),
+			latestVal:  "100",
+			expected:   true,
+		},
+		{
+			name:       "absolute deviation near zero",
+			trigger:    &AbsoluteDeviationTrigger{Threshold: quantized(t, "0.0005")},
+			onChainVal: "0.0001",
+			latestVal:  "-0.0006",
+			expected:   true,
+		},
+		{
+			name:       "absolute deviation within threshold",
+			trigger:    &AbsoluteDeviationTrigger{Threshold: quantized(t, "0.0005")},
+			onChainVal: "0.0001",
+			latestVal:  "0.0004",
+			expected:   false,
+		},
+		{
+			name:       "outside basis point band",
+			trigger:    &BasisPointsTrigger{BandBps: 25},
+			onChainVal: "2000",
+			latestVal:  "2005.01",
+			expected:   true,
+		},
+		{
+			name:       "on basis point band edge",
+			trigger:    &BasisPointsTrigger{BandBps: 25},
+			onChainVal: "2000",
+			latestVal:  "1995",
+			expected:   false,
+		},
+		{
+			name:       "time scaled deviation before decay",
+			trigger:    &TimeScaledDeviationTrigger{InitialPercent: 2, FinalPercent: 0.2, DecayPeriod: 10 * time.Minute},
+			onChainTs:  baseNs,
+			onChainVal: "100",
+			latestTs:   baseNs + uint64(time.Minute),
+			latestVal:  "101",
+			expected:   false,
+		},
+		{
+			name:       "time scaled deviation after decay",
+			trigger:    &TimeScaledDeviationTrigger{InitialPercent: 2, FinalPercent: 0.2, DecayPeriod: 10 * time.Minute},
+			onChainTs:  baseNs,
+			onChainVal: "100",
+			latestTs:   baseNs + uint64(9*time.Minute),
+			latestVal:  "101",
+			expected:   true,
+		},
+		{
+			name:       "aligned heartbeat after boundary",
+			trigger:    &AlignedHeartbeatTrigger{Interval: time.Minute},
+			now:        base.Add(time.Minute),
+			onChainTs:  baseNs,
+			onChainVal: "100",
+			latestTs:   baseNs + uint64(31*time.Second),
+			latestVal:  "100",
+			expected:   true,
+		},
+		{
+			name:       "aligned heartbeat already pushed",
+			trigger:    &AlignedHeartbeatTrigger{Interval: time.Minute},
+			now:        base.Add(time.Minute),
+			onChainTs:  baseNs + uint64(31*time.Second),
+			onChainVal: "100",
+			latestTs:   baseNs + uint64(40*time.Second),
+			latestVal:  "100",
+			expected:   false,
+		},
+		{
+			name:       "aligned heartbeat without value after boundary",
+			trigger:    &AlignedHeartbeatTrigger{Interval: time.Minute},
+			now:        base.Add(time.Minute),
+			onChainTs:  baseNs,
+			onChainVal: "100",
+			latestTs:   baseNs + uint64(20*time.Second),
+			latestVal:  "100",
+			expected:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			onChain := InternalTemporalNumericValue{TimestampNs: tt.onChainTs, QuantizedValue: quantized(t, tt.onChainVal)}
+			latest := AggregatedSignedPrice{
+				TimestampNano: tt.latestTs,
+				StorkSignedPrice: &StorkSignedPrice{
+					QuantizedPrice: shared.QuantizedPrice(quantized(t, tt.latestVal).String()),
+				},
+			}
+
+			assert.Equal(t, tt.expected, tt.trigger.ShouldUpdate(tt.now, onChain, latest))
+		})
+	}
+}
+
+func TestQuietHours(t *testing.T) {
+	t.Parallel()
+
+	overnight := &QuietHours{StartMinute: 22 * 60, EndMinute: 6 * 60, Location: time.UTC}
+	daytime := &QuietHours{StartMinute: 9 * 60, EndMinute: 17 * 60, Location: time.UTC}
+
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 3, 2, hour, minute, 0, 0, time.UTC)
+	}
+
+	assert.True(t, overnight.Contains(at(23, 0)))
+	assert.True(t, overnight.Contains(at(5, 59)))
+	assert.False(t, overnight.Contains(at(6, 0)))
+	assert.False(t, overnight.Contains(at(12, 0)))
+	assert.True(t, daytime.Contains(at(9, 0)))
+	assert.False(t, daytime.Contains(at(17, 0)))
+}
+
+func TestEvaluateTriggers(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 2, 23, 0, 0, 0, time.UTC)
+	nowNs := uint64(now.UnixNano())
+	quiet := &QuietHours{StartMinute: 22 * 60, EndMinute: 6 * 60, Location: time.UTC}
+
+	onChain := InternalTemporalNumericValue{TimestampNs: nowNs - uint64(2*time.Minute), QuantizedValue: quantized(t, "100")}
+	moved := AggregatedSignedPrice{
+		TimestampNano:    nowNs,
+		StorkSignedPrice: &StorkSignedPrice{QuantizedPrice: shared.QuantizedPrice(quantized(t, "110").String())},
+	}
+
+	entry := AssetEntry{PercentChangeThreshold: 1, FallbackPeriodSecs: 3600}
+	reason, ok := entry.EvaluateTriggers(now, onChain, moved)
+	assert.True(t, ok)
+	assert.Equal(t, UpdateReasonDeviation, reason)
+
+	entry.QuietHours = quiet
+	_, ok = entry.EvaluateTriggers(now, onChain, moved)
+	assert.False(t, ok)
+
+	// heartbeats are still pushed within quiet hours
+	entry.FallbackPeriodSecs = 60
+	reason, ok = entry.EvaluateTriggers(now, onChain, moved)
+	assert.True(t, ok)
+	assert.Equal(t, UpdateReasonHeartbeat, reason)
+
+	// configured triggers replace the unset legacy fields instead of pushing on every change
+	entry = AssetEntry{Triggers: []TriggerSpec{{Type: "basis_points", Trigger: &BasisPointsTrigger{BandBps: 2000}}}}
+	_, ok = entry.EvaluateTriggers(now, onChain, moved)
+	assert.False(t, ok)
+}
+
+type constantTrigger struct {
+	Fire bool `yaml:"fire"`
+}
+
+func (c *constantTrigger) Reason() UpdateReason {
+	return UpdateReasonDeviation
+}
+
+func (c *constantTrigger) ShouldUpdate(time.Time, InternalTemporalNumericValue, AggregatedSignedPrice) bool {
+	return c.Fire
+}
+
+func TestLoadConfigTriggers(t *testing.T) {
+	t.Parallel()
+
+	RegisterUpdateTrigger("constant", func(unmarshal func(any) error) (UpdateTrigger, error) {
+		var trigger constantTrigger
+
+		err := unmarshal(&trigger)
+
+		return &trigger, err
+	})
+
+	tests := []struct {
+		name        string
+		fileContent string
+		check       func(t *testing.T, entry AssetEntry)
+		wantError   error
+	}{
+		{
+			name: "built in and custom triggers",
+			fileContent: `assets:
+  SOFR:
+    asset_id: SOFR
+    encoded_asset_id: "0x01"
+    triggers:
+      - type: absolute_deviation
+        threshold: "0.0005"
+      - type: basis_points
+        band_bps: 25
+      - type: time_scaled_deviation
+        initial_percent: 2
+        final_percent: 0.5
+        decay_period: 10m
+      - type: aligned_heartbeat
+        interval: 1m
+      - type: fallback_period
+        period: 1h
+      - type: constant
+        fire: true
+    quiet_hours:
+      start: "22:00"
+      end: "06:30"
+      timezone: America/New_York`,
+			check: func(t *testing.T, entry AssetEntry) {
+				t.Helper()
+
+				require.Len(t, entry.Triggers, 6)
+				assert.Equal(t, quantized(t, "0.0005"), entry.Triggers[0].Trigger.(*AbsoluteDeviationTrigger).Threshold)
+				assert.Equal(t, &BasisPointsTrigger{BandBps: 25}, entry.Triggers[1].Trigger)
+				assert.Equal(t, &TimeScaledDeviationTrigger{
+					InitialPercent: 2, FinalPercent: 0.5, DecayPeriod: 10 * time.Minute,
+				}, entry.Triggers[2].Trigger)
+				assert.Equal(t, &AlignedHeartbeatTrigger{Interval: time.Minute}, entry.Triggers[3].Trigger)
+				assert.Equal(t, &FallbackPeriodTrigger{Period: time.Hour}, entry.Triggers[4].Trigger)
+				assert.Equal(t, &constantTrigger{Fire: true}, entry.Triggers[5].Trigger)
+				assert.Equal(t, "constant", entry.Triggers[5].Type)
+				assert.Equal(t, 22*60, entry.QuietHours.StartMinute)
+				assert.Equal(t, 6*60+30, entry.QuietHours.EndMinute)
+				assert.Equal(t, "America/New_York", entry.QuietHours.Location.String())
+				// the unset legacy fields add no triggers
+				assert.Len(t, entry.UpdateTriggers(), 6)
+			},
+		},
+		{
+			name: "unknown trigger",
+			fileContent: `assets:
+  BTCUSD:
+    asset_id: BTCUSD
+    triggers:
+      - type: moon_phase`,
+			wantError: ErrUnknownUpdateTrigger,
+		},
+		{
+			name: "invalid trigger parameters",
+			fileContent: `assets:
+  BTCUSD:
+    asset_id: BTCUSD
+    triggers:
+      - type: aligned_heartbeat
+        interval: 0s`,
+			wantError: ErrInvalidUpdateTrigger,
+		},
+		{
+			name: "invalid quiet hours",
+			fileContent: `assets:
+  BTCUSD:
+    asset_id: BTCUSD
+    quiet_hours:
+      start: "25:00"
+      end: "06:00"`,
+			wantError: ErrInvalidQuietHours,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "asset-config.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.fileContent), 0o600))
+
+			config, err := LoadConfig(path)
+			if tt.wantError != nil {
+				require.ErrorIs(t, err, tt.wantError)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			for _, entry := range config.Assets {
+				tt.check(t, entry)
+			}
+		})
+	}
+}
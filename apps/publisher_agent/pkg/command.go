@@ -2,6 +2,8 @@ package publisher_agent
 
 import (
 	"fmt"
+	"log"
+	"net"
 	"net/http"
 	"time"
 
@@ -10,6 +12,8 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/pkgerrors"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 var PublisherAgentCmd = &cobra.Command{
@@ -48,6 +52,7 @@ func runPublisherAgent(cmd *cobra.Command, args []string) error {
 	mainLogger.Info().Msg("initializing publisher agent")
 
 	valueUpdateChannels := make([]chan ValueUpdate, 0)
+	healthReporters := make([]HealthReporter, 0)
 	var evmRunner *PublisherAgentRunner[*shared.EvmSignature]
 	var starkRunner *PublisherAgentRunner[*shared.StarkSignature]
 	for _, signatureType := range config.SignatureTypes {
@@ -71,6 +76,7 @@ func runPublisherAgent(cmd *cobra.Command, args []string) error {
 				logger,
 			)
 			valueUpdateChannels = append(valueUpdateChannels, evmRunner.ValueUpdateCh)
+			healthReporters = append(healthReporters, evmRunner)
 			go evmRunner.Run()
 		case shared.StarkSignatureType:
 			mainLogger.Info().Msg("Starting Stark runner")
@@ -100,6 +106,7 @@ func runPublisherAgent(cmd *cobra.Command, args []string) error {
 				logger,
 			)
 			valueUpdateChannels = append(valueUpdateChannels, starkRunner.ValueUpdateCh)
+			healthReporters = append(healthReporters, starkRunner)
 			go starkRunner.Run()
 		default:
 			return fmt.Errorf("invalid signature type: %s", signatureType)
@@ -119,17 +126,85 @@ func runPublisherAgent(cmd *cobra.Command, args []string) error {
 		go incomingWsPuller.Run()
 	}
 
+	ingester := NewValueUpdateIngester(valueUpdateChannels, IncomingLogger())
+
+	incomingAuthenticator, err := NewIncomingAuthenticator(
+		secrets.IncomingAuthToken,
+		secrets.IncomingHmacSecret,
+		config.IncomingIpAllowlist,
+		IncomingLogger(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create incoming authenticator: %v", err)
+	}
+
+	incomingTlsConfig, err := LoadIncomingTlsConfig(
+		config.IncomingTlsCertFile,
+		config.IncomingTlsKeyFile,
+		config.IncomingTlsClientCaFile,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to load incoming tls config: %v", err)
+	}
+
+	if config.IncomingGrpcPort > 0 {
+		listener, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%d", config.IncomingGrpcPort))
+		if err != nil {
+			return fmt.Errorf("failed to listen on incoming grpc port: %v", err)
+		}
+
+		grpcServerOptions := []grpc.ServerOption{grpc.StreamInterceptor(incomingAuthenticator.StreamInterceptor())}
+		if incomingTlsConfig != nil {
+			grpcServerOptions = append(grpcServerOptions, grpc.Creds(credentials.NewTLS(incomingTlsConfig)))
+		}
+
+		grpcServer := grpc.NewServer(grpcServerOptions...)
+		RegisterValueIngestionServer(grpcServer, ingester)
+		go func() {
+			mainLogger.Info().Msgf("starting incoming grpc server on port %d", config.IncomingGrpcPort)
+			err := grpcServer.Serve(listener)
+			mainLogger.Fatal().Err(err).Msg("incoming grpc server failed, process exiting")
+		}()
+	}
+
+	if config.MetricsPort > 0 {
+		metricsMux := http.NewServeMux()
+		RegisterMetricsHandlers(metricsMux, healthReporters)
+		go func() {
+			mainLogger.Info().Msgf("starting metrics http server on port %d", config.MetricsPort)
+			err := http.ListenAndServe(fmt.Sprintf("0.0.0.0:%d", config.MetricsPort), metricsMux)
+			mainLogger.Fatal().Err(err).Msg("metrics http server failed, process exiting")
+		}()
+	}
+
 	if config.IncomingWsPort > 0 {
-		http.HandleFunc("/publish", func(resp http.ResponseWriter, req *http.Request) {
+		incomingMux := http.NewServeMux()
+		incomingMux.HandleFunc("/publish", incomingAuthenticator.Wrap(func(resp http.ResponseWriter, req *http.Request) {
 			HandleNewIncomingWsConnection(
 				resp,
 				req,
 				IncomingLogger(),
 				valueUpdateChannels,
 			)
-		})
-		mainLogger.Info().Msgf("starting incoming http server on port %d", config.IncomingWsPort)
-		err = http.ListenAndServe(fmt.Sprintf("0.0.0.0:%d", config.IncomingWsPort), nil)
+		}))
+		incomingMux.HandleFunc("/v1/values", incomingAuthenticator.Wrap(ingester.HandleIngestValues))
+
+		incomingLogger := IncomingLogger()
+		server := &http.Server{
+			Addr:      fmt.Sprintf("0.0.0.0:%d", config.IncomingWsPort),
+			Handler:   incomingMux,
+			TLSConfig: incomingTlsConfig,
+			// logs failed tls handshakes, such as clients without a valid certificate, with the peer address
+			ErrorLog: log.New(&incomingLogger, "", 0),
+		}
+		if incomingTlsConfig != nil {
+			mainLogger.Info().Msgf("starting incoming https server on port %d", config.IncomingWsPort)
+			// the certificate is already loaded into the tls config
+			err = server.ListenAndServeTLS("", "")
+		} else {
+			mainLogger.Info().Msgf("starting incoming http server on port %d", config.IncomingWsPort)
+			err = server.ListenAndServe()
+		}
 		mainLogger.Fatal().Err(err).Msg("incoming http server failed, process exiting")
 	} else {
 		mainLogger.Info().Msg("Not running incoming http server because incoming ws port is not specified")
@@ -26,6 +26,7 @@ const (
 	DefaultBrokerReconnectDelay             = "5s"
 	DefaultPullBasedReconnectDelay          = "5s"
 	DefaultPullBasedReadTimeout             = "10s"
+	DefaultBrokerReplayBufferMaxAssets      = 10000
 )
 
 type Config struct {
@@ -36,6 +37,8 @@ type Config struct {
 	StorkRegistryBaseUrl             string
 	StorkRegistryRefreshInterval     string
 	BrokerReconnectDelay             string
+	BrokerReplayBufferTTL            string
+	BrokerReplayBufferMaxAssets      int
 	PublisherMetadataRefreshInterval string
 	PublisherMetadataBaseUrl         string
 	PullBasedWsUrl                   string
@@ -43,7 +46,14 @@ type Config struct {
 	PullBasedWsReconnectDelay        string
 	PullBasedWsReadTimeout           string
 	SignEveryUpdate                  bool
+	AssetSigningPolicies             []AssetSigningPolicyConfig
 	IncomingWsPort                   int
+	IncomingGrpcPort                 int
+	MetricsPort                      int
+	IncomingIpAllowlist              []string
+	IncomingTlsCertFile              string
+	IncomingTlsKeyFile               string
+	IncomingTlsClientCaFile          string
 	SeededBrokers                    []BrokerConnectionConfig
 }
 
@@ -54,6 +64,9 @@ type Keys struct {
 	StarkPublicKey  signer.StarkPublisherKey
 	OracleID        OracleID `json:"OracleId"` //nolint:tagliatelle // Backwards compatibility
 	PullBasedAuth   shared.AuthToken
+	// IncomingAuthToken and IncomingHmacSecret authenticate publishers connecting to the incoming endpoints
+	IncomingAuthToken  shared.AuthToken
+	IncomingHmacSecret string
 }
 
 // this overwrites
@@ -83,6 +96,16 @@ func (k *Keys) updateFromEnvVars() {
 	if pullBasedAuth != "" {
 		k.PullBasedAuth = shared.AuthToken(pullBasedAuth)
 	}
+
+	incomingAuthToken := os.Getenv("STORK_INCOMING_AUTH_TOKEN")
+	if incomingAuthToken != "" {
+		k.IncomingAuthToken = shared.AuthToken(incomingAuthToken)
+	}
+
+	incomingHmacSecret := os.Getenv("STORK_INCOMING_HMAC_SECRET")
+	if incomingHmacSecret != "" {
+		k.IncomingHmacSecret = incomingHmacSecret
+	}
 }
 
 func readFile(path string) ([]byte, error) {
@@ -205,6 +228,26 @@ func LoadConfig(
 		return nil, nil, errors.New("broker reconnect duration must be positive")
 	}
 
+	// the replay buffer is disabled unless a ttl is configured
+	var brokerReplayBufferTTL time.Duration
+	if len(configFile.BrokerReplayBufferTTL) > 0 {
+		brokerReplayBufferTTL, err = time.ParseDuration(configFile.BrokerReplayBufferTTL)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid broker replay buffer ttl: %s", configFile.BrokerReplayBufferTTL)
+		}
+	}
+	if brokerReplayBufferTTL < 0 {
+		return nil, nil, errors.New("broker replay buffer ttl must not be negative")
+	}
+
+	brokerReplayBufferMaxAssets := configFile.BrokerReplayBufferMaxAssets
+	if brokerReplayBufferMaxAssets == 0 {
+		brokerReplayBufferMaxAssets = DefaultBrokerReplayBufferMaxAssets
+	}
+	if brokerReplayBufferMaxAssets < 0 {
+		return nil, nil, errors.New("broker replay buffer max assets must be positive")
+	}
+
 	publisherMetadataUpdateIntervalStr := configFile.PublisherMetadataRefreshInterval
 	if len(publisherMetadataUpdateIntervalStr) == 0 {
 		publisherMetadataUpdateIntervalStr = DefaultPublisherMetadataRefreshInterval
@@ -225,16 +268,56 @@ func LoadConfig(
 		return nil, nil, errors.New("change threshold percent must be positive")
 	}
 
+	defaultSigningPolicy := SigningPolicy{
+		ClockPeriod:               clockUpdatePeriod,
+		ChangeThresholdProportion: changeThresholdPercent / 100.0,
+		SignEveryUpdate:           configFile.SignEveryUpdate,
+	}
+	assetSigningPolicies := make([]AssetSigningPolicy, 0, len(configFile.AssetSigningPolicies))
+	assetSigningPolicyPatterns := make(map[string]struct{})
+	for _, assetSigningPolicyConfig := range configFile.AssetSigningPolicies {
+		assetSigningPolicy, err := NewAssetSigningPolicy(assetSigningPolicyConfig, defaultSigningPolicy)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if _, exists := assetSigningPolicyPatterns[assetSigningPolicy.Pattern]; exists {
+			return nil, nil, fmt.Errorf("duplicate asset signing policy for %s", assetSigningPolicy.Pattern)
+		}
+		assetSigningPolicyPatterns[assetSigningPolicy.Pattern] = struct{}{}
+
+		assetSigningPolicies = append(assetSigningPolicies, assetSigningPolicy)
+	}
+
 	if configFile.IncomingWsPort > 65535 {
 		return nil, nil, errors.New("incoming ws port must be between 0 and 65535")
 	}
 
-	if configFile.IncomingWsPort == 0 && len(configFile.PullBasedWsUrl) == 0 {
+	if configFile.IncomingGrpcPort < 0 || configFile.IncomingGrpcPort > 65535 {
+		return nil, nil, errors.New("incoming grpc port must be between 0 and 65535")
+	}
+
+	if configFile.MetricsPort < 0 || configFile.MetricsPort > 65535 {
+		return nil, nil, errors.New("metrics port must be between 0 and 65535")
+	}
+
+	// metrics and health are served without authentication, so they must not share a port with the incoming endpoints
+	if configFile.MetricsPort > 0 &&
+		(configFile.MetricsPort == configFile.IncomingWsPort || configFile.MetricsPort == configFile.IncomingGrpcPort) {
+		return nil, nil, errors.New("metrics port must differ from the incoming ws and grpc ports")
+	}
+
+	if configFile.IncomingWsPort == 0 && configFile.IncomingGrpcPort == 0 && len(configFile.PullBasedWsUrl) == 0 {
 		return nil, nil, errors.New(
-			"must specify an incoming ws url to pull from or a port to expose for our incoming ws",
+			"must specify an incoming ws url to pull from or a port to expose for our incoming ws or grpc service",
 		)
 	}
 
+	_, err = parseIpAllowlist(configFile.IncomingIpAllowlist)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	pullBasedReconnectDelayStr := configFile.PullBasedWsReconnectDelay
 	if len(pullBasedReconnectDelayStr) == 0 {
 		pullBasedReconnectDelayStr = DefaultPullBasedReconnectDelay
@@ -274,6 +357,8 @@ func LoadConfig(
 		storkRegistryBaseUrl,
 		storkRegistryRefreshDuration,
 		brokerReconnectDelayDuration,
+		brokerReplayBufferTTL,
+		brokerReplayBufferMaxAssets,
 		publisherMetadataBaseUrl,
 		publisherMetadataUpdateDuration,
 		configFile.PullBasedWsUrl,
@@ -281,7 +366,14 @@ func LoadConfig(
 		pullBasedReconnectDuration,
 		pullBasedWsReadTimeout,
 		configFile.SignEveryUpdate,
+		assetSigningPolicies,
 		configFile.IncomingWsPort,
+		configFile.IncomingGrpcPort,
+		configFile.MetricsPort,
+		configFile.IncomingIpAllowlist,
+		configFile.IncomingTlsCertFile,
+		configFile.IncomingTlsKeyFile,
+		configFile.IncomingTlsClientCaFile,
 		configFile.SeededBrokers,
 	)
 
@@ -289,26 +381,34 @@ func LoadConfig(
 		keys.EvmPrivateKey,
 		keys.StarkPrivateKey,
 		keys.PullBasedAuth,
+		keys.IncomingAuthToken,
+		keys.IncomingHmacSecret,
 	)
 
 	return config, secrets, nil
 }
 
 type StorkPublisherAgentSecrets struct {
-	EvmPrivateKey   signer.EvmPrivateKey
-	StarkPrivateKey signer.StarkPrivateKey
-	PullBasedAuth   shared.AuthToken
+	EvmPrivateKey      signer.EvmPrivateKey
+	StarkPrivateKey    signer.StarkPrivateKey
+	PullBasedAuth      shared.AuthToken
+	IncomingAuthToken  shared.AuthToken
+	IncomingHmacSecret string
 }
 
 func NewStorkPublisherAgentSecrets(
 	evmPrivateKey signer.EvmPrivateKey,
 	starkPrivateKey signer.StarkPrivateKey,
 	pullBasedAuth shared.AuthToken,
+	incomingAuthToken shared.AuthToken,
+	incomingHmacSecret string,
 ) *StorkPublisherAgentSecrets {
 	return &StorkPublisherAgentSecrets{
-		EvmPrivateKey:   evmPrivateKey,
-		StarkPrivateKey: starkPrivateKey,
-		PullBasedAuth:   pullBasedAuth,
+		EvmPrivateKey:      evmPrivateKey,
+		StarkPrivateKey:    starkPrivateKey,
+		PullBasedAuth:      pullBasedAuth,
+		IncomingAuthToken:  incomingAuthToken,
+		IncomingHmacSecret: incomingHmacSecret,
 	}
 }
 
@@ -323,6 +423,8 @@ type StorkPublisherAgentConfig struct {
 	StorkRegistryBaseUrl            string
 	StorkRegistryRefreshInterval    time.Duration
 	BrokerReconnectDelay            time.Duration
+	BrokerReplayBufferTTL           time.Duration
+	BrokerReplayBufferMaxAssets     int
 	PublisherMetadataBaseUrl        string
 	PublisherMetadataUpdateInterval time.Duration
 	PullBasedWsUrl                  string
@@ -330,7 +432,14 @@ type StorkPublisherAgentConfig struct {
 	PullBasedWsReconnectDelay       time.Duration
 	PullBasedWsReadTimeout          time.Duration
 	SignEveryUpdate                 bool
+	AssetSigningPolicies            []AssetSigningPolicy
 	IncomingWsPort                  int
+	IncomingGrpcPort                int
+	MetricsPort                     int
+	IncomingIpAllowlist             []string
+	IncomingTlsCertFile             string
+	IncomingTlsKeyFile              string
+	IncomingTlsClientCaFile         string
 	SeededBrokers                   []BrokerConnectionConfig
 }
 
@@ -345,6 +454,8 @@ func NewStorkPublisherAgentConfig(
 	storkRegistryBaseUrl string,
 	storkRegistryRefreshInterval time.Duration,
 	brokerReconnectDelay time.Duration,
+	brokerReplayBufferTTL time.Duration,
+	brokerReplayBufferMaxAssets int,
 	publisherMetadataBaseUrl string,
 	publisherMetadataUpdateInterval time.Duration,
 	pullBasedWsUrl string,
@@ -352,7 +463,14 @@ func NewStorkPublisherAgentConfig(
 	pullBasedWsReconnectDelay time.Duration,
 	pullBasedWsReadTimeout time.Duration,
 	signEveryUpdate bool,
+	assetSigningPolicies []AssetSigningPolicy,
 	incomingWsPort int,
+	incomingGrpcPort int,
+	metricsPort int,
+	incomingIpAllowlist []string,
+	incomingTlsCertFile string,
+	incomingTlsKeyFile string,
+	incomingTlsClientCaFile string,
 	seededBrokers []BrokerConnectionConfig,
 ) *StorkPublisherAgentConfig {
 	return &StorkPublisherAgentConfig{
@@ -366,6 +484,8 @@ func NewStorkPublisherAgentConfig(
 		StorkRegistryBaseUrl:            storkRegistryBaseUrl,
 		StorkRegistryRefreshInterval:    storkRegistryRefreshInterval,
 		BrokerReconnectDelay:            brokerReconnectDelay,
+		BrokerReplayBufferTTL:           brokerReplayBufferTTL,
+		BrokerReplayBufferMaxAssets:     brokerReplayBufferMaxAssets,
 		PublisherMetadataBaseUrl:        publisherMetadataBaseUrl,
 		PublisherMetadataUpdateInterval: publisherMetadataUpdateInterval,
 		PullBasedWsUrl:                  pullBasedWsUrl,
@@ -373,7 +493,14 @@ func NewStorkPublisherAgentConfig(
 		PullBasedWsReconnectDelay:       pullBasedWsReconnectDelay,
 		PullBasedWsReadTimeout:          pullBasedWsReadTimeout,
 		SignEveryUpdate:                 signEveryUpdate,
+		AssetSigningPolicies:            assetSigningPolicies,
 		IncomingWsPort:                  incomingWsPort,
+		IncomingGrpcPort:                incomingGrpcPort,
+		MetricsPort:                     metricsPort,
+		IncomingIpAllowlist:             incomingIpAllowlist,
+		IncomingTlsCertFile:             incomingTlsCertFile,
+		IncomingTlsKeyFile:              incomingTlsKeyFile,
+		IncomingTlsClientCaFile:         incomingTlsClientCaFile,
 		SeededBrokers:                   seededBrokers,
 	}
 }
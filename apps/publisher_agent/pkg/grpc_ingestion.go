@@ -0,0 +1,263 @@
+package publisher_agent
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Stork-Oracle/stork-external/shared"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	ValueIngestionProtoFile     = "stork/publisher_agent/v1/value_ingestion.proto"
+	ValueIngestionServiceName   = "stork.publisher_agent.v1.ValueIngestion"
+	PublishValuesStreamName     = "PublishValues"
+	publishValuesRequestName    = "stork.publisher_agent.v1.PublishValuesRequest"
+	publishValuesResponseName   = "stork.publisher_agent.v1.PublishValuesResponse"
+	valueIngestionPackageName   = "stork.publisher_agent.v1"
+	valueIngestionStructImport  = "google/protobuf/struct.proto"
+	valueIngestionStructMessage = ".google.protobuf.Struct"
+)
+
+// valueIngestionFile describes the messages of the ValueIngestion service, matching
+// apps/publisher_agent/proto/stork/publisher_agent/v1/value_ingestion.proto. It is built here rather than generated,
+// and the messages are handled as dynamic messages. TestValueIngestionFileMatchesProto fails if the two diverge.
+//
+//nolint:gochecknoglobals
+var valueIngestionFile = mustBuildValueIngestionFile()
+
+func descriptorField(
+	name string,
+	number int32,
+	fieldType descriptorpb.FieldDescriptorProto_Type,
+	label descriptorpb.FieldDescriptorProto_Label,
+	typeName string,
+) *descriptorpb.FieldDescriptorProto {
+	fieldDescriptor := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		Number:   proto.Int32(number),
+		Type:     fieldType.Enum(),
+		Label:    label.Enum(),
+		JsonName: proto.String(name),
+	}
+	if typeName != "" {
+		fieldDescriptor.TypeName = proto.String(typeName)
+	}
+
+	return fieldDescriptor
+}
+
+func mustBuildValueIngestionFile() protoreflect.FileDescriptor {
+	optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+	repeated := descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+
+	fileDescriptorProto := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String(ValueIngestionProtoFile),
+		Package:    proto.String(valueIngestionPackageName),
+		Dependency: []string{valueIngestionStructImport},
+		Syntax:     proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("ValueUpdate"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					descriptorField("publish_timestamp_nano", 1, descriptorpb.FieldDescriptorProto_TYPE_INT64, optional, ""),
+					descriptorField("asset", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, optional, ""),
+					descriptorField("value", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING, optional, ""),
+					descriptorField(
+						"metadata", 4, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, optional, valueIngestionStructMessage,
+					),
+				},
+			},
+			{
+				Name: proto.String("PublishValuesRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					descriptorField(
+						"updates", 1, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, repeated,
+						"."+valueIngestionPackageName+".ValueUpdate",
+					),
+				},
+			},
+			{
+				Name: proto.String("IngestionError"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					descriptorField("index", 1, descriptorpb.FieldDescriptorProto_TYPE_UINT32, optional, ""),
+					descriptorField("error", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, optional, ""),
+				},
+			},
+			{
+				Name: proto.String("PublishValuesResponse"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					descriptorField("accepted", 1, descriptorpb.FieldDescriptorProto_TYPE_UINT32, optional, ""),
+					descriptorField(
+						"errors", 2, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, repeated,
+						"."+valueIngestionPackageName+".IngestionError",
+					),
+				},
+			},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{
+			{
+				Name: proto.String("ValueIngestion"),
+				Method: []*descriptorpb.MethodDescriptorProto{
+					{
+						Name:            proto.String(PublishValuesStreamName),
+						InputType:       proto.String("." + publishValuesRequestName),
+						OutputType:      proto.String("." + publishValuesResponseName),
+						ClientStreaming: proto.Bool(true),
+						ServerStreaming: proto.Bool(true),
+					},
+				},
+			},
+		},
+	}
+
+	// google/protobuf/struct.proto is resolved from the registry, where importing structpb registered it
+	fileDescriptor, err := protodesc.NewFile(fileDescriptorProto, protoregistry.GlobalFiles)
+	if err != nil {
+		panic(fmt.Sprintf("invalid value ingestion descriptor: %v", err))
+	}
+
+	// registered so that the reflection service can describe the service to clients such as grpcurl
+	err = protoregistry.GlobalFiles.RegisterFile(fileDescriptor)
+	if err != nil {
+		panic(fmt.Sprintf("failed to register value ingestion descriptor: %v", err))
+	}
+
+	return fileDescriptor
+}
+
+func valueIngestionMessage(name protoreflect.FullName) protoreflect.MessageDescriptor {
+	return valueIngestionFile.Messages().ByName(name.Name())
+}
+
+// RegisterValueIngestionServer registers the ValueIngestion service, which streams batches of value updates into the
+// ingester and responds to each batch with the errors of the updates that were not accepted.
+func RegisterValueIngestionServer(server *grpc.Server, ingester *ValueUpdateIngester) {
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: ValueIngestionServiceName,
+		HandlerType: (*any)(nil),
+		Methods:     []grpc.MethodDesc{},
+		Streams: []grpc.StreamDesc{
+			{
+				StreamName: PublishValuesStreamName,
+				Handler: func(_ any, stream grpc.ServerStream) error {
+					return publishValues(ingester, stream)
+				},
+				ServerStreams: true,
+				ClientStreams: true,
+			},
+		},
+		Metadata: ValueIngestionProtoFile,
+	}, nil)
+	reflection.Register(server)
+}
+
+func publishValues(ingester *ValueUpdateIngester, stream grpc.ServerStream) error {
+	requestDescriptor := valueIngestionMessage(publishValuesRequestName)
+	responseDescriptor := valueIngestionMessage(publishValuesResponseName)
+
+	for {
+		request := dynamicpb.NewMessage(requestDescriptor)
+
+		err := stream.RecvMsg(request)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to receive value updates: %w", err)
+		}
+
+		valueUpdates, err := valueUpdatesFromRequest(request)
+		if err != nil {
+			return fmt.Errorf("failed to read value updates: %w", err)
+		}
+
+		err = stream.SendMsg(ingestionResponseMessage(responseDescriptor, ingester.Ingest(valueUpdates)))
+		if err != nil {
+			return fmt.Errorf("failed to send ingestion response: %w", err)
+		}
+	}
+}
+
+func valueUpdatesFromRequest(request protoreflect.Message) ([]ValueUpdatePushWebsocket, error) {
+	updates := request.Get(request.Descriptor().Fields().ByName("updates")).List()
+	valueUpdates := make([]ValueUpdatePushWebsocket, 0, updates.Len())
+
+	for i := range updates.Len() {
+		update := updates.Get(i).Message()
+		fields := update.Descriptor().Fields()
+
+		valueUpdate := ValueUpdatePushWebsocket{
+			PublishTimestampNano: update.Get(fields.ByName("publish_timestamp_nano")).Int(),
+			Asset:                shared.AssetID(update.Get(fields.ByName("asset")).String()),
+			Value:                update.Get(fields.ByName("value")).String(),
+		}
+
+		metadataField := fields.ByName("metadata")
+		if update.Has(metadataField) {
+			metadata, err := metadataFromStruct(update.Get(metadataField).Message().Interface())
+			if err != nil {
+				return nil, err
+			}
+
+			valueUpdate.Metadata = metadata
+		}
+
+		valueUpdates = append(valueUpdates, valueUpdate)
+	}
+
+	return valueUpdates, nil
+}
+
+// metadataFromStruct converts a google.protobuf.Struct, which may be a dynamic message, to metadata.
+func metadataFromStruct(message proto.Message) (Metadata, error) {
+	metadata, ok := message.(*structpb.Struct)
+	if !ok {
+		encoded, err := proto.Marshal(message)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode metadata: %w", err)
+		}
+
+		metadata = &structpb.Struct{}
+
+		err = proto.Unmarshal(encoded, metadata)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode metadata: %w", err)
+		}
+	}
+
+	return metadata.AsMap(), nil
+}
+
+func ingestionResponseMessage(
+	responseDescriptor protoreflect.MessageDescriptor,
+	ingestionResponse IngestionResponse,
+) *dynamicpb.Message {
+	response := dynamicpb.NewMessage(responseDescriptor)
+	fields := responseDescriptor.Fields()
+
+	//nolint:gosec // Batches never hold anywhere near 2^32 updates.
+	response.Set(fields.ByName("accepted"), protoreflect.ValueOfUint32(uint32(ingestionResponse.Accepted)))
+
+	errorList := response.Mutable(fields.ByName("errors")).List()
+	for _, ingestionError := range ingestionResponse.Errors {
+		element := errorList.NewElement()
+		errorFields := element.Message().Descriptor().Fields()
+		//nolint:gosec // Batches never hold anywhere near 2^32 updates.
+		element.Message().Set(errorFields.ByName("index"), protoreflect.ValueOfUint32(uint32(ingestionError.Index)))
+		element.Message().Set(errorFields.ByName("error"), protoreflect.ValueOfString(ingestionError.Error))
+		errorList.Append(element)
+	}
+
+	return response
+}
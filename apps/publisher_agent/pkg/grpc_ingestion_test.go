@@ -0,0 +1,126 @@
+package publisher_agent
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const valueIngestionProtoPath = "../proto/" + ValueIngestionProtoFile
+
+//nolint:gochecknoglobals
+var (
+	protoCommentRegex = regexp.MustCompile(`//[^\n]*`)
+	protoPackageRegex = regexp.MustCompile(`package\s+([\w.]+)\s*;`)
+	protoImportRegex  = regexp.MustCompile(`import\s+"([^"]+)"\s*;`)
+	protoMessageRegex = regexp.MustCompile(`message\s+(\w+)\s*\{([^}]*)\}`)
+	protoFieldRegex   = regexp.MustCompile(`(repeated\s+)?([\w.]+)\s+(\w+)\s*=\s*(\d+)\s*;`)
+	protoServiceRegex = regexp.MustCompile(`service\s+(\w+)\s*\{([^}]*)\}`)
+	protoRpcRegex     = regexp.MustCompile(
+		`rpc\s+(\w+)\s*\(\s*(stream\s+)?([\w.]+)\s*\)\s*returns\s*\(\s*(stream\s+)?([\w.]+)\s*\)`,
+	)
+
+	protoScalarKinds = map[string]protoreflect.Kind{
+		"bool":   protoreflect.BoolKind,
+		"int32":  protoreflect.Int32Kind,
+		"int64":  protoreflect.Int64Kind,
+		"uint32": protoreflect.Uint32Kind,
+		"uint64": protoreflect.Uint64Kind,
+		"double": protoreflect.DoubleKind,
+		"float":  protoreflect.FloatKind,
+		"string": protoreflect.StringKind,
+		"bytes":  protoreflect.BytesKind,
+	}
+)
+
+// TestValueIngestionFileMatchesProto checks the hand built descriptor against the .proto clients are generated from,
+// which only uses messages of scalar, message and repeated fields and a single service.
+func TestValueIngestionFileMatchesProto(t *testing.T) {
+	t.Parallel()
+
+	content, err := os.ReadFile(valueIngestionProtoPath)
+	require.NoError(t, err)
+
+	source := protoCommentRegex.ReplaceAllString(string(content), "")
+
+	packageMatch := protoPackageRegex.FindStringSubmatch(source)
+	require.NotNil(t, packageMatch)
+	assert.Equal(t, protoreflect.FullName(packageMatch[1]), valueIngestionFile.Package())
+
+	imports := valueIngestionFile.Imports()
+	importMatches := protoImportRegex.FindAllStringSubmatch(source, -1)
+	require.Len(t, importMatches, imports.Len())
+
+	for i, importMatch := range importMatches {
+		assert.Equal(t, importMatch[1], imports.Get(i).Path())
+	}
+
+	resolveType := func(name string) protoreflect.FullName {
+		if strings.Contains(name, ".") {
+			return protoreflect.FullName(name)
+		}
+
+		return valueIngestionFile.Package().Append(protoreflect.Name(name))
+	}
+
+	messages := valueIngestionFile.Messages()
+	messageMatches := protoMessageRegex.FindAllStringSubmatch(source, -1)
+	require.Len(t, messageMatches, messages.Len())
+
+	for _, messageMatch := range messageMatches {
+		message := messages.ByName(protoreflect.Name(messageMatch[1]))
+		require.NotNil(t, message, "message %s", messageMatch[1])
+
+		fields := message.Fields()
+		fieldMatches := protoFieldRegex.FindAllStringSubmatch(messageMatch[2], -1)
+		require.Len(t, fieldMatches, fields.Len(), "fields of %s", messageMatch[1])
+
+		for _, fieldMatch := range fieldMatches {
+			field := fields.ByName(protoreflect.Name(fieldMatch[3]))
+			require.NotNil(t, field, "field %s.%s", messageMatch[1], fieldMatch[3])
+
+			number, err := strconv.Atoi(fieldMatch[4])
+			require.NoError(t, err)
+			assert.Equal(t, protoreflect.FieldNumber(number), field.Number(), field.FullName())
+			assert.Equal(t, fieldMatch[1] != "", field.IsList(), field.FullName())
+
+			if kind, ok := protoScalarKinds[fieldMatch[2]]; ok {
+				assert.Equal(t, kind, field.Kind(), field.FullName())
+			} else {
+				require.Equal(t, protoreflect.MessageKind, field.Kind(), field.FullName())
+				assert.Equal(t, resolveType(fieldMatch[2]), field.Message().FullName(), field.FullName())
+			}
+		}
+	}
+
+	services := valueIngestionFile.Services()
+	serviceMatches := protoServiceRegex.FindAllStringSubmatch(source, -1)
+	require.Len(t, serviceMatches, services.Len())
+
+	for _, serviceMatch := range serviceMatches {
+		service := services.ByName(protoreflect.Name(serviceMatch[1]))
+		require.NotNil(t, service, "service %s", serviceMatch[1])
+
+		methods := service.Methods()
+		rpcMatches := protoRpcRegex.FindAllStringSubmatch(serviceMatch[2], -1)
+		require.Len(t, rpcMatches, methods.Len(), "methods of %s", serviceMatch[1])
+
+		for _, rpcMatch := range rpcMatches {
+			method := methods.ByName(protoreflect.Name(rpcMatch[1]))
+			require.NotNil(t, method, "method %s", rpcMatch[1])
+
+			assert.Equal(t, rpcMatch[2] != "", method.IsStreamingClient(), method.FullName())
+			assert.Equal(t, resolveType(rpcMatch[3]), method.Input().FullName(), method.FullName())
+			assert.Equal(t, rpcMatch[4] != "", method.IsStreamingServer(), method.FullName())
+			assert.Equal(t, resolveType(rpcMatch[5]), method.Output().FullName(), method.FullName())
+		}
+	}
+
+	assert.Equal(t, ValueIngestionServiceName, string(services.Get(0).FullName()))
+}
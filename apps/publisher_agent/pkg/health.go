@@ -0,0 +1,216 @@
+package publisher_agent
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Stork-Oracle/stork-external/shared"
+)
+
+type HealthReporter interface {
+	Health() RunnerHealthStatus
+}
+
+type BrokerHealthStatus struct {
+	PublishUrl BrokerPublishUrl `json:"publish_url"`
+	Connected  bool             `json:"connected"`
+	// SecondsSinceLastSent is nil until signed prices have been sent to the broker
+	SecondsSinceLastSent *float64 `json:"seconds_since_last_sent"`
+}
+
+type RunnerHealthStatus struct {
+	SignatureType          shared.SignatureType `json:"signature_type"`
+	LastRegistryRefresh    *time.Time           `json:"last_registry_refresh"`
+	LastRegistryRefreshErr string               `json:"last_registry_refresh_error,omitempty"`
+	Brokers                []BrokerHealthStatus `json:"brokers"`
+}
+
+// Healthy reports whether the runner is connected to at least one broker.
+func (s RunnerHealthStatus) Healthy() bool {
+	return slices.ContainsFunc(s.Brokers, func(broker BrokerHealthStatus) bool {
+		return broker.Connected
+	})
+}
+
+type HealthResponse struct {
+	Healthy bool                 `json:"healthy"`
+	Runners []RunnerHealthStatus `json:"runners"`
+}
+
+type brokerHealth struct {
+	connected bool
+	lastSent  time.Time
+}
+
+// RunnerHealth tracks the broker connections and registry refreshes of a runner, for the health endpoint and the
+// broker and registry metrics. A nil RunnerHealth records nothing.
+type RunnerHealth struct {
+	signatureType          shared.SignatureType
+	lock                   sync.Mutex
+	brokers                map[BrokerPublishUrl]*brokerHealth
+	lastRegistryRefresh    time.Time
+	lastRegistryRefreshErr error
+}
+
+func NewRunnerHealth(signatureType shared.SignatureType) *RunnerHealth {
+	return &RunnerHealth{
+		signatureType: signatureType,
+		lock:          sync.Mutex{},
+		brokers:       make(map[BrokerPublishUrl]*brokerHealth),
+	}
+}
+
+func (h *RunnerHealth) broker(url BrokerPublishUrl) *brokerHealth {
+	broker, exists := h.brokers[url]
+	if !exists {
+		broker = &brokerHealth{}
+		h.brokers[url] = broker
+	}
+
+	return broker
+}
+
+func (h *RunnerHealth) SetBrokerConnected(url BrokerPublishUrl, connected bool) {
+	if h == nil {
+		return
+	}
+
+	h.lock.Lock()
+	h.broker(url).connected = connected
+	h.lock.Unlock()
+
+	value := 0.0
+	if connected {
+		value = 1
+	}
+
+	brokerConnected.WithLabelValues(string(h.signatureType), string(url)).Set(value)
+}
+
+// RemoveBroker forgets a broker that is no longer assigned to the publisher.
+func (h *RunnerHealth) RemoveBroker(url BrokerPublishUrl) {
+	if h == nil {
+		return
+	}
+
+	h.lock.Lock()
+	delete(h.brokers, url)
+	h.lock.Unlock()
+
+	labels := []string{string(h.signatureType), string(url)}
+	brokerConnected.DeleteLabelValues(labels...)
+	brokerLastSentTimestamp.DeleteLabelValues(labels...)
+	brokerQueueDepth.DeleteLabelValues(labels...)
+	droppedBrokerBatchesTotal.DeleteLabelValues(labels...)
+	replayedUpdatesTotal.DeleteLabelValues(labels...)
+}
+
+func (h *RunnerHealth) RecordSent(url BrokerPublishUrl, now time.Time) {
+	if h == nil {
+		return
+	}
+
+	h.lock.Lock()
+	h.broker(url).lastSent = now
+	h.lock.Unlock()
+
+	brokerLastSentTimestamp.WithLabelValues(string(h.signatureType), string(url)).Set(float64(now.UnixNano()) / 1e9)
+}
+
+func (h *RunnerHealth) RecordDropped(url BrokerPublishUrl) {
+	if h == nil {
+		return
+	}
+
+	droppedBrokerBatchesTotal.WithLabelValues(string(h.signatureType), string(url)).Inc()
+}
+
+func (h *RunnerHealth) RecordReplayed(url BrokerPublishUrl, numUpdates int) {
+	if h == nil {
+		return
+	}
+
+	replayedUpdatesTotal.WithLabelValues(string(h.signatureType), string(url)).Add(float64(numUpdates))
+}
+
+func (h *RunnerHealth) RecordRegistryRefresh(now time.Time, err error) {
+	if h == nil {
+		return
+	}
+
+	h.lock.Lock()
+	h.lastRegistryRefreshErr = err
+	if err == nil {
+		h.lastRegistryRefresh = now
+	}
+	h.lock.Unlock()
+
+	if err != nil {
+		registryRefreshesTotal.WithLabelValues(string(h.signatureType), "failure").Inc()
+		return
+	}
+
+	registryRefreshesTotal.WithLabelValues(string(h.signatureType), "success").Inc()
+	registryLastSuccessTimestamp.WithLabelValues(string(h.signatureType)).Set(float64(now.UnixNano()) / 1e9)
+}
+
+func (h *RunnerHealth) Status(now time.Time) RunnerHealthStatus {
+	status := RunnerHealthStatus{Brokers: []BrokerHealthStatus{}}
+	if h == nil {
+		return status
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	status.SignatureType = h.signatureType
+
+	if !h.lastRegistryRefresh.IsZero() {
+		lastRegistryRefresh := h.lastRegistryRefresh
+		status.LastRegistryRefresh = &lastRegistryRefresh
+	}
+
+	if h.lastRegistryRefreshErr != nil {
+		status.LastRegistryRefreshErr = h.lastRegistryRefreshErr.Error()
+	}
+
+	for url, broker := range h.brokers {
+		brokerStatus := BrokerHealthStatus{PublishUrl: url, Connected: broker.connected}
+		if !broker.lastSent.IsZero() {
+			secondsSinceLastSent := now.Sub(broker.lastSent).Seconds()
+			brokerStatus.SecondsSinceLastSent = &secondsSinceLastSent
+		}
+
+		status.Brokers = append(status.Brokers, brokerStatus)
+	}
+
+	slices.SortFunc(status.Brokers, func(a, b BrokerHealthStatus) int {
+		return strings.Compare(string(a.PublishUrl), string(b.PublishUrl))
+	})
+
+	return status
+}
+
+// NewHealthHandler reports the health of every runner, responding 503 unless each runner is connected to at least
+// one broker.
+func NewHealthHandler(runners []HealthReporter) http.HandlerFunc {
+	return func(resp http.ResponseWriter, req *http.Request) {
+		response := HealthResponse{Healthy: true, Runners: make([]RunnerHealthStatus, 0, len(runners))}
+		for _, runner := range runners {
+			status := runner.Health()
+			response.Healthy = response.Healthy && status.Healthy()
+			response.Runners = append(response.Runners, status)
+		}
+
+		resp.Header().Set("Content-Type", "application/json")
+		if !response.Healthy {
+			resp.WriteHeader(http.StatusServiceUnavailable)
+		}
+
+		_ = json.NewEncoder(resp).Encode(response)
+	}
+}
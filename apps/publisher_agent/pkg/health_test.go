@@ -0,0 +1,104 @@
+package publisher_agent
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Stork-Oracle/stork-external/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunnerHealthStatus(t *testing.T) {
+	t.Parallel()
+
+	broker1 := BrokerPublishUrl("wss://broker1.example.com")
+	broker2 := BrokerPublishUrl("wss://broker2.example.com")
+	now := time.Now()
+
+	health := NewRunnerHealth(shared.EvmSignatureType)
+	health.RecordRegistryRefresh(now.Add(-time.Minute), nil)
+	health.RecordRegistryRefresh(now, errors.New("registry unavailable"))
+	health.SetBrokerConnected(broker2, false)
+	health.SetBrokerConnected(broker1, true)
+	health.RecordSent(broker1, now.Add(-2*time.Second))
+
+	status := health.Status(now)
+	assert.True(t, status.Healthy())
+	assert.Equal(t, shared.EvmSignatureType, status.SignatureType)
+	require.NotNil(t, status.LastRegistryRefresh)
+	assert.Equal(t, now.Add(-time.Minute), *status.LastRegistryRefresh)
+	assert.Equal(t, "registry unavailable", status.LastRegistryRefreshErr)
+
+	require.Len(t, status.Brokers, 2)
+	assert.Equal(t, broker1, status.Brokers[0].PublishUrl)
+	assert.True(t, status.Brokers[0].Connected)
+	require.NotNil(t, status.Brokers[0].SecondsSinceLastSent)
+	assert.InDelta(t, 2, *status.Brokers[0].SecondsSinceLastSent, 1e-9)
+	assert.Equal(t, broker2, status.Brokers[1].PublishUrl)
+	assert.False(t, status.Brokers[1].Connected)
+	assert.Nil(t, status.Brokers[1].SecondsSinceLastSent)
+
+	health.SetBrokerConnected(broker1, false)
+	assert.False(t, health.Status(now).Healthy())
+
+	health.RemoveBroker(broker1)
+	health.RemoveBroker(broker2)
+	assert.Empty(t, health.Status(now).Brokers)
+}
+
+type staticHealthReporter RunnerHealthStatus
+
+func (s staticHealthReporter) Health() RunnerHealthStatus {
+	return RunnerHealthStatus(s)
+}
+
+func TestHealthHandler(t *testing.T) {
+	t.Parallel()
+
+	connected := staticHealthReporter{
+		SignatureType: shared.EvmSignatureType,
+		Brokers:       []BrokerHealthStatus{{PublishUrl: "wss://broker1.example.com", Connected: true}},
+	}
+	disconnected := staticHealthReporter{
+		SignatureType: shared.StarkSignatureType,
+		Brokers:       []BrokerHealthStatus{{PublishUrl: "wss://broker1.example.com", Connected: false}},
+	}
+
+	tests := []struct {
+		name           string
+		runners        []HealthReporter
+		expectedStatus int
+	}{
+		{
+			name:           "all runners connected",
+			runners:        []HealthReporter{connected},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "a runner without a connected broker",
+			runners:        []HealthReporter{connected, disconnected},
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := httptest.NewRecorder()
+			NewHealthHandler(tt.runners)(recorder, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+
+			var response HealthResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedStatus == http.StatusOK, response.Healthy)
+			assert.Len(t, response.Runners, len(tt.runners))
+		})
+	}
+}
@@ -0,0 +1,391 @@
+package publisher_agent
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Stork-Oracle/stork-external/shared"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	HmacTimestampHeader = "X-Stork-Timestamp"
+	HmacNonceHeader     = "X-Stork-Nonce"
+	HmacSignatureHeader = "X-Stork-Signature"
+	HmacMaxClockSkew    = 30 * time.Second
+	HmacMaxNonceLength  = 128
+)
+
+var (
+	ErrPeerNotAllowed      = errors.New("peer address is not in the ip allowlist")
+	ErrMissingCredentials  = errors.New("missing bearer token or hmac signature")
+	ErrInvalidBearerToken  = errors.New("invalid bearer token")
+	ErrInvalidHmacRequest  = errors.New("invalid hmac signature")
+	ErrStaleHmacTimestamp  = errors.New("hmac timestamp is too far from the current time")
+	ErrInvalidHmacNonce    = errors.New("hmac nonce must be between 1 and 128 characters")
+	ErrReplayedHmacNonce   = errors.New("hmac nonce was already used")
+	ErrMissingTlsKeyPair   = errors.New("incoming tls cert file and key file must be set together")
+	ErrClientCaWithoutTls  = errors.New("incoming tls client ca file requires an incoming tls cert file")
+	ErrInvalidClientCaFile = errors.New("incoming tls client ca file contains no certificates")
+)
+
+// IncomingAuthenticator authenticates connections to the incoming endpoints. A peer must be in the ip allowlist if one
+// is set, and must present the bearer token or an hmac signature if either is configured. A nil IncomingAuthenticator
+// accepts every connection. Client certificates are verified by the tls config instead, see LoadIncomingTlsConfig.
+type IncomingAuthenticator struct {
+	bearerToken     shared.AuthToken
+	hmacSecret      []byte
+	hmacNonces      *hmacNonceCache
+	allowedNetworks []*net.IPNet
+	logger          zerolog.Logger
+}
+
+// NewIncomingAuthenticator returns nil if no bearer token, hmac secret or ip allowlist is set.
+func NewIncomingAuthenticator(
+	bearerToken shared.AuthToken,
+	hmacSecret string,
+	ipAllowlist []string,
+	logger zerolog.Logger,
+) (*IncomingAuthenticator, error) {
+	if len(bearerToken) == 0 && len(hmacSecret) == 0 && len(ipAllowlist) == 0 {
+		return nil, nil //nolint:nilnil // Authentication is disabled.
+	}
+
+	allowedNetworks, err := parseIpAllowlist(ipAllowlist)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IncomingAuthenticator{
+		bearerToken:     bearerToken,
+		hmacSecret:      []byte(hmacSecret),
+		hmacNonces:      newHmacNonceCache(),
+		allowedNetworks: allowedNetworks,
+		logger:          logger,
+	}, nil
+}
+
+// parseIpAllowlist accepts both single addresses and CIDR ranges.
+func parseIpAllowlist(ipAllowlist []string) ([]*net.IPNet, error) {
+	allowedNetworks := make([]*net.IPNet, 0, len(ipAllowlist))
+
+	for _, entry := range ipAllowlist {
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid ip allowlist entry: %s", entry)
+			}
+
+			allowedNetworks = append(allowedNetworks, &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)})
+
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ip allowlist entry: %s", entry)
+		}
+
+		allowedNetworks = append(allowedNetworks, network)
+	}
+
+	return allowedNetworks, nil
+}
+
+func (a *IncomingAuthenticator) checkPeer(peerAddress string) error {
+	if len(a.allowedNetworks) == 0 {
+		return nil
+	}
+
+	host, _, err := net.SplitHostPort(peerAddress)
+	if err != nil {
+		host = peerAddress
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return ErrPeerNotAllowed
+	}
+
+	for _, network := range a.allowedNetworks {
+		if network.Contains(ip) {
+			return nil
+		}
+	}
+
+	return ErrPeerNotAllowed
+}
+
+func (a *IncomingAuthenticator) requiresCredentials() bool {
+	return len(a.bearerToken) > 0 || len(a.hmacSecret) > 0
+}
+
+func (a *IncomingAuthenticator) checkBearerToken(authorization string) error {
+	token, found := strings.CutPrefix(authorization, "Bearer ")
+	if !found || len(a.bearerToken) == 0 {
+		return ErrInvalidBearerToken
+	}
+
+	if subtle.ConstantTimeCompare([]byte(token), []byte(a.bearerToken)) != 1 {
+		return ErrInvalidBearerToken
+	}
+
+	return nil
+}
+
+// HmacSignature signs a request as hex(HMAC-SHA256(secret, timestamp + "\n" + nonce + "\n" + method + "\n" + path +
+// "\n" + hex(SHA256(body)))), where timestamp is the unix time in seconds sent in the X-Stork-Timestamp header and
+// nonce is a value unique to the request sent in the X-Stork-Nonce header.
+func HmacSignature(secret []byte, timestamp string, nonce string, method string, path string, body []byte) string {
+	bodyHash := sha256.Sum256(body)
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp + "\n" + nonce + "\n" + method + "\n" + path + "\n" + hex.EncodeToString(bodyHash[:])))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (a *IncomingAuthenticator) checkHmacSignature(req *http.Request, now time.Time) error {
+	if len(a.hmacSecret) == 0 {
+		return ErrInvalidHmacRequest
+	}
+
+	timestamp := req.Header.Get(HmacTimestampHeader)
+
+	timestampSeconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidHmacRequest
+	}
+
+	signedAt := time.Unix(timestampSeconds, 0)
+
+	skew := now.Sub(signedAt)
+	if skew > HmacMaxClockSkew || skew < -HmacMaxClockSkew {
+		return ErrStaleHmacTimestamp
+	}
+
+	nonce := req.Header.Get(HmacNonceHeader)
+	if len(nonce) == 0 || len(nonce) > HmacMaxNonceLength {
+		return ErrInvalidHmacNonce
+	}
+
+	var body []byte
+	if req.Body != nil {
+		body, err = io.ReadAll(io.LimitReader(req.Body, MaxIngestionRequestBytes+1))
+		if err != nil {
+			return fmt.Errorf("failed to read request body: %w", err)
+		}
+
+		// the handler reads the body again
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	expected := HmacSignature(a.hmacSecret, timestamp, nonce, req.Method, req.URL.Path, body)
+	if !hmac.Equal([]byte(req.Header.Get(HmacSignatureHeader)), []byte(expected)) {
+		return ErrInvalidHmacRequest
+	}
+
+	// only recorded once the signature is verified, so that unauthenticated peers cannot fill the cache
+	if !a.hmacNonces.add(nonce, signedAt.Add(HmacMaxClockSkew), now) {
+		return ErrReplayedHmacNonce
+	}
+
+	return nil
+}
+
+// hmacNonceCache holds the nonces of verified hmac requests until their timestamp is too old to be accepted again.
+type hmacNonceCache struct {
+	mu        sync.Mutex
+	expiries  map[string]time.Time
+	lastPrune time.Time
+}
+
+func newHmacNonceCache() *hmacNonceCache {
+	return &hmacNonceCache{
+		mu:        sync.Mutex{},
+		expiries:  make(map[string]time.Time),
+		lastPrune: time.Time{},
+	}
+}
+
+// add records nonce until expiry, returning false if it is already recorded.
+func (c *hmacNonceCache) add(nonce string, expiry time.Time, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// pruned at most once a second, as a burst of requests would otherwise scan the whole cache for each one
+	if now.Sub(c.lastPrune) >= time.Second {
+		for cachedNonce, cachedExpiry := range c.expiries {
+			if !cachedExpiry.After(now) {
+				delete(c.expiries, cachedNonce)
+			}
+		}
+
+		c.lastPrune = now
+	}
+
+	if cachedExpiry, ok := c.expiries[nonce]; ok && cachedExpiry.After(now) {
+		return false
+	}
+
+	c.expiries[nonce] = expiry
+
+	return true
+}
+
+func (a *IncomingAuthenticator) authenticateRequest(req *http.Request, now time.Time) error {
+	err := a.checkPeer(req.RemoteAddr)
+	if err != nil {
+		return err
+	}
+
+	if !a.requiresCredentials() {
+		return nil
+	}
+
+	if authorization := req.Header.Get("Authorization"); len(authorization) > 0 {
+		return a.checkBearerToken(authorization)
+	}
+
+	if len(req.Header.Get(HmacSignatureHeader)) > 0 {
+		return a.checkHmacSignature(req, now)
+	}
+
+	return ErrMissingCredentials
+}
+
+// Wrap rejects requests that fail authentication with 401, or 403 if the peer is not in the ip allowlist.
+func (a *IncomingAuthenticator) Wrap(handler http.HandlerFunc) http.HandlerFunc {
+	if a == nil {
+		return handler
+	}
+
+	return func(resp http.ResponseWriter, req *http.Request) {
+		err := a.authenticateRequest(req, time.Now())
+		if err != nil {
+			a.logger.Warn().
+				Err(err).
+				Str("peer", req.RemoteAddr).
+				Str("path", req.URL.Path).
+				Msg("rejected incoming request")
+
+			status := http.StatusUnauthorized
+			if errors.Is(err, ErrPeerNotAllowed) {
+				status = http.StatusForbidden
+			}
+
+			writeJSON(resp, status, ErrorMessage{Error: http.StatusText(status)}, a.logger)
+
+			return
+		}
+
+		handler(resp, req)
+	}
+}
+
+func (a *IncomingAuthenticator) authenticateStream(ctx context.Context) error {
+	peerAddress := ""
+	if p, ok := peer.FromContext(ctx); ok {
+		peerAddress = p.Addr.String()
+	}
+
+	err := a.checkPeer(peerAddress)
+	if err == nil && a.requiresCredentials() {
+		// hmac signatures cover http requests only, so grpc clients authenticate with the bearer token
+		authorization := metadata.ValueFromIncomingContext(ctx, "authorization")
+		if len(authorization) == 0 {
+			err = ErrMissingCredentials
+		} else {
+			err = a.checkBearerToken(authorization[0])
+		}
+	}
+
+	if err != nil {
+		a.logger.Warn().Err(err).Str("peer", peerAddress).Msg("rejected incoming grpc stream")
+
+		if errors.Is(err, ErrPeerNotAllowed) {
+			return status.Error(codes.PermissionDenied, err.Error())
+		}
+
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	return nil
+}
+
+// StreamInterceptor applies the ip allowlist and bearer token to grpc streams.
+func (a *IncomingAuthenticator) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if a != nil {
+			err := a.authenticateStream(stream.Context())
+			if err != nil {
+				return err
+			}
+		}
+
+		return handler(srv, stream)
+	}
+}
+
+// LoadIncomingTlsConfig returns nil if no cert file is set. If a client ca file is set, clients must present a
+// certificate signed by it.
+func LoadIncomingTlsConfig(certFile string, keyFile string, clientCaFile string) (*tls.Config, error) {
+	if len(certFile) == 0 && len(keyFile) == 0 {
+		if len(clientCaFile) > 0 {
+			return nil, ErrClientCaWithoutTls
+		}
+
+		return nil, nil //nolint:nilnil // TLS is disabled.
+	}
+
+	if len(certFile) == 0 || len(keyFile) == 0 {
+		return nil, ErrMissingTlsKeyPair
+	}
+
+	certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load incoming tls key pair: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if len(clientCaFile) > 0 {
+		clientCaData, err := readFile(clientCaFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read incoming tls client ca file: %w", err)
+		}
+
+		clientCas := x509.NewCertPool()
+		if !clientCas.AppendCertsFromPEM(clientCaData) {
+			return nil, ErrInvalidClientCaFile
+		}
+
+		tlsConfig.ClientCAs = clientCas
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,235 @@
+package publisher_agent
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testBearerToken = "test-token"
+	testHmacSecret  = "test-secret"
+)
+
+func signedRequest(timestamp time.Time, nonce string, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/v1/values", strings.NewReader(body))
+	timestampHeader := strconv.FormatInt(timestamp.Unix(), 10)
+	req.Header.Set(HmacTimestampHeader, timestampHeader)
+	req.Header.Set(HmacNonceHeader, nonce)
+	req.Header.Set(
+		HmacSignatureHeader,
+		HmacSignature([]byte(testHmacSecret), timestampHeader, nonce, http.MethodPost, "/v1/values", []byte(body)),
+	)
+
+	return req
+}
+
+func TestIncomingAuthenticatorWrap(t *testing.T) {
+	t.Parallel()
+
+	authenticator, err := NewIncomingAuthenticator(
+		testBearerToken,
+		testHmacSecret,
+		[]string{"192.0.2.1", "10.0.0.0/8"},
+		zerolog.Nop(),
+	)
+	require.NoError(t, err)
+
+	tamperedRequest := signedRequest(time.Now(), "nonce-tampered", `[{"t":1,"a":"BTCUSD","v":"1"}]`)
+	tamperedRequest.Body = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`[]`)).Body
+
+	tests := []struct {
+		name           string
+		request        func() *http.Request
+		remoteAddr     string
+		expectedStatus int
+	}{
+		{
+			name: "bearer token",
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/publish", nil)
+				req.Header.Set("Authorization", "Bearer "+testBearerToken)
+
+				return req
+			},
+			remoteAddr:     "10.1.2.3:1234",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "wrong bearer token",
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/publish", nil)
+				req.Header.Set("Authorization", "Bearer wrong")
+
+				return req
+			},
+			remoteAddr:     "10.1.2.3:1234",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "hmac signature",
+			request: func() *http.Request {
+				return signedRequest(time.Now(), "nonce-valid", `[{"t":1,"a":"BTCUSD","v":"1"}]`)
+			},
+			remoteAddr:     "192.0.2.1:1234",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "stale hmac signature",
+			request: func() *http.Request {
+				return signedRequest(time.Now().Add(-time.Hour), "nonce-stale", `[]`)
+			},
+			remoteAddr:     "192.0.2.1:1234",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "hmac signature without a nonce",
+			request: func() *http.Request {
+				return signedRequest(time.Now(), "", `[]`)
+			},
+			remoteAddr:     "192.0.2.1:1234",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "hmac signature over a different body",
+			request:        func() *http.Request { return tamperedRequest },
+			remoteAddr:     "192.0.2.1:1234",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "no credentials",
+			request:        func() *http.Request { return httptest.NewRequest(http.MethodGet, "/publish", nil) },
+			remoteAddr:     "10.1.2.3:1234",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "peer not in allowlist",
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/publish", nil)
+				req.Header.Set("Authorization", "Bearer "+testBearerToken)
+
+				return req
+			},
+			remoteAddr:     "192.0.2.2:1234",
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := tt.request()
+			req.RemoteAddr = tt.remoteAddr
+			resp := httptest.NewRecorder()
+
+			authenticator.Wrap(func(resp http.ResponseWriter, req *http.Request) {
+				resp.WriteHeader(http.StatusOK)
+			})(resp, req)
+
+			assert.Equal(t, tt.expectedStatus, resp.Code)
+		})
+	}
+}
+
+func TestIncomingAuthenticatorHmacReplay(t *testing.T) {
+	t.Parallel()
+
+	authenticator, err := NewIncomingAuthenticator("", testHmacSecret, nil, zerolog.Nop())
+	require.NoError(t, err)
+
+	now := time.Now()
+	body := `[{"t":1,"a":"BTCUSD","v":"1"}]`
+
+	require.NoError(t, authenticator.authenticateRequest(signedRequest(now, "nonce-1", body), now))
+	require.ErrorIs(
+		t,
+		authenticator.authenticateRequest(signedRequest(now, "nonce-1", body), now.Add(time.Second)),
+		ErrReplayedHmacNonce,
+	)
+	require.NoError(t, authenticator.authenticateRequest(signedRequest(now, "nonce-2", body), now.Add(time.Second)))
+
+	// a forged request reusing a fresh nonce is rejected without using up the nonce
+	forged := signedRequest(now, "nonce-3", body)
+	forged.Header.Set(HmacSignatureHeader, "00")
+	require.ErrorIs(t, authenticator.authenticateRequest(forged, now), ErrInvalidHmacRequest)
+	require.NoError(t, authenticator.authenticateRequest(signedRequest(now, "nonce-3", body), now))
+
+	// once the timestamp is too old to be accepted, the nonce is forgotten
+	later := now.Add(HmacMaxClockSkew + 2*time.Second)
+	require.ErrorIs(
+		t,
+		authenticator.authenticateRequest(signedRequest(now, "nonce-1", body), later),
+		ErrStaleHmacTimestamp,
+	)
+	require.NoError(t, authenticator.authenticateRequest(signedRequest(later, "nonce-4", body), later))
+	assert.NotContains(t, authenticator.hmacNonces.expiries, "nonce-1")
+}
+
+func TestIncomingAuthenticatorDisabled(t *testing.T) {
+	t.Parallel()
+
+	authenticator, err := NewIncomingAuthenticator("", "", nil, zerolog.Nop())
+	require.NoError(t, err)
+	require.Nil(t, authenticator)
+
+	resp := httptest.NewRecorder()
+	authenticator.Wrap(func(resp http.ResponseWriter, req *http.Request) {
+		resp.WriteHeader(http.StatusOK)
+	})(resp, httptest.NewRequest(http.MethodGet, "/publish", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	_, err = NewIncomingAuthenticator("", "", []string{"not an ip"}, zerolog.Nop())
+	require.Error(t, err)
+}
+
+func TestIncomingAuthenticatorStream(t *testing.T) {
+	t.Parallel()
+
+	authenticator, err := NewIncomingAuthenticator(testBearerToken, "", []string{"10.0.0.0/8"}, zerolog.Nop())
+	require.NoError(t, err)
+
+	peerContext := func(address string, authorization string) context.Context {
+		ctx := peer.NewContext(t.Context(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(address), Port: 1234}})
+		if authorization != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", authorization))
+		}
+
+		return ctx
+	}
+
+	require.NoError(t, authenticator.authenticateStream(peerContext("10.1.2.3", "Bearer "+testBearerToken)))
+	assert.Equal(t, codes.Unauthenticated, status.Code(authenticator.authenticateStream(peerContext("10.1.2.3", ""))))
+	assert.Equal(
+		t,
+		codes.PermissionDenied,
+		status.Code(authenticator.authenticateStream(peerContext("192.0.2.1", "Bearer "+testBearerToken))),
+	)
+}
+
+func TestLoadIncomingTlsConfig(t *testing.T) {
+	t.Parallel()
+
+	tlsConfig, err := LoadIncomingTlsConfig("", "", "")
+	require.NoError(t, err)
+	assert.Nil(t, tlsConfig)
+
+	_, err = LoadIncomingTlsConfig("cert.pem", "", "")
+	require.ErrorIs(t, err, ErrMissingTlsKeyPair)
+
+	_, err = LoadIncomingTlsConfig("", "", "ca.pem")
+	require.ErrorIs(t, err, ErrClientCaWithoutTls)
+}
@@ -86,15 +86,10 @@ func (p *IncomingWebsocketPuller) Run() {
 					Value:                new(big.Float).SetFloat64(priceUpdatePullWebsocket.Price),
 					Metadata:             priceUpdatePullWebsocket.Metadata,
 				}
-				for _, valueUpdateCh := range p.ValueUpdateChannels {
-					select {
-					case valueUpdateCh <- valueUpdate:
-					default:
-						if time.Since(lastDropLogTime) >= FullQueueLogFrequency {
-							p.Logger.Error().Msg("dropped incoming price update - too many updates")
-							lastDropLogTime = time.Now()
-						}
-					}
+				if sendValueUpdate(valueUpdate, p.ValueUpdateChannels) > 0 &&
+					time.Since(lastDropLogTime) >= FullQueueLogFrequency {
+					p.Logger.Error().Msg("dropped incoming price update - too many updates")
+					lastDropLogTime = time.Now()
 				}
 			}
 		}
@@ -0,0 +1,132 @@
+package publisher_agent
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const MaxIngestionRequestBytes = 16 * 1024 * 1024
+
+var (
+	ErrMissingAsset       = errors.New("asset must not be empty")
+	ErrMissingTimestamp   = errors.New("timestamp must be positive")
+	ErrQueueFull          = errors.New("dropped - too many updates")
+	ErrQueuePartiallyFull = errors.New("partially dropped - too many updates")
+)
+
+// IngestionError reports why one value update of a request was not accepted.
+type IngestionError struct {
+	Index int    `json:"index"`
+	Error string `json:"error"`
+}
+
+type IngestionResponse struct {
+	Accepted int              `json:"accepted"`
+	Errors   []IngestionError `json:"errors,omitempty"`
+}
+
+// ValueUpdateIngester validates value updates pushed over HTTP or gRPC and hands them to every runner, dropping them
+// for runners whose queue is full just like updates received over the /publish websocket.
+type ValueUpdateIngester struct {
+	valueUpdateChannels []chan ValueUpdate
+	logger              zerolog.Logger
+	// lastDropLogNano is shared by concurrent requests
+	lastDropLogNano atomic.Int64
+}
+
+func NewValueUpdateIngester(valueUpdateChannels []chan ValueUpdate, logger zerolog.Logger) *ValueUpdateIngester {
+	return &ValueUpdateIngester{
+		valueUpdateChannels: valueUpdateChannels,
+		logger:              logger,
+	}
+}
+
+// Ingest sends every valid update on, reporting the index and error of each update that was not accepted.
+func (i *ValueUpdateIngester) Ingest(valueUpdates []ValueUpdatePushWebsocket) IngestionResponse {
+	response := IngestionResponse{}
+
+	for index, valueUpdatePush := range valueUpdates {
+		err := i.ingest(valueUpdatePush)
+		if err != nil {
+			response.Errors = append(response.Errors, IngestionError{Index: index, Error: err.Error()})
+			continue
+		}
+
+		response.Accepted++
+	}
+
+	return response
+}
+
+func (i *ValueUpdateIngester) ingest(valueUpdatePush ValueUpdatePushWebsocket) error {
+	if valueUpdatePush.Asset == "" {
+		return ErrMissingAsset
+	}
+
+	if valueUpdatePush.PublishTimestampNano <= 0 {
+		return ErrMissingTimestamp
+	}
+
+	valueUpdate, err := convertToValueUpdate(valueUpdatePush)
+	if err != nil {
+		return fmt.Errorf("invalid value: %w", err)
+	}
+
+	dropped := sendValueUpdate(*valueUpdate, i.valueUpdateChannels)
+	if dropped == 0 {
+		return nil
+	}
+
+	lastDropLogNano := i.lastDropLogNano.Load()
+	now := time.Now().UnixNano()
+	if time.Duration(now-lastDropLogNano) >= FullQueueLogFrequency &&
+		i.lastDropLogNano.CompareAndSwap(lastDropLogNano, now) {
+		i.logger.Error().Msg("dropped incoming price update - too many updates")
+	}
+
+	if dropped < len(i.valueUpdateChannels) {
+		return fmt.Errorf(
+			"%w (dropped by %d of %d signature types)", ErrQueuePartiallyFull, dropped, len(i.valueUpdateChannels),
+		)
+	}
+
+	return ErrQueueFull
+}
+
+// HandleIngestValues accepts a JSON array of value updates, in the format sent over the /publish websocket.
+func (i *ValueUpdateIngester) HandleIngestValues(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		resp.Header().Set("Allow", http.MethodPost)
+		writeJSON(resp, http.StatusMethodNotAllowed, ErrorMessage{Error: "method not allowed"}, i.logger)
+
+		return
+	}
+
+	var valueUpdates []ValueUpdatePushWebsocket
+
+	err := json.NewDecoder(http.MaxBytesReader(resp, req.Body, MaxIngestionRequestBytes)).Decode(&valueUpdates)
+	if err != nil {
+		i.logger.Debug().Err(err).Msg("Failed to parse value updates")
+		writeJSON(resp, http.StatusBadRequest, ErrorMessage{Error: "failed to parse value updates"}, i.logger)
+
+		return
+	}
+
+	writeJSON(resp, http.StatusOK, i.Ingest(valueUpdates), i.logger)
+}
+
+func writeJSON(resp http.ResponseWriter, status int, body any, logger zerolog.Logger) {
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(status)
+
+	err := json.NewEncoder(resp).Encode(body)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Failed to write response")
+	}
+}
@@ -0,0 +1,212 @@
+package publisher_agent
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestIngest(t *testing.T) {
+	t.Parallel()
+
+	valueUpdateCh := make(chan ValueUpdate, 1)
+	ingester := NewValueUpdateIngester([]chan ValueUpdate{valueUpdateCh}, zerolog.Nop())
+
+	response := ingester.Ingest([]ValueUpdatePushWebsocket{
+		{PublishTimestampNano: 1, Asset: "BTCUSD", Value: "100.5"},
+		{PublishTimestampNano: 1, Asset: "", Value: "1"},
+		{PublishTimestampNano: 0, Asset: "ETHUSD", Value: "1"},
+		{PublishTimestampNano: 1, Asset: "ETHUSD", Value: "not a number"},
+		// the channel only has room for the first update
+		{PublishTimestampNano: 2, Asset: "ETHUSD", Value: "0x10"},
+	})
+
+	assert.Equal(t, 1, response.Accepted)
+	require.Len(t, response.Errors, 4)
+	assert.Equal(t, IngestionError{Index: 1, Error: ErrMissingAsset.Error()}, response.Errors[0])
+	assert.Equal(t, IngestionError{Index: 2, Error: ErrMissingTimestamp.Error()}, response.Errors[1])
+	assert.Equal(t, 3, response.Errors[2].Index)
+	assert.Equal(t, IngestionError{Index: 4, Error: ErrQueueFull.Error()}, response.Errors[3])
+
+	valueUpdate := <-valueUpdateCh
+	assert.Equal(t, "BTCUSD", string(valueUpdate.Asset))
+	assert.Equal(t, "100.5", valueUpdate.Value.Text('f', 1))
+}
+
+func TestIngestPartialDrop(t *testing.T) {
+	t.Parallel()
+
+	evmValueUpdateCh := make(chan ValueUpdate, 2)
+	starkValueUpdateCh := make(chan ValueUpdate, 1)
+	ingester := NewValueUpdateIngester([]chan ValueUpdate{evmValueUpdateCh, starkValueUpdateCh}, zerolog.Nop())
+
+	response := ingester.Ingest([]ValueUpdatePushWebsocket{
+		{PublishTimestampNano: 1, Asset: "BTCUSD", Value: "1"},
+		// only the first channel has room for the second update
+		{PublishTimestampNano: 2, Asset: "BTCUSD", Value: "2"},
+		{PublishTimestampNano: 3, Asset: "BTCUSD", Value: "3"},
+	})
+
+	assert.Equal(t, 1, response.Accepted)
+	require.Len(t, response.Errors, 2)
+	assert.Equal(t, 1, response.Errors[0].Index)
+	assert.Equal(t, ErrQueuePartiallyFull.Error()+" (dropped by 1 of 2 signature types)", response.Errors[0].Error)
+	assert.Equal(t, IngestionError{Index: 2, Error: ErrQueueFull.Error()}, response.Errors[1])
+	assert.Len(t, evmValueUpdateCh, 2)
+	assert.Len(t, starkValueUpdateCh, 1)
+}
+
+func TestHandleIngestValues(t *testing.T) {
+	t.Parallel()
+
+	valueUpdateCh := make(chan ValueUpdate, 2)
+	ingester := NewValueUpdateIngester([]chan ValueUpdate{valueUpdateCh}, zerolog.Nop())
+
+	tests := []struct {
+		name           string
+		method         string
+		body           string
+		expectedStatus int
+		expected       IngestionResponse
+	}{
+		{
+			name:   "accepted and rejected updates",
+			method: http.MethodPost,
+			body: `[{"t":1710191092123456789,"a":"BTCUSD","v":"60000.5","m":{"source":"test"}},` +
+				`{"t":1710191092123456789,"a":"","v":"1"}]`,
+			expectedStatus: http.StatusOK,
+			expected: IngestionResponse{
+				Accepted: 1,
+				Errors:   []IngestionError{{Index: 1, Error: ErrMissingAsset.Error()}},
+			},
+		},
+		{
+			name:           "not a list of updates",
+			method:         http.MethodPost,
+			body:           `{"t":1}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "wrong method",
+			method:         http.MethodGet,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(tt.method, "/v1/values", strings.NewReader(tt.body))
+			resp := httptest.NewRecorder()
+
+			ingester.HandleIngestValues(resp, req)
+
+			require.Equal(t, tt.expectedStatus, resp.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response IngestionResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+			assert.Equal(t, tt.expected, response)
+		})
+	}
+}
+
+func newValueUpdateMessage(
+	t *testing.T,
+	timestamp int64,
+	asset string,
+	value string,
+	metadata map[string]any,
+) protoreflect.Message {
+	t.Helper()
+
+	message := dynamicpb.NewMessage(valueIngestionMessage("stork.publisher_agent.v1.ValueUpdate"))
+	fields := message.Descriptor().Fields()
+	message.Set(fields.ByName("publish_timestamp_nano"), protoreflect.ValueOfInt64(timestamp))
+	message.Set(fields.ByName("asset"), protoreflect.ValueOfString(asset))
+	message.Set(fields.ByName("value"), protoreflect.ValueOfString(value))
+
+	if metadata != nil {
+		metadataStruct, err := structpb.NewStruct(metadata)
+		require.NoError(t, err)
+		message.Set(fields.ByName("metadata"), protoreflect.ValueOfMessage(metadataStruct.ProtoReflect()))
+	}
+
+	return message
+}
+
+func TestPublishValuesStream(t *testing.T) {
+	t.Parallel()
+
+	valueUpdateCh := make(chan ValueUpdate, 1)
+	ingester := NewValueUpdateIngester([]chan ValueUpdate{valueUpdateCh}, zerolog.Nop())
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	RegisterValueIngestionServer(server, ingester)
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	stream, err := conn.NewStream(
+		t.Context(),
+		&grpc.StreamDesc{StreamName: PublishValuesStreamName, ServerStreams: true, ClientStreams: true},
+		"/"+ValueIngestionServiceName+"/"+PublishValuesStreamName,
+	)
+	require.NoError(t, err)
+
+	request := dynamicpb.NewMessage(valueIngestionMessage(publishValuesRequestName))
+	updates := request.Mutable(request.Descriptor().Fields().ByName("updates")).List()
+	updates.Append(protoreflect.ValueOfMessage(
+		newValueUpdateMessage(t, 1710191092123456789, "BTCUSD", "60000.5", map[string]any{"source": "test"}),
+	))
+	updates.Append(protoreflect.ValueOfMessage(newValueUpdateMessage(t, 1710191092123456789, "ETHUSD", "abc", nil)))
+
+	require.NoError(t, stream.SendMsg(request))
+
+	response := dynamicpb.NewMessage(valueIngestionMessage(publishValuesResponseName))
+	require.NoError(t, stream.RecvMsg(response))
+
+	responseFields := response.Descriptor().Fields()
+	assert.Equal(t, uint64(1), response.Get(responseFields.ByName("accepted")).Uint())
+
+	errorList := response.Get(responseFields.ByName("errors")).List()
+	require.Equal(t, 1, errorList.Len())
+	ingestionError := errorList.Get(0).Message()
+	assert.Equal(t, uint64(1), ingestionError.Get(ingestionError.Descriptor().Fields().ByName("index")).Uint())
+
+	valueUpdate := <-valueUpdateCh
+	assert.Equal(t, "BTCUSD", string(valueUpdate.Asset))
+	assert.Equal(t, Metadata{"source": "test"}, valueUpdate.Metadata)
+
+	require.NoError(t, stream.CloseSend())
+}
@@ -0,0 +1,176 @@
+package publisher_agent
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Stork-Oracle/stork-external/shared"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const QueueDepthSampleInterval = time.Second
+
+var (
+	// labelled only by assets a broker subscribes to, as publishers can send arbitrary asset names
+	incomingUpdatesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "stork",
+		Subsystem: "publisher_agent",
+		Name:      "incoming_updates_total",
+		Help:      "Value updates received from the publisher, per asset",
+	}, []string{"asset"})
+	droppedIncomingUpdatesTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "stork",
+		Subsystem: "publisher_agent",
+		Name:      "dropped_incoming_updates_total",
+		Help:      "Incoming value updates dropped because a runner's value update queue was full",
+	})
+	signingDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "stork",
+		Subsystem: "publisher_agent",
+		Name:      "signing_duration_seconds",
+		Help:      "Time taken to sign a value update",
+		Buckets:   []float64{.00005, .0001, .00025, .0005, .001, .0025, .005, .01, .025, .05, .1},
+	}, []string{"signature_type"})
+	queueDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "stork",
+		Subsystem: "publisher_agent",
+		Name:      "queue_depth",
+		Help:      "Number of items waiting in each of a runner's queues",
+	}, []string{"signature_type", "queue"})
+	brokerQueueDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "stork",
+		Subsystem: "publisher_agent",
+		Name:      "broker_queue_depth",
+		Help:      "Number of signed price update batches waiting to be sent to each broker",
+	}, []string{"signature_type", "broker_url"})
+	droppedBrokerBatchesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "stork",
+		Subsystem: "publisher_agent",
+		Name:      "dropped_broker_batches_total",
+		Help:      "Signed price update batches dropped because a broker's outgoing queue was full",
+	}, []string{"signature_type", "broker_url"})
+	replayedUpdatesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "stork",
+		Subsystem: "publisher_agent",
+		Name:      "replayed_updates_total",
+		Help:      "Buffered signed price updates replayed to each broker on reconnect",
+	}, []string{"signature_type", "broker_url"})
+	brokerConnected = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "stork",
+		Subsystem: "publisher_agent",
+		Name:      "broker_connected",
+		Help:      "Whether the outgoing websocket to each broker is connected (1) or not (0)",
+	}, []string{"signature_type", "broker_url"})
+	brokerLastSentTimestamp = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "stork",
+		Subsystem: "publisher_agent",
+		Name:      "broker_last_sent_timestamp_seconds",
+		Help:      "Unix time signed prices were last sent to each broker",
+	}, []string{"signature_type", "broker_url"})
+	registryRefreshesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "stork",
+		Subsystem: "publisher_agent",
+		Name:      "registry_refreshes_total",
+		Help:      "Stork Registry broker refreshes, by result",
+	}, []string{"signature_type", "result"})
+	registryLastSuccessTimestamp = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "stork",
+		Subsystem: "publisher_agent",
+		Name:      "registry_last_success_timestamp_seconds",
+		Help:      "Unix time of the last successful Stork Registry broker refresh",
+	}, []string{"signature_type"})
+)
+
+// Queues reported in the queue_depth metric.
+const (
+	ValueUpdateQueue = "value_update"
+	SignQueue        = "sign"
+	SignedBatchQueue = "signed_batch"
+)
+
+// OtherAssetLabel is the asset label incoming updates are counted under when no broker subscribes to their asset.
+const OtherAssetLabel = "other"
+
+//nolint:gochecknoglobals // incoming updates are counted before they reach the runners, so the set is shared.
+var incomingAssets = NewKnownAssets()
+
+// KnownAssets holds the assets the brokers of each signature type subscribe to, which bound the asset label of
+// the incoming updates metric.
+type KnownAssets struct {
+	lock            sync.RWMutex
+	bySignatureType map[shared.SignatureType]map[shared.AssetID]struct{}
+}
+
+func NewKnownAssets() *KnownAssets {
+	return &KnownAssets{
+		lock:            sync.RWMutex{},
+		bySignatureType: make(map[shared.SignatureType]map[shared.AssetID]struct{}),
+	}
+}
+
+// Update replaces the assets of a signature type with those of its brokers. The wildcard asset is not a label.
+func (k *KnownAssets) Update(
+	signatureType shared.SignatureType,
+	assetsByBroker map[BrokerPublishUrl]map[shared.AssetID]struct{},
+) {
+	assets := make(map[shared.AssetID]struct{})
+
+	for _, brokerAssets := range assetsByBroker {
+		for asset := range brokerAssets {
+			if asset != WildcardSubscriptionAsset {
+				assets[asset] = struct{}{}
+			}
+		}
+	}
+
+	k.lock.Lock()
+	k.bySignatureType[signatureType] = assets
+	k.lock.Unlock()
+}
+
+// Label returns the asset label of an incoming update for the asset.
+func (k *KnownAssets) Label(asset shared.AssetID) string {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+
+	for _, assets := range k.bySignatureType {
+		if _, exists := assets[asset]; exists {
+			return string(asset)
+		}
+	}
+
+	return OtherAssetLabel
+}
+
+// sendValueUpdate counts an incoming value update and hands it to every runner, dropping it for runners whose
+// queue is full. It returns the number of runners the update was dropped for.
+func sendValueUpdate(valueUpdate ValueUpdate, valueUpdateChannels []chan ValueUpdate) int {
+	incomingUpdatesTotal.WithLabelValues(incomingAssets.Label(valueUpdate.Asset)).Inc()
+
+	dropped := 0
+
+	for _, valueUpdateCh := range valueUpdateChannels {
+		select {
+		case valueUpdateCh <- valueUpdate:
+		default:
+			droppedIncomingUpdatesTotal.Inc()
+
+			dropped++
+		}
+	}
+
+	return dropped
+}
+
+func observeSigning(signatureType shared.SignatureType, elapsed time.Duration) {
+	signingDuration.WithLabelValues(string(signatureType)).Observe(elapsed.Seconds())
+}
+
+// RegisterMetricsHandlers adds the /metrics and /health handlers to mux.
+func RegisterMetricsHandlers(mux *http.ServeMux, runners []HealthReporter) {
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.Handle("/health", NewHealthHandler(runners))
+}
@@ -0,0 +1,34 @@
+package publisher_agent
+
+import (
+	"testing"
+
+	"github.com/Stork-Oracle/stork-external/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKnownAssetsLabel(t *testing.T) {
+	t.Parallel()
+
+	knownAssets := NewKnownAssets()
+	assert.Equal(t, OtherAssetLabel, knownAssets.Label("BTCUSD"))
+
+	knownAssets.Update(shared.EvmSignatureType, map[BrokerPublishUrl]map[shared.AssetID]struct{}{
+		"wss://broker-a": {"BTCUSD": {}},
+		"wss://broker-b": {"ETHUSD": {}, WildcardSubscriptionAsset: {}},
+	})
+	knownAssets.Update(shared.StarkSignatureType, map[BrokerPublishUrl]map[shared.AssetID]struct{}{
+		"wss://broker-c": {"SOLUSD": {}},
+	})
+
+	assert.Equal(t, "BTCUSD", knownAssets.Label("BTCUSD"))
+	assert.Equal(t, "ETHUSD", knownAssets.Label("ETHUSD"))
+	assert.Equal(t, "SOLUSD", knownAssets.Label("SOLUSD"))
+	assert.Equal(t, OtherAssetLabel, knownAssets.Label("DOGEUSD"))
+	assert.Equal(t, OtherAssetLabel, knownAssets.Label(WildcardSubscriptionAsset))
+
+	// a refresh replaces the assets of the signature type
+	knownAssets.Update(shared.EvmSignatureType, map[BrokerPublishUrl]map[shared.AssetID]struct{}{})
+	assert.Equal(t, OtherAssetLabel, knownAssets.Label("BTCUSD"))
+	assert.Equal(t, "SOLUSD", knownAssets.Label("SOLUSD"))
+}
@@ -15,24 +15,32 @@ type DeltaTick struct{}
 
 type ClockTick struct{}
 
+// RateLimitTick signs the newest update of an asset that was skipped by its signing rate limit.
+type RateLimitTick struct {
+	Asset shared.AssetID
+}
+
 const SignedMessageBatchPeriod = 1 * time.Millisecond
 
 type ValueUpdateProcessor[T shared.Signature] struct {
-	valueUpdateCh             chan ValueUpdate
-	signedPriceUpdateBatchCh  chan SignedPriceUpdateBatch[T]
-	signer                    signer.Signer[T]
-	oracleId                  OracleID
-	numRunners                int
-	valueUpdates              map[shared.AssetID]ValueUpdate
-	lastReportedPrice         map[shared.AssetID]float64
-	clockPeriod               time.Duration
-	deltaCheckPeriod          time.Duration
-	changeThresholdProportion float64 // 0-1
-	signEveryUpdate           bool
-	logger                    zerolog.Logger
-	totalSignatures           int
-	totalSigningNs            int64
-	signQueueSize             int32
+	valueUpdateCh            chan ValueUpdate
+	signedPriceUpdateBatchCh chan SignedPriceUpdateBatch[T]
+	signer                   signer.Signer[T]
+	oracleId                 OracleID
+	numRunners               int
+	valueUpdates             map[shared.AssetID]ValueUpdate
+	lastReportedPrice        map[shared.AssetID]float64
+	nextClockUpdate          map[shared.AssetID]time.Time
+	lastSigned               map[shared.AssetID]time.Time
+	rateLimitedUpdates       map[shared.AssetID]ValueUpdateWithTrigger
+	rateLimitTickScheduled   map[shared.AssetID]bool
+	deltaCheckPeriod         time.Duration
+	clockTickPeriod          time.Duration
+	signingPolicies          *SigningPolicies
+	logger                   zerolog.Logger
+	totalSignatures          int
+	totalSigningNs           int64
+	signQueueSize            int32
 }
 
 func NewPriceUpdateProcessor[T shared.Signature](
@@ -43,34 +51,51 @@ func NewPriceUpdateProcessor[T shared.Signature](
 	deltaCheckPeriod time.Duration,
 	changeThresholdProportion float64,
 	signEveryUpdate bool,
+	assetSigningPolicies []AssetSigningPolicy,
 	valueUpdateCh chan ValueUpdate,
 	signedPriceUpdateBatchCh chan SignedPriceUpdateBatch[T],
 	logger zerolog.Logger,
 ) *ValueUpdateProcessor[T] {
+	defaultSigningPolicy := SigningPolicy{
+		ClockPeriod:               clockPeriod,
+		ChangeThresholdProportion: changeThresholdProportion,
+		SignEveryUpdate:           signEveryUpdate,
+	}
+
+	signingPolicies := NewSigningPolicies(defaultSigningPolicy, assetSigningPolicies)
+
 	return &ValueUpdateProcessor[T]{
-		valueUpdateCh:             valueUpdateCh,
-		signedPriceUpdateBatchCh:  signedPriceUpdateBatchCh,
-		signer:                    signer,
-		oracleId:                  oracleId,
-		numRunners:                numRunners,
-		valueUpdates:              make(map[shared.AssetID]ValueUpdate),
-		lastReportedPrice:         make(map[shared.AssetID]float64),
-		clockPeriod:               clockPeriod,
-		deltaCheckPeriod:          deltaCheckPeriod,
-		changeThresholdProportion: changeThresholdProportion,
-		signEveryUpdate:           signEveryUpdate,
-		logger:                    logger,
+		valueUpdateCh:            valueUpdateCh,
+		signedPriceUpdateBatchCh: signedPriceUpdateBatchCh,
+		signer:                   signer,
+		oracleId:                 oracleId,
+		numRunners:               numRunners,
+		valueUpdates:             make(map[shared.AssetID]ValueUpdate),
+		lastReportedPrice:        make(map[shared.AssetID]float64),
+		nextClockUpdate:          make(map[shared.AssetID]time.Time),
+		lastSigned:               make(map[shared.AssetID]time.Time),
+		rateLimitedUpdates:       make(map[shared.AssetID]ValueUpdateWithTrigger),
+		rateLimitTickScheduled:   make(map[shared.AssetID]bool),
+		deltaCheckPeriod:         deltaCheckPeriod,
+		clockTickPeriod:          signingPolicies.ClockTickPeriod(),
+		signingPolicies:          signingPolicies,
+		logger:                   logger,
 	}
 }
 
 func (vup *ValueUpdateProcessor[T]) DeltaUpdate() []ValueUpdateWithTrigger {
 	significantUpdates := make([]ValueUpdateWithTrigger, 0)
 	for asset, valueUpdate := range vup.valueUpdates {
+		policy := vup.signingPolicies.ForAsset(asset)
+		if policy.SignEveryUpdate {
+			continue
+		}
+
 		// float imprecision is ok for change threshold computation
 		currentValue, _ := valueUpdate.Value.Float64()
 		lastReportedValue, exists := vup.lastReportedPrice[asset]
 		if exists {
-			if math.Abs((currentValue-lastReportedValue)/lastReportedValue) > vup.changeThresholdProportion {
+			if math.Abs((currentValue-lastReportedValue)/lastReportedValue) > policy.ChangeThresholdProportion {
 				significantUpdates = append(
 					significantUpdates,
 					ValueUpdateWithTrigger{
@@ -93,12 +118,25 @@ func (vup *ValueUpdateProcessor[T]) DeltaUpdate() []ValueUpdateWithTrigger {
 	return significantUpdates
 }
 
-func (vup *ValueUpdateProcessor[T]) ClockUpdate() []ValueUpdateWithTrigger {
+// ClockUpdate returns the latest value of every asset whose clock update is due. An asset stays due until
+// recordClockUpdate is called for it, so updates skipped by the signing rate limit are retried on the next tick.
+func (vup *ValueUpdateProcessor[T]) ClockUpdate(now time.Time) []ValueUpdateWithTrigger {
 	updates := make([]ValueUpdateWithTrigger, 0)
+	// clock ticks come every clockTickPeriod, so allow half a tick of jitter before skipping an asset's update
+	tolerance := vup.clockTickPeriod / 2
+
+	for asset, valueUpdate := range vup.valueUpdates {
+		if vup.signingPolicies.ForAsset(asset).ClockPeriod <= 0 {
+			continue
+		}
+
+		nextClockUpdate, exists := vup.nextClockUpdate[asset]
+		if exists && now.Add(tolerance).Before(nextClockUpdate) {
+			continue
+		}
 
-	for _, valueUpdate := range vup.valueUpdates {
 		currentTimeValueUpdate := ValueUpdate{
-			PublishTimestampNano: time.Now().UnixNano(),
+			PublishTimestampNano: now.UnixNano(),
 			Value:                valueUpdate.Value,
 			Asset:                valueUpdate.Asset,
 			Metadata:             valueUpdate.Metadata,
@@ -115,6 +153,83 @@ func (vup *ValueUpdateProcessor[T]) ClockUpdate() []ValueUpdateWithTrigger {
 	return updates
 }
 
+// recordClockUpdate schedules the next clock update of an asset one clock period after the one just queued was due,
+// so that updates don't drift later by the tick jitter. If the asset fell a whole period behind, such as after being
+// rate limited, the schedule restarts from now rather than catching up with a burst of updates.
+func (vup *ValueUpdateProcessor[T]) recordClockUpdate(asset shared.AssetID, now time.Time) {
+	clockPeriod := vup.signingPolicies.ForAsset(asset).ClockPeriod
+
+	due, exists := vup.nextClockUpdate[asset]
+	if !exists || now.Sub(due) >= clockPeriod {
+		due = now
+	}
+
+	vup.nextClockUpdate[asset] = due.Add(clockPeriod)
+}
+
+// queueForSigning sends the update to the signer threads unless the asset was signed more recently than its policy
+// allows.
+func (vup *ValueUpdateProcessor[T]) queueForSigning(
+	update ValueUpdateWithTrigger,
+	priceUpdatesToSignCh chan ValueUpdateWithTrigger,
+	signatureType shared.SignatureType,
+) bool {
+	asset := update.ValueUpdate.Asset
+	now := time.Now()
+
+	minSigningInterval := vup.signingPolicies.ForAsset(asset).MinSigningInterval
+	if lastSigned, exists := vup.lastSigned[asset]; exists && now.Sub(lastSigned) < minSigningInterval {
+		return false
+	}
+
+	vup.lastSigned[asset] = now
+	// every trigger signs the asset's newest value, so a rate limited update waiting to be signed is superseded
+	delete(vup.rateLimitedUpdates, asset)
+
+	priceUpdatesToSignCh <- update
+	queueDepth.WithLabelValues(string(signatureType), SignQueue).
+		Set(float64(atomic.AddInt32(&vup.signQueueSize, 1)))
+
+	return true
+}
+
+// deferSigning keeps the newest update of an asset skipped by its signing rate limit, and sends a RateLimitTick to q
+// once the rate limit allows the asset to be signed again.
+func (vup *ValueUpdateProcessor[T]) deferSigning(update ValueUpdateWithTrigger, q chan any) {
+	asset := update.ValueUpdate.Asset
+	vup.rateLimitedUpdates[asset] = update
+
+	if vup.rateLimitTickScheduled[asset] {
+		return
+	}
+
+	vup.rateLimitTickScheduled[asset] = true
+
+	minSigningInterval := vup.signingPolicies.ForAsset(asset).MinSigningInterval
+	time.AfterFunc(time.Until(vup.lastSigned[asset].Add(minSigningInterval)), func() {
+		q <- RateLimitTick{Asset: asset}
+	})
+}
+
+// signRateLimitedUpdate signs the update kept by deferSigning, if it was not superseded in the meantime.
+func (vup *ValueUpdateProcessor[T]) signRateLimitedUpdate(
+	asset shared.AssetID,
+	q chan any,
+	priceUpdatesToSignCh chan ValueUpdateWithTrigger,
+	signatureType shared.SignatureType,
+) {
+	vup.rateLimitTickScheduled[asset] = false
+
+	update, exists := vup.rateLimitedUpdates[asset]
+	if !exists {
+		return
+	}
+
+	if !vup.queueForSigning(update, priceUpdatesToSignCh, signatureType) {
+		vup.deferSigning(update, q)
+	}
+}
+
 func (vup *ValueUpdateProcessor[T]) Run() {
 	queue := make(chan any, 4096)
 	priceUpdatesToSignCh := make(chan ValueUpdateWithTrigger, 4096)
@@ -127,16 +242,16 @@ func (vup *ValueUpdateProcessor[T]) Run() {
 		}
 	}(queue)
 
-	// clock thread if configured
-	if vup.clockPeriod.Nanoseconds() > 0 {
+	// clock thread if configured, ticking often enough for every asset's clock period
+	if vup.clockTickPeriod > 0 {
 		go func(q chan any) {
-			for range time.Tick(vup.clockPeriod) {
+			for range time.Tick(vup.clockTickPeriod) {
 				q <- ClockTick{}
 			}
 		}(queue)
 	}
 
-	if !vup.signEveryUpdate {
+	if vup.signingPolicies.NeedsDeltaChecks() {
 		// delta check thread
 		go func(q chan any) {
 			for range time.Tick(vup.deltaCheckPeriod) {
@@ -145,6 +260,8 @@ func (vup *ValueUpdateProcessor[T]) Run() {
 		}(queue)
 	}
 
+	signatureType := vup.signer.GetSignatureType()
+
 	numSignerThreads := max(runtime.NumCPU()/vup.numRunners, 1)
 	vup.logger.Debug().Msgf("Starting %v signer threads", numSignerThreads)
 	// start a signing thread for each CPU core
@@ -161,9 +278,13 @@ func (vup *ValueUpdateProcessor[T]) Run() {
 				)
 				if err != nil {
 					vup.logger.Error().Err(err).Msg("Failed to sign update")
+					queueDepth.WithLabelValues(string(signatureType), SignQueue).
+						Set(float64(atomic.AddInt32(&vup.signQueueSize, -1)))
 					continue
 				}
 
+				observeSigning(signatureType, time.Since(start))
+
 				priceUpdate := SignedPriceUpdate[T]{
 					OracleID: vup.oracleId,
 					AssetID:  update.ValueUpdate.Asset,
@@ -171,7 +292,7 @@ func (vup *ValueUpdateProcessor[T]) Run() {
 					SignedPrice: SignedPrice[T]{
 						PublisherKey:         shared.PublisherKey(vup.signer.GetPublisherKey()),
 						ExternalAssetID:      externalAssetId,
-						SignatureType:        shared.SignatureType(vup.signer.GetSignatureType()),
+						SignatureType:        signatureType,
 						QuantizedPrice:       quantizedPrice,
 						TimestampedSignature: *timestampedSig,
 						Metadata:             update.ValueUpdate.Metadata,
@@ -180,7 +301,8 @@ func (vup *ValueUpdateProcessor[T]) Run() {
 
 				signedUpdates <- priceUpdate
 				elapsed := time.Since(start).Microseconds()
-				atomic.AddInt32(&vup.signQueueSize, -1)
+				queueDepth.WithLabelValues(string(signatureType), SignQueue).
+					Set(float64(atomic.AddInt32(&vup.signQueueSize, -1)))
 				ageMs := (time.Now().UnixNano() - update.ValueUpdate.PublishTimestampNano) / 1_000_000
 				vup.logger.Debug().
 					Msgf("Signing update on thread %v took %v microseconds (age %v ms, queue size: %v)", threadNum, elapsed, ageMs, vup.signQueueSize)
@@ -210,25 +332,35 @@ func (vup *ValueUpdateProcessor[T]) Run() {
 
 	for val := range queue {
 		var valueUpdates []ValueUpdateWithTrigger
+		now := time.Now()
 		switch msg := val.(type) {
 		case DeltaTick:
 			valueUpdates = vup.DeltaUpdate()
 		case ClockTick:
-			valueUpdates = vup.ClockUpdate()
+			valueUpdates = vup.ClockUpdate(now)
+		case RateLimitTick:
+			vup.signRateLimitedUpdate(msg.Asset, queue, priceUpdatesToSignCh, signatureType)
 		case ValueUpdate:
-			if vup.signEveryUpdate {
-				priceUpdatesToSignCh <- ValueUpdateWithTrigger{ValueUpdate: msg, TriggerType: UnspecifiedTriggerType}
-				atomic.AddInt32(&vup.signQueueSize, 1)
+			if vup.signingPolicies.ForAsset(msg.Asset).SignEveryUpdate {
+				update := ValueUpdateWithTrigger{ValueUpdate: msg, TriggerType: UnspecifiedTriggerType}
+				if !vup.queueForSigning(update, priceUpdatesToSignCh, signatureType) {
+					vup.deferSigning(update, queue)
+				}
 			}
 			vup.valueUpdates[msg.Asset] = msg
 		}
 
 		if len(valueUpdates) > 0 {
 			for _, priceUpdate := range valueUpdates {
-				priceUpdatesToSignCh <- priceUpdate
-				atomic.AddInt32(&vup.signQueueSize, 1)
+				// rate limited updates are not reported, so later delta checks still compare against the last signed price
+				if !vup.queueForSigning(priceUpdate, priceUpdatesToSignCh, signatureType) {
+					continue
+				}
 				lastReportedPrice, _ := priceUpdate.ValueUpdate.Value.Float64()
 				vup.lastReportedPrice[priceUpdate.ValueUpdate.Asset] = lastReportedPrice
+				if priceUpdate.TriggerType == ClockTriggerType {
+					vup.recordClockUpdate(priceUpdate.ValueUpdate.Asset, now)
+				}
 			}
 		}
 	}
@@ -47,6 +47,8 @@ func TestDeltaOnly(t *testing.T) {
 		DefaultStorkRegistryBaseUrl,
 		time.Duration(0),
 		time.Duration(0),
+		time.Duration(0),
+		0,
 		"",
 		time.Duration(0),
 		"",
@@ -54,7 +56,14 @@ func TestDeltaOnly(t *testing.T) {
 		time.Duration(0),
 		time.Duration(0),
 		false,
+		nil,
+		0,
+		0,
 		0,
+		nil,
+		"",
+		"",
+		"",
 		[]BrokerConnectionConfig{},
 	)
 
@@ -73,6 +82,7 @@ func TestDeltaOnly(t *testing.T) {
 		config.DeltaCheckPeriod,
 		DefaultChangeThresholdPercent,
 		false,
+		nil,
 		inputCh,
 		outputCh,
 		logger,
@@ -134,6 +144,8 @@ func TestZeroPrice(t *testing.T) {
 		DefaultStorkRegistryBaseUrl,
 		time.Duration(0),
 		time.Duration(0),
+		time.Duration(0),
+		0,
 		"",
 		time.Duration(0),
 		"",
@@ -141,7 +153,14 @@ func TestZeroPrice(t *testing.T) {
 		time.Duration(0),
 		time.Duration(0),
 		false,
+		nil,
+		0,
+		0,
 		0,
+		nil,
+		"",
+		"",
+		"",
 		[]BrokerConnectionConfig{},
 	)
 
@@ -160,6 +179,7 @@ func TestZeroPrice(t *testing.T) {
 		config.DeltaCheckPeriod,
 		DefaultChangeThresholdPercent,
 		false,
+		nil,
 		inputCh,
 		outputCh,
 		logger,
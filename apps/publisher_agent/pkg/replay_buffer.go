@@ -0,0 +1,111 @@
+package publisher_agent
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Stork-Oracle/stork-external/shared"
+)
+
+type bufferedSignedPriceUpdate[T shared.Signature] struct {
+	update     SignedPriceUpdate[T]
+	bufferedAt time.Time
+}
+
+// ReplayBuffer holds the latest signed price update per asset for a broker while it is disconnected, so that the
+// broker receives recent prices as soon as it reconnects instead of waiting for the next clock update.
+// Updates are kept for at most ttl, and once maxAssets assets are buffered the least recently buffered is evicted.
+// A nil ReplayBuffer buffers nothing.
+type ReplayBuffer[T shared.Signature] struct {
+	ttl       time.Duration
+	maxAssets int
+	assets    *OutgoingWebsocketConnectionAssets[T]
+	lock      sync.Mutex
+	updates   map[shared.AssetID]bufferedSignedPriceUpdate[T]
+}
+
+// NewReplayBuffer returns a buffer for the broker's assets, or nil if ttl is not positive.
+func NewReplayBuffer[T shared.Signature](
+	ttl time.Duration,
+	maxAssets int,
+	assets *OutgoingWebsocketConnectionAssets[T],
+) *ReplayBuffer[T] {
+	if ttl <= 0 {
+		return nil
+	}
+
+	return &ReplayBuffer[T]{
+		ttl:       ttl,
+		maxAssets: maxAssets,
+		assets:    assets,
+		lock:      sync.Mutex{},
+		updates:   make(map[shared.AssetID]bufferedSignedPriceUpdate[T]),
+	}
+}
+
+// Add buffers the updates in the batch for the broker's assets, replacing any buffered update for the same asset.
+func (b *ReplayBuffer[T]) Add(now time.Time, signedPriceUpdateBatch SignedPriceUpdateBatch[T]) {
+	if b == nil {
+		return
+	}
+
+	filteredPriceUpdates := b.assets.filterSignedPriceUpdateBatch(signedPriceUpdateBatch)
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.prune(now)
+
+	for asset, signedPriceUpdate := range filteredPriceUpdates {
+		_, buffered := b.updates[asset]
+		if !buffered && b.maxAssets > 0 && len(b.updates) >= b.maxAssets {
+			b.evictOldest()
+		}
+
+		b.updates[asset] = bufferedSignedPriceUpdate[T]{update: signedPriceUpdate, bufferedAt: now}
+	}
+}
+
+// Drain empties the buffer, returning the buffered updates that have not expired.
+func (b *ReplayBuffer[T]) Drain(now time.Time) SignedPriceUpdateBatch[T] {
+	replay := make(SignedPriceUpdateBatch[T])
+	if b == nil {
+		return replay
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.prune(now)
+
+	for asset, buffered := range b.updates {
+		replay[asset] = buffered.update
+	}
+
+	b.updates = make(map[shared.AssetID]bufferedSignedPriceUpdate[T])
+
+	return replay
+}
+
+func (b *ReplayBuffer[T]) prune(now time.Time) {
+	for asset, buffered := range b.updates {
+		if now.Sub(buffered.bufferedAt) > b.ttl {
+			delete(b.updates, asset)
+		}
+	}
+}
+
+func (b *ReplayBuffer[T]) evictOldest() {
+	var (
+		oldestAsset shared.AssetID
+		oldest      time.Time
+	)
+
+	for asset, buffered := range b.updates {
+		if oldest.IsZero() || buffered.bufferedAt.Before(oldest) {
+			oldestAsset, oldest = asset, buffered.bufferedAt
+		}
+	}
+
+	delete(b.updates, oldestAsset)
+}
@@ -0,0 +1,155 @@
+package publisher_agent
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Stork-Oracle/stork-external/shared"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signedUpdate(asset shared.AssetID, price string) SignedPriceUpdate[*shared.EvmSignature] {
+	return SignedPriceUpdate[*shared.EvmSignature]{
+		AssetID:     asset,
+		SignedPrice: SignedPrice[*shared.EvmSignature]{QuantizedPrice: shared.QuantizedPrice(price)},
+	}
+}
+
+func signedBatch(updates ...SignedPriceUpdate[*shared.EvmSignature]) SignedPriceUpdateBatch[*shared.EvmSignature] {
+	batch := make(SignedPriceUpdateBatch[*shared.EvmSignature])
+	for _, update := range updates {
+		batch[update.AssetID] = update
+	}
+
+	return batch
+}
+
+type bufferedBatch struct {
+	offset time.Duration
+	batch  SignedPriceUpdateBatch[*shared.EvmSignature]
+}
+
+func TestReplayBuffer(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		ttl       time.Duration
+		maxAssets int
+		assets    map[shared.AssetID]struct{}
+		batches   []bufferedBatch
+		expected  SignedPriceUpdateBatch[*shared.EvmSignature]
+	}{
+		{
+			name:      "latest update per asset",
+			ttl:       time.Minute,
+			maxAssets: 10,
+			assets:    map[shared.AssetID]struct{}{WildcardSubscriptionAsset: {}},
+			batches: []bufferedBatch{
+				{-3 * time.Second, signedBatch(signedUpdate("BTCUSD", "1"), signedUpdate("ETHUSD", "2"))},
+				{-2 * time.Second, signedBatch(signedUpdate("BTCUSD", "3"))},
+			},
+			expected: signedBatch(signedUpdate("BTCUSD", "3"), signedUpdate("ETHUSD", "2")),
+		},
+		{
+			name:      "expired updates are not replayed",
+			ttl:       5 * time.Second,
+			maxAssets: 10,
+			assets:    map[shared.AssetID]struct{}{WildcardSubscriptionAsset: {}},
+			batches: []bufferedBatch{
+				{-10 * time.Second, signedBatch(signedUpdate("BTCUSD", "1"), signedUpdate("ETHUSD", "2"))},
+				{-2 * time.Second, signedBatch(signedUpdate("BTCUSD", "3"))},
+			},
+			expected: signedBatch(signedUpdate("BTCUSD", "3")),
+		},
+		{
+			name:      "only the broker's assets",
+			ttl:       time.Minute,
+			maxAssets: 10,
+			assets:    map[shared.AssetID]struct{}{"ETHUSD": {}},
+			batches: []bufferedBatch{
+				{-time.Second, signedBatch(signedUpdate("BTCUSD", "1"), signedUpdate("ETHUSD", "2"))},
+			},
+			expected: signedBatch(signedUpdate("ETHUSD", "2")),
+		},
+		{
+			name:      "least recently buffered asset is evicted",
+			ttl:       time.Minute,
+			maxAssets: 2,
+			assets:    map[shared.AssetID]struct{}{WildcardSubscriptionAsset: {}},
+			batches: []bufferedBatch{
+				{-3 * time.Second, signedBatch(signedUpdate("BTCUSD", "1"))},
+				{-2 * time.Second, signedBatch(signedUpdate("ETHUSD", "2"))},
+				{-1 * time.Second, signedBatch(signedUpdate("SOLUSD", "3"))},
+			},
+			expected: signedBatch(signedUpdate("ETHUSD", "2"), signedUpdate("SOLUSD", "3")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			buffer := NewReplayBuffer(
+				tt.ttl,
+				tt.maxAssets,
+				NewOutgoingWebsocketConnectionAssets[*shared.EvmSignature](tt.assets),
+			)
+
+			for _, batch := range tt.batches {
+				buffer.Add(now.Add(batch.offset), batch.batch)
+			}
+
+			assert.Equal(t, tt.expected, buffer.Drain(now))
+			assert.Empty(t, buffer.Drain(now), "draining should empty the buffer")
+		})
+	}
+}
+
+func TestReplayBufferDisabled(t *testing.T) {
+	t.Parallel()
+
+	buffer := NewReplayBuffer[*shared.EvmSignature](0, DefaultBrokerReplayBufferMaxAssets, nil)
+	require.Nil(t, buffer)
+
+	buffer.Add(time.Now(), signedBatch(signedUpdate("BTCUSD", "1")))
+	assert.Empty(t, buffer.Drain(time.Now()))
+}
+
+// Batches signed while a broker is disconnected are buffered for it instead of only being dropped.
+func TestFanOutBuffersForDisconnectedBrokers(t *testing.T) {
+	t.Parallel()
+
+	brokerPublishUrl1 := BrokerPublishUrl("wss://broker1.example.com")
+
+	runner := &PublisherAgentRunner[*shared.EvmSignature]{
+		signedPriceBatchCh:          make(chan SignedPriceUpdateBatch[*shared.EvmSignature]),
+		outgoingConnectionsByBroker: make(map[BrokerPublishUrl]*OutgoingWebsocketConnection[*shared.EvmSignature]),
+		replayBuffersByBroker:       make(map[BrokerPublishUrl]*ReplayBuffer[*shared.EvmSignature]),
+		outgoingConnectionsLock:     sync.RWMutex{},
+		logger:                      zerolog.Nop(),
+	}
+
+	assets := NewOutgoingWebsocketConnectionAssets[*shared.EvmSignature](
+		map[shared.AssetID]struct{}{WildcardSubscriptionAsset: {}},
+	)
+	buffer := NewReplayBuffer(time.Minute, DefaultBrokerReplayBufferMaxAssets, assets)
+	runner.setReplayBuffer(brokerPublishUrl1, buffer)
+
+	go runner.FanOutSignedPriceBatches()
+
+	runner.signedPriceBatchCh <- signedBatch(signedUpdate("BTCUSD", "1"))
+	runner.signedPriceBatchCh <- signedBatch(signedUpdate("BTCUSD", "2"))
+	// the unbuffered channel hands over the third batch only once the second has been fanned out
+	runner.signedPriceBatchCh <- signedBatch()
+
+	assert.Equal(t, signedBatch(signedUpdate("BTCUSD", "2")), buffer.Drain(time.Now()))
+
+	runner.setReplayBuffer(brokerPublishUrl1, nil)
+	assert.Empty(t, runner.replayBuffersByBroker)
+}
@@ -26,6 +26,9 @@ type PublisherAgentRunner[T shared.Signature] struct {
 	signer                      signer.Signer[T]
 	storkAuthSigner             signer.StorkAuthSigner
 	publisherMetadataReporter   *PublisherMetadataReporter
+	health                      *RunnerHealth
+	// replayBuffersByBroker holds the replay buffers of disconnected brokers, guarded by outgoingConnectionsLock
+	replayBuffersByBroker map[BrokerPublishUrl]*ReplayBuffer[T]
 }
 
 func NewPublisherAgentRunner[T shared.Signature](
@@ -76,14 +79,21 @@ func NewPublisherAgentRunner[T shared.Signature](
 		seededBrokers:               seededBrokers,
 		assetsByBroker:              make(map[BrokerPublishUrl]map[shared.AssetID]struct{}),
 		outgoingConnectionsByBroker: make(map[BrokerPublishUrl]*OutgoingWebsocketConnection[T]),
+		replayBuffersByBroker:       make(map[BrokerPublishUrl]*ReplayBuffer[T]),
 		outgoingConnectionsLock:     sync.RWMutex{},
 		wsConnectFn:                 wsConnectFn,
 		signer:                      signer,
 		storkAuthSigner:             storkAuthSigner,
 		publisherMetadataReporter:   publisherMetadataReporter,
+		health:                      NewRunnerHealth(signatureType),
 	}
 }
 
+// Health reports the broker connections and registry refreshes of the runner.
+func (r *PublisherAgentRunner[T]) Health() RunnerHealthStatus {
+	return r.health.Status(time.Now())
+}
+
 func (r *PublisherAgentRunner[T]) UpdateBrokerConnections() {
 	r.logger.Debug().Msg("Running broker connection updater")
 
@@ -91,12 +101,14 @@ func (r *PublisherAgentRunner[T]) UpdateBrokerConnections() {
 
 	publicKey := r.signer.GetPublisherKey()
 	registryBrokers, err := r.registryClient.GetBrokersForPublisher(publicKey)
+	r.health.RecordRegistryRefresh(time.Now(), err)
 	if err != nil {
 		r.logger.Error().Err(err).Msg("failed to get broker connections from Stork Registry")
 		return
 	}
 
 	newBrokerMap := r.mergeBrokers(registryBrokers, r.seededBrokers)
+	incomingAssets.Update(r.signatureType, newBrokerMap)
 
 	// add or update desired connections
 	r.outgoingConnectionsLock.RLock()
@@ -124,6 +136,8 @@ func (r *PublisherAgentRunner[T]) UpdateBrokerConnections() {
 			if outgoingConnectionExists {
 				outgoingConnection.Remove()
 			}
+			r.setReplayBuffer(url, nil)
+			r.health.RemoveBroker(url)
 			delete(r.assetsByBroker, url)
 		}
 	}
@@ -148,6 +162,7 @@ func (r *PublisherAgentRunner[T]) Run() {
 		r.config.DeltaCheckPeriod,
 		r.config.ChangeThresholdProportion,
 		r.config.SignEveryUpdate,
+		r.config.AssetSigningPolicies,
 		r.ValueUpdateCh,
 		r.signedPriceBatchCh,
 		r.logger,
@@ -158,6 +173,8 @@ func (r *PublisherAgentRunner[T]) Run() {
 
 	go r.RunBrokerConnectionUpdater()
 
+	go r.RunQueueDepthReporter()
+
 	if r.config.PublisherMetadataUpdateInterval.Nanoseconds() > 0 {
 		go r.publisherMetadataReporter.Run()
 	}
@@ -172,18 +189,19 @@ func (r *PublisherAgentRunner[T]) FanOutSignedPriceBatches() {
 	for signedPriceUpdateBatch := range r.signedPriceBatchCh {
 		// copy the connections out so we never write to a connection's channel while holding the lock -
 		// a blocking write under the read lock deadlocks against onClose taking the write lock
-		r.outgoingConnectionsLock.RLock()
-		outgoingConnections := make([]*OutgoingWebsocketConnection[T], 0, len(r.outgoingConnectionsByBroker))
-		for _, outgoingConnection := range r.outgoingConnectionsByBroker {
-			outgoingConnections = append(outgoingConnections, outgoingConnection)
+		outgoingConnections, replayBuffers := r.copyFanOutTargets()
+
+		now := time.Now()
+		for _, replayBuffer := range replayBuffers {
+			replayBuffer.Add(now, signedPriceUpdateBatch)
 		}
-		r.outgoingConnectionsLock.RUnlock()
 
 		// a slow or dead broker must not block the other brokers or back up the signing pipeline
-		for _, outgoingConnection := range outgoingConnections {
+		for url, outgoingConnection := range outgoingConnections {
 			select {
 			case outgoingConnection.signedPriceUpdateBatchCh <- signedPriceUpdateBatch:
 			default:
+				r.health.RecordDropped(url)
 				if time.Since(lastDropLogTime) >= FullQueueLogFrequency {
 					r.logger.Error().Msg("dropped signed price update batch - outgoing websocket queue is full")
 					lastDropLogTime = time.Now()
@@ -193,8 +211,49 @@ func (r *PublisherAgentRunner[T]) FanOutSignedPriceBatches() {
 	}
 }
 
+// copyFanOutTargets copies out the connected brokers and the replay buffers of the disconnected brokers.
+func (r *PublisherAgentRunner[T]) copyFanOutTargets() (
+	map[BrokerPublishUrl]*OutgoingWebsocketConnection[T],
+	map[BrokerPublishUrl]*ReplayBuffer[T],
+) {
+	r.outgoingConnectionsLock.RLock()
+	defer r.outgoingConnectionsLock.RUnlock()
+
+	outgoingConnections := make(map[BrokerPublishUrl]*OutgoingWebsocketConnection[T], len(r.outgoingConnectionsByBroker))
+	for url, outgoingConnection := range r.outgoingConnectionsByBroker {
+		outgoingConnections[url] = outgoingConnection
+	}
+
+	replayBuffers := make(map[BrokerPublishUrl]*ReplayBuffer[T], len(r.replayBuffersByBroker))
+	for url, replayBuffer := range r.replayBuffersByBroker {
+		replayBuffers[url] = replayBuffer
+	}
+
+	return outgoingConnections, replayBuffers
+}
+
+// RunQueueDepthReporter periodically reports the depth of the runner's queues and of each broker's outgoing queue.
+func (r *PublisherAgentRunner[T]) RunQueueDepthReporter() {
+	signatureType := string(r.signatureType)
+
+	for range time.Tick(QueueDepthSampleInterval) {
+		queueDepth.WithLabelValues(signatureType, ValueUpdateQueue).Set(float64(len(r.ValueUpdateCh)))
+		queueDepth.WithLabelValues(signatureType, SignedBatchQueue).Set(float64(len(r.signedPriceBatchCh)))
+
+		outgoingConnections, _ := r.copyFanOutTargets()
+		for url, outgoingConnection := range outgoingConnections {
+			brokerQueueDepth.WithLabelValues(signatureType, string(url)).
+				Set(float64(len(outgoingConnection.signedPriceUpdateBatchCh)))
+		}
+	}
+}
+
 func (r *PublisherAgentRunner[T]) RunOutgoingConnection(url BrokerPublishUrl, assetIds map[shared.AssetID]struct{}) {
 	assets := NewOutgoingWebsocketConnectionAssets[T](assetIds)
+	replayBuffer := NewReplayBuffer(r.config.BrokerReplayBufferTTL, r.config.BrokerReplayBufferMaxAssets, assets)
+
+	// buffer until the first connection too, so the broker receives the latest prices as soon as it connects
+	r.setReplayBuffer(url, replayBuffer)
 
 	for {
 		r.logger.Debug().Msgf("Connecting to receiver WebSocket with url %s", url)
@@ -207,6 +266,7 @@ func (r *PublisherAgentRunner[T]) RunOutgoingConnection(url BrokerPublishUrl, as
 		conn, err := r.wsConnectFn(string(url), headers)
 		if err != nil {
 			r.logger.Error().Err(err).Msgf("Failed to connect to outgoing WebSocket: %v", err)
+			r.health.SetBrokerConnected(url, false)
 			time.Sleep(r.config.BrokerReconnectDelay)
 			continue
 		}
@@ -220,21 +280,40 @@ func (r *PublisherAgentRunner[T]) RunOutgoingConnection(url BrokerPublishUrl, as
 				r.logger.Info().Str("broker_url", string(url)).Msg("removing receiver websocket")
 				r.outgoingConnectionsLock.Lock()
 				delete(r.outgoingConnectionsByBroker, url)
+				if replayBuffer != nil {
+					r.replayBuffersByBroker[url] = replayBuffer
+				}
 				r.outgoingConnectionsLock.Unlock()
+				r.health.SetBrokerConnected(url, false)
+			},
+		)
+		outgoingWebsocketConn := NewOutgoingWebsocketConnection(
+			websocketConn,
+			assets,
+			r.logger,
+			func() {
+				r.health.RecordSent(url, time.Now())
 			},
 		)
-		outgoingWebsocketConn := NewOutgoingWebsocketConnection(websocketConn, assets, r.logger)
 
-		// add subscriber to list
+		// add subscriber to list, queueing the buffered updates ahead of any new batch
 		r.outgoingConnectionsLock.Lock()
+		delete(r.replayBuffersByBroker, url)
+		replay := replayBuffer.Drain(time.Now())
+		if len(replay) > 0 {
+			outgoingWebsocketConn.signedPriceUpdateBatchCh <- replay
+			r.health.RecordReplayed(url, len(replay))
+		}
 		r.outgoingConnectionsByBroker[url] = outgoingWebsocketConn
 		r.outgoingConnectionsLock.Unlock()
+		r.health.SetBrokerConnected(url, true)
 
 		// read until a failure happens or the connection is closed
 		outgoingWebsocketConn.Writer()
 
 		if outgoingWebsocketConn.removed {
 			r.logger.Info().Msg("Outgoing websocket was removed - not reconnecting")
+			r.setReplayBuffer(url, nil)
 			return
 		} else {
 			r.logger.Warn().Msgf("Outgoing websocket writer thread failed - reconnecting after %s", r.config.BrokerReconnectDelay)
@@ -243,6 +322,19 @@ func (r *PublisherAgentRunner[T]) RunOutgoingConnection(url BrokerPublishUrl, as
 	}
 }
 
+// setReplayBuffer starts buffering updates for a disconnected broker, or stops if replayBuffer is nil.
+func (r *PublisherAgentRunner[T]) setReplayBuffer(url BrokerPublishUrl, replayBuffer *ReplayBuffer[T]) {
+	r.outgoingConnectionsLock.Lock()
+	defer r.outgoingConnectionsLock.Unlock()
+
+	if replayBuffer == nil {
+		delete(r.replayBuffersByBroker, url)
+		return
+	}
+
+	r.replayBuffersByBroker[url] = replayBuffer
+}
+
 func (r *PublisherAgentRunner[T]) mergeBrokers(
 	registryBrokers map[BrokerPublishUrl]map[shared.AssetID]struct{},
 	seededBrokers map[BrokerPublishUrl]map[shared.AssetID]struct{},
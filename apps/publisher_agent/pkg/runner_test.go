@@ -149,7 +149,7 @@ func TestFanOutDoesNotBlockRemovalWhenBrokerQueueIsFull(t *testing.T) {
 		runner.outgoingConnectionsLock.Unlock()
 	})
 	assets := NewOutgoingWebsocketConnectionAssets[*shared.EvmSignature](map[shared.AssetID]struct{}{"BTCUSD": {}})
-	outgoingConnection := NewOutgoingWebsocketConnection(websocketConn, assets, zerolog.Nop())
+	outgoingConnection := NewOutgoingWebsocketConnection(websocketConn, assets, zerolog.Nop(), func() {})
 
 	runner.outgoingConnectionsByBroker[brokerPublishUrl1] = outgoingConnection
 
@@ -0,0 +1,198 @@
+package publisher_agent
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Stork-Oracle/stork-external/shared"
+)
+
+var (
+	ErrMissingSigningPolicyAsset = errors.New("asset signing policy must specify an asset")
+	ErrInvalidSigningPolicyAsset = errors.New("asset signing policy may only use * at the end of the asset")
+)
+
+// SigningPolicy decides when the processor signs the values of an asset.
+type SigningPolicy struct {
+	// ClockPeriod is how often the latest value is signed regardless of changes, 0 disables clock updates
+	ClockPeriod               time.Duration
+	ChangeThresholdProportion float64 // 0-1
+	SignEveryUpdate           bool
+	// MinSigningInterval limits how often the asset is signed, 0 means no limit
+	MinSigningInterval time.Duration
+}
+
+// AssetSigningPolicyConfig overrides the global signing settings for the assets matching Asset, which is an asset id, a
+// prefix ending in * (e.g. USDC*) or * for every asset. Unset fields keep the global setting.
+type AssetSigningPolicyConfig struct {
+	Asset                  string
+	ClockPeriod            string
+	ChangeThresholdPercent float64 // 0-100
+	SignEveryUpdate        *bool
+	MaxSignaturesPerSecond float64
+}
+
+type AssetSigningPolicy struct {
+	Pattern string
+	Policy  SigningPolicy
+}
+
+// NewAssetSigningPolicy applies the overrides in the config to the default policy.
+func NewAssetSigningPolicy(config AssetSigningPolicyConfig, defaultPolicy SigningPolicy) (AssetSigningPolicy, error) {
+	if len(config.Asset) == 0 {
+		return AssetSigningPolicy{}, ErrMissingSigningPolicyAsset
+	}
+
+	if strings.Contains(strings.TrimSuffix(config.Asset, "*"), "*") {
+		return AssetSigningPolicy{}, ErrInvalidSigningPolicyAsset
+	}
+
+	policy := defaultPolicy
+
+	if len(config.ClockPeriod) > 0 {
+		clockPeriod, err := time.ParseDuration(config.ClockPeriod)
+		if err != nil || clockPeriod < 0 {
+			return AssetSigningPolicy{}, fmt.Errorf("invalid clock period for %s: %s", config.Asset, config.ClockPeriod)
+		}
+
+		policy.ClockPeriod = clockPeriod
+	}
+
+	if config.ChangeThresholdPercent < 0 {
+		return AssetSigningPolicy{}, fmt.Errorf("change threshold percent for %s must be positive", config.Asset)
+	}
+
+	if config.ChangeThresholdPercent > 0 {
+		policy.ChangeThresholdProportion = config.ChangeThresholdPercent / 100.0
+	}
+
+	if config.SignEveryUpdate != nil {
+		policy.SignEveryUpdate = *config.SignEveryUpdate
+	}
+
+	if config.MaxSignaturesPerSecond < 0 {
+		return AssetSigningPolicy{}, fmt.Errorf("max signatures per second for %s must not be negative", config.Asset)
+	}
+
+	if config.MaxSignaturesPerSecond > 0 {
+		policy.MinSigningInterval = time.Duration(float64(time.Second) / config.MaxSignaturesPerSecond)
+	}
+
+	return AssetSigningPolicy{Pattern: config.Asset, Policy: policy}, nil
+}
+
+// SigningPolicies resolves the policy of each asset. An exact asset id takes precedence, then the longest matching
+// prefix, then *, and assets matching none of them use the default policy. Resolved policies are cached, so
+// SigningPolicies must only be used from one goroutine.
+type SigningPolicies struct {
+	defaultPolicy  SigningPolicy
+	exactPolicies  map[shared.AssetID]SigningPolicy
+	prefixPolicies []AssetSigningPolicy
+	resolved       map[shared.AssetID]SigningPolicy
+}
+
+func NewSigningPolicies(defaultPolicy SigningPolicy, assetPolicies []AssetSigningPolicy) *SigningPolicies {
+	policies := &SigningPolicies{
+		defaultPolicy:  defaultPolicy,
+		exactPolicies:  make(map[shared.AssetID]SigningPolicy),
+		prefixPolicies: make([]AssetSigningPolicy, 0),
+		resolved:       make(map[shared.AssetID]SigningPolicy),
+	}
+
+	for _, assetPolicy := range assetPolicies {
+		prefix, isPrefix := strings.CutSuffix(assetPolicy.Pattern, "*")
+		if !isPrefix {
+			policies.exactPolicies[shared.AssetID(assetPolicy.Pattern)] = assetPolicy.Policy
+			continue
+		}
+
+		policies.prefixPolicies = append(
+			policies.prefixPolicies,
+			AssetSigningPolicy{Pattern: prefix, Policy: assetPolicy.Policy},
+		)
+	}
+
+	// the first matching prefix is the longest
+	slices.SortStableFunc(policies.prefixPolicies, func(a, b AssetSigningPolicy) int {
+		return len(b.Pattern) - len(a.Pattern)
+	})
+
+	return policies
+}
+
+func (p *SigningPolicies) ForAsset(asset shared.AssetID) SigningPolicy {
+	if policy, exists := p.resolved[asset]; exists {
+		return policy
+	}
+
+	policy, exists := p.exactPolicies[asset]
+	if !exists {
+		policy = p.defaultPolicy
+
+		for _, prefixPolicy := range p.prefixPolicies {
+			if strings.HasPrefix(string(asset), prefixPolicy.Pattern) {
+				policy = prefixPolicy.Policy
+				break
+			}
+		}
+	}
+
+	p.resolved[asset] = policy
+
+	return policy
+}
+
+func (p *SigningPolicies) all() []SigningPolicy {
+	policies := []SigningPolicy{p.defaultPolicy}
+	for _, policy := range p.exactPolicies {
+		policies = append(policies, policy)
+	}
+
+	for _, prefixPolicy := range p.prefixPolicies {
+		policies = append(policies, prefixPolicy.Policy)
+	}
+
+	return policies
+}
+
+// MinClockTickPeriod bounds how often the clock ticks when clock periods have a tiny common divisor.
+const MinClockTickPeriod = 10 * time.Millisecond
+
+// ClockTickPeriod returns how often the clock must tick for every policy's clock updates to land on a tick, which is
+// the greatest common divisor of the clock periods but no less than MinClockTickPeriod or the shortest clock period.
+// It returns 0 if no policy has clock updates.
+func (p *SigningPolicies) ClockTickPeriod() time.Duration {
+	var tickPeriod, minClockPeriod time.Duration
+
+	for _, policy := range p.all() {
+		if policy.ClockPeriod <= 0 {
+			continue
+		}
+
+		if minClockPeriod == 0 || policy.ClockPeriod < minClockPeriod {
+			minClockPeriod = policy.ClockPeriod
+		}
+
+		tickPeriod = gcdDuration(tickPeriod, policy.ClockPeriod)
+	}
+
+	return max(tickPeriod, min(MinClockTickPeriod, minClockPeriod))
+}
+
+func gcdDuration(a, b time.Duration) time.Duration {
+	for b != 0 {
+		a, b = b, a%b
+	}
+
+	return a
+}
+
+// NeedsDeltaChecks reports whether any policy signs on changes rather than every update.
+func (p *SigningPolicies) NeedsDeltaChecks() bool {
+	return slices.ContainsFunc(p.all(), func(policy SigningPolicy) bool {
+		return !policy.SignEveryUpdate
+	})
+}
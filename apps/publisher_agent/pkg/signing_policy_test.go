@@ -0,0 +1,319 @@
+package publisher_agent
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Stork-Oracle/stork-external/shared"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:gochecknoglobals
+var testDefaultSigningPolicy = SigningPolicy{
+	ClockPeriod:               500 * time.Millisecond,
+	ChangeThresholdProportion: 0.001,
+	SignEveryUpdate:           false,
+}
+
+func TestNewAssetSigningPolicy(t *testing.T) {
+	t.Parallel()
+
+	signEveryUpdate := true
+
+	assetSigningPolicy, err := NewAssetSigningPolicy(
+		AssetSigningPolicyConfig{
+			Asset:                  "USDC*",
+			ClockPeriod:            "5s",
+			SignEveryUpdate:        &signEveryUpdate,
+			MaxSignaturesPerSecond: 4,
+		},
+		testDefaultSigningPolicy,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, AssetSigningPolicy{
+		Pattern: "USDC*",
+		Policy: SigningPolicy{
+			ClockPeriod:               5 * time.Second,
+			ChangeThresholdProportion: testDefaultSigningPolicy.ChangeThresholdProportion,
+			SignEveryUpdate:           true,
+			MinSigningInterval:        250 * time.Millisecond,
+		},
+	}, assetSigningPolicy)
+
+	_, err = NewAssetSigningPolicy(AssetSigningPolicyConfig{Asset: ""}, testDefaultSigningPolicy)
+	require.ErrorIs(t, err, ErrMissingSigningPolicyAsset)
+
+	_, err = NewAssetSigningPolicy(AssetSigningPolicyConfig{Asset: "*USD"}, testDefaultSigningPolicy)
+	require.ErrorIs(t, err, ErrInvalidSigningPolicyAsset)
+
+	_, err = NewAssetSigningPolicy(AssetSigningPolicyConfig{Asset: "BTCUSD", ClockPeriod: "-1s"}, testDefaultSigningPolicy)
+	require.Error(t, err)
+}
+
+func TestSigningPoliciesForAsset(t *testing.T) {
+	t.Parallel()
+
+	policy := func(changeThresholdProportion float64) SigningPolicy {
+		return SigningPolicy{ClockPeriod: time.Second, ChangeThresholdProportion: changeThresholdProportion}
+	}
+
+	policies := NewSigningPolicies(testDefaultSigningPolicy, []AssetSigningPolicy{
+		{Pattern: "*", Policy: policy(0.01)},
+		{Pattern: "USD*", Policy: policy(0.02)},
+		{Pattern: "USDC*", Policy: policy(0.03)},
+		{Pattern: "USDCUSD", Policy: policy(0.04)},
+	})
+
+	tests := []struct {
+		asset    shared.AssetID
+		expected SigningPolicy
+	}{
+		{asset: "USDCUSD", expected: policy(0.04)},
+		{asset: "USDCEUR", expected: policy(0.03)},
+		{asset: "USDTUSD", expected: policy(0.02)},
+		{asset: "BTCUSD", expected: policy(0.01)},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, policies.ForAsset(tt.asset), tt.asset)
+	}
+
+	assert.Equal(
+		t,
+		testDefaultSigningPolicy,
+		NewSigningPolicies(testDefaultSigningPolicy, nil).ForAsset("BTCUSD"),
+		"assets without a matching policy use the default",
+	)
+}
+
+func TestSigningPoliciesTicks(t *testing.T) {
+	t.Parallel()
+
+	signEveryUpdate := SigningPolicy{SignEveryUpdate: true}
+	policies := NewSigningPolicies(signEveryUpdate, []AssetSigningPolicy{
+		{Pattern: "BTCUSD", Policy: SigningPolicy{ClockPeriod: 2 * time.Second}},
+		{Pattern: "ETH*", Policy: SigningPolicy{ClockPeriod: time.Second, SignEveryUpdate: true}},
+	})
+	assert.Equal(t, time.Second, policies.ClockTickPeriod())
+	assert.True(t, policies.NeedsDeltaChecks())
+
+	policies = NewSigningPolicies(signEveryUpdate, nil)
+	assert.Equal(t, time.Duration(0), policies.ClockTickPeriod())
+	assert.False(t, policies.NeedsDeltaChecks())
+
+	// periods that are not multiples of each other tick at their greatest common divisor
+	policies = NewSigningPolicies(testDefaultSigningPolicy, []AssetSigningPolicy{
+		{Pattern: "BTCUSD", Policy: SigningPolicy{ClockPeriod: 1200 * time.Millisecond}},
+	})
+	assert.Equal(t, 100*time.Millisecond, policies.ClockTickPeriod())
+
+	// but no faster than MinClockTickPeriod
+	policies = NewSigningPolicies(testDefaultSigningPolicy, []AssetSigningPolicy{
+		{Pattern: "BTCUSD", Policy: SigningPolicy{ClockPeriod: 500*time.Millisecond + time.Microsecond}},
+	})
+	assert.Equal(t, MinClockTickPeriod, policies.ClockTickPeriod())
+}
+
+func TestPerAssetDeltaAndClockUpdates(t *testing.T) {
+	t.Parallel()
+
+	processor := NewPriceUpdateProcessor[*shared.EvmSignature](
+		nil,
+		"czowx",
+		1,
+		testDefaultSigningPolicy.ClockPeriod,
+		10*time.Millisecond,
+		testDefaultSigningPolicy.ChangeThresholdProportion,
+		false,
+		[]AssetSigningPolicy{
+			{Pattern: "USDC*", Policy: SigningPolicy{ClockPeriod: time.Hour, ChangeThresholdProportion: 0.1}},
+			{Pattern: "SOLUSD", Policy: SigningPolicy{SignEveryUpdate: true}},
+		},
+		nil,
+		nil,
+		zerolog.Nop(),
+	)
+
+	for _, asset := range []shared.AssetID{"BTCUSD", "USDCUSD", "SOLUSD"} {
+		processor.valueUpdates[asset] = ValueUpdate{Asset: asset, Value: big.NewFloat(1.05)}
+		processor.lastReportedPrice[asset] = 1.0
+	}
+
+	updatedAssets := func(updates []ValueUpdateWithTrigger) []shared.AssetID {
+		assets := make([]shared.AssetID, 0, len(updates))
+		for _, update := range updates {
+			assets = append(assets, update.ValueUpdate.Asset)
+		}
+
+		return assets
+	}
+
+	// a 5% change exceeds the default threshold but not the 10% threshold for USDC, and SOLUSD signs every update
+	assert.ElementsMatch(t, []shared.AssetID{"BTCUSD"}, updatedAssets(processor.DeltaUpdate()))
+
+	// SOLUSD has no clock updates, and USDCUSD only gets one every hour
+	now := time.Now()
+	assert.ElementsMatch(t, []shared.AssetID{"BTCUSD", "USDCUSD"}, updatedAssets(processor.ClockUpdate(now)))
+	processor.recordClockUpdate("BTCUSD", now)
+	processor.recordClockUpdate("USDCUSD", now)
+	assert.ElementsMatch(
+		t,
+		[]shared.AssetID{"BTCUSD"},
+		updatedAssets(processor.ClockUpdate(now.Add(testDefaultSigningPolicy.ClockPeriod))),
+	)
+}
+
+func TestClockUpdatePeriodNotMultipleOfTick(t *testing.T) {
+	t.Parallel()
+
+	processor := NewPriceUpdateProcessor[*shared.EvmSignature](
+		nil,
+		"czowx",
+		1,
+		testDefaultSigningPolicy.ClockPeriod,
+		10*time.Millisecond,
+		testDefaultSigningPolicy.ChangeThresholdProportion,
+		false,
+		[]AssetSigningPolicy{{Pattern: "BTCUSD", Policy: SigningPolicy{ClockPeriod: 1200 * time.Millisecond}}},
+		nil,
+		nil,
+		zerolog.Nop(),
+	)
+	processor.valueUpdates["BTCUSD"] = ValueUpdate{Asset: "BTCUSD", Value: big.NewFloat(1)}
+
+	// tick for 6 seconds, a few milliseconds late each time, and record every update as queued
+	start := time.Now()
+	updateTimes := make([]time.Duration, 0)
+
+	for tick := range 60 {
+		offset := time.Duration(tick)*processor.clockTickPeriod + time.Duration(tick%3)*time.Millisecond
+		for range processor.ClockUpdate(start.Add(offset)) {
+			processor.recordClockUpdate("BTCUSD", start.Add(offset))
+			updateTimes = append(updateTimes, offset.Round(100*time.Millisecond))
+		}
+	}
+
+	assert.Equal(t, []time.Duration{
+		0, 1200 * time.Millisecond, 2400 * time.Millisecond, 3600 * time.Millisecond, 4800 * time.Millisecond,
+	}, updateTimes)
+}
+
+func TestRateLimitedClockUpdateStaysDue(t *testing.T) {
+	t.Parallel()
+
+	processor := NewPriceUpdateProcessor[*shared.EvmSignature](
+		nil,
+		"czowx",
+		1,
+		testDefaultSigningPolicy.ClockPeriod,
+		10*time.Millisecond,
+		testDefaultSigningPolicy.ChangeThresholdProportion,
+		false,
+		[]AssetSigningPolicy{{
+			Pattern: "BTCUSD",
+			Policy:  SigningPolicy{ClockPeriod: testDefaultSigningPolicy.ClockPeriod, MinSigningInterval: time.Hour},
+		}},
+		nil,
+		nil,
+		zerolog.Nop(),
+	)
+	processor.valueUpdates["BTCUSD"] = ValueUpdate{Asset: "BTCUSD", Value: big.NewFloat(1)}
+	processor.lastSigned["BTCUSD"] = time.Now()
+
+	priceUpdatesToSignCh := make(chan ValueUpdateWithTrigger, 1)
+	now := time.Now()
+
+	updates := processor.ClockUpdate(now)
+	require.Len(t, updates, 1)
+	assert.False(t, processor.queueForSigning(updates[0], priceUpdatesToSignCh, shared.EvmSignatureType))
+
+	// the update was not queued, so the asset is still due on the next tick
+	assert.Len(t, processor.ClockUpdate(now.Add(processor.clockTickPeriod)), 1)
+}
+
+func TestRateLimitedSignEveryUpdate(t *testing.T) {
+	t.Parallel()
+
+	minSigningInterval := 50 * time.Millisecond
+	processor := NewPriceUpdateProcessor[*shared.EvmSignature](
+		nil,
+		"czowx",
+		1,
+		0,
+		10*time.Millisecond,
+		testDefaultSigningPolicy.ChangeThresholdProportion,
+		true,
+		[]AssetSigningPolicy{{
+			Pattern: "BTCUSD",
+			Policy:  SigningPolicy{SignEveryUpdate: true, MinSigningInterval: minSigningInterval},
+		}},
+		nil,
+		nil,
+		zerolog.Nop(),
+	)
+
+	q := make(chan any, 1)
+	priceUpdatesToSignCh := make(chan ValueUpdateWithTrigger, 4)
+	update := func(value float64) ValueUpdateWithTrigger {
+		return ValueUpdateWithTrigger{
+			ValueUpdate: ValueUpdate{Asset: "BTCUSD", Value: big.NewFloat(value)},
+			TriggerType: UnspecifiedTriggerType,
+		}
+	}
+
+	require.True(t, processor.queueForSigning(update(1), priceUpdatesToSignCh, shared.EvmSignatureType))
+
+	// updates within the rate limit are kept, and only the newest is signed once the rate limit allows it
+	for _, value := range []float64{2, 3} {
+		require.False(t, processor.queueForSigning(update(value), priceUpdatesToSignCh, shared.EvmSignatureType))
+		processor.deferSigning(update(value), q)
+	}
+
+	select {
+	case tick := <-q:
+		require.Equal(t, RateLimitTick{Asset: "BTCUSD"}, tick)
+		processor.signRateLimitedUpdate(tick.(RateLimitTick).Asset, q, priceUpdatesToSignCh, shared.EvmSignatureType)
+	case <-time.After(time.Second):
+		t.Fatal("rate limited update was never signed")
+	}
+
+	require.Len(t, priceUpdatesToSignCh, 2)
+	<-priceUpdatesToSignCh
+	signed := <-priceUpdatesToSignCh
+	value, _ := signed.ValueUpdate.Value.Float64()
+	assert.InDelta(t, 3.0, value, 0)
+	assert.Empty(t, processor.rateLimitedUpdates)
+	assert.False(t, processor.rateLimitTickScheduled["BTCUSD"])
+}
+
+func TestQueueForSigningRateLimit(t *testing.T) {
+	t.Parallel()
+
+	processor := NewPriceUpdateProcessor[*shared.EvmSignature](
+		nil,
+		"czowx",
+		1,
+		testDefaultSigningPolicy.ClockPeriod,
+		10*time.Millisecond,
+		testDefaultSigningPolicy.ChangeThresholdProportion,
+		false,
+		[]AssetSigningPolicy{{Pattern: "BTCUSD", Policy: SigningPolicy{MinSigningInterval: time.Hour}}},
+		nil,
+		nil,
+		zerolog.Nop(),
+	)
+
+	priceUpdatesToSignCh := make(chan ValueUpdateWithTrigger, 4)
+	update := func(asset shared.AssetID) ValueUpdateWithTrigger {
+		return ValueUpdateWithTrigger{ValueUpdate: ValueUpdate{Asset: asset}, TriggerType: DeltaTriggerType}
+	}
+
+	assert.True(t, processor.queueForSigning(update("BTCUSD"), priceUpdatesToSignCh, shared.EvmSignatureType))
+	assert.False(t, processor.queueForSigning(update("BTCUSD"), priceUpdatesToSignCh, shared.EvmSignatureType))
+	assert.True(t, processor.queueForSigning(update("ETHUSD"), priceUpdatesToSignCh, shared.EvmSignatureType))
+	assert.True(t, processor.queueForSigning(update("ETHUSD"), priceUpdatesToSignCh, shared.EvmSignatureType))
+	assert.Len(t, priceUpdatesToSignCh, 3)
+}
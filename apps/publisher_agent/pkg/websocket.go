@@ -156,15 +156,10 @@ func (iwc *IncomingWebsocketConnection) Reader(valueUpdateChannels []chan ValueU
 						}
 						break
 					}
-					for _, valueUpdateCh := range valueUpdateChannels {
-						select {
-						case valueUpdateCh <- *valueUpdate:
-						default:
-							if time.Since(lastDropLogTime) >= FullQueueLogFrequency {
-								logger.Error().Msg("dropped incoming price update - too many updates")
-								lastDropLogTime = time.Now()
-							}
-						}
+					if sendValueUpdate(*valueUpdate, valueUpdateChannels) > 0 &&
+						time.Since(lastDropLogTime) >= FullQueueLogFrequency {
+						logger.Error().Msg("dropped incoming price update - too many updates")
+						lastDropLogTime = time.Now()
 					}
 				}
 			}
@@ -227,18 +222,21 @@ type OutgoingWebsocketConnection[T shared.Signature] struct {
 	removed                  bool
 	logger                   zerolog.Logger
 	signedPriceUpdateBatchCh chan SignedPriceUpdateBatch[T]
+	onSent                   func()
 }
 
 func NewOutgoingWebsocketConnection[T shared.Signature](
 	conn WebsocketConnection,
 	assets *OutgoingWebsocketConnectionAssets[T],
 	logger zerolog.Logger,
+	onSent func(),
 ) *OutgoingWebsocketConnection[T] {
 	return &OutgoingWebsocketConnection[T]{
 		WebsocketConnection:      conn,
 		assets:                   assets,
 		signedPriceUpdateBatchCh: make(chan SignedPriceUpdateBatch[T], 4096),
 		logger:                   logger,
+		onSent:                   onSent,
 	}
 }
 
@@ -274,6 +272,8 @@ func (owc *OutgoingWebsocketConnection[T]) Writer() {
 				err = SendWebsocketMsg(owc.conn, "signed_prices", filteredPriceUpdates, "", "", logger)
 				if err != nil {
 					logger.Warn().Err(err).Msg("failed to send signed prices")
+				} else {
+					owc.onSent()
 				}
 			}
 		case _ = <-owc.closed:
@@ -0,0 +1,56 @@
+package evm
+
+import (
+	"errors"
+
+	"github.com/Stork-Oracle/stork-external/shared"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var ErrNoMerkleLeaves = errors.New("no leaves provided")
+
+// PublisherPriceMessageHash returns the keccak256 hash of a publisher price payload. This is the
+// leaf used for the publisher merkle root, and matches getPublisherMessageHash in StorkVerify.sol.
+func PublisherPriceMessageHash(
+	publishTimestampNano int64,
+	externalAssetID string,
+	quantizedValue string,
+	publisherKey shared.PublisherKey,
+) common.Hash {
+	payload := getPublisherPricePayload(
+		publishTimestampNano,
+		quantizedValue,
+		externalAssetID,
+		common.HexToAddress(string(publisherKey)),
+	)
+	payloadHash, _ := getHashes(payload)
+
+	return payloadHash
+}
+
+// ComputeMerkleRoot computes the publisher merkle root the same way as computeMerkleRoot in StorkVerify.sol:
+// levels with an odd number of nodes duplicate their last node, and parents are keccak256(left || right).
+func ComputeMerkleRoot(leaves []common.Hash) (common.Hash, error) {
+	if len(leaves) == 0 {
+		return common.Hash{}, ErrNoMerkleLeaves
+	}
+
+	level := make([]common.Hash, len(leaves))
+	copy(level, leaves)
+
+	for len(level) > 1 {
+		if len(level)%2 != 0 {
+			level = append(level, level[len(level)-1])
+		}
+
+		next := make([]common.Hash, len(level)/2)
+		for i := 0; i < len(level); i += 2 {
+			next[i/2] = crypto.Keccak256Hash(level[i].Bytes(), level[i+1].Bytes())
+		}
+
+		level = next
+	}
+
+	return level[0], nil
+}
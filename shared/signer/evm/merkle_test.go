@@ -0,0 +1,48 @@
+package evm
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeMerkleRoot(t *testing.T) {
+	a := common.HexToHash("0x01")
+	b := common.HexToHash("0x02")
+	c := common.HexToHash("0x03")
+
+	_, err := ComputeMerkleRoot(nil)
+	assert.ErrorIs(t, err, ErrNoMerkleLeaves)
+
+	root, err := ComputeMerkleRoot([]common.Hash{a})
+	require.NoError(t, err)
+	assert.Equal(t, a, root)
+
+	root, err = ComputeMerkleRoot([]common.Hash{a, b})
+	require.NoError(t, err)
+	assert.Equal(t, crypto.Keccak256Hash(a.Bytes(), b.Bytes()), root)
+
+	// odd levels duplicate the last node
+	ab := crypto.Keccak256Hash(a.Bytes(), b.Bytes())
+	cc := crypto.Keccak256Hash(c.Bytes(), c.Bytes())
+	root, err = ComputeMerkleRoot([]common.Hash{a, b, c})
+	require.NoError(t, err)
+	assert.Equal(t, crypto.Keccak256Hash(ab.Bytes(), cc.Bytes()), root)
+}
+
+func TestPublisherPriceMessageHashMatchesSignedMsgHash(t *testing.T) {
+	signer, err := NewSigner("0x8b558d5fc31eb64bb51d44b4b28658180e96764d5d5ac68e6d124f86f576d9de", zerolog.Nop())
+	require.NoError(t, err)
+
+	for _, value := range []string{"100002000000000000000000", "-17725899000000"} {
+		timestampedSignature, _, err := signer.SignPublisherPrice(1757543080505451010, "BTCUSD", value)
+		require.NoError(t, err)
+
+		hash := PublisherPriceMessageHash(1757543080505451010, "BTCUSD", value, signer.GetPublisherKey())
+		assert.Equal(t, timestampedSignature.MsgHash, hash.Hex())
+	}
+}